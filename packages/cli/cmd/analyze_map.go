@@ -0,0 +1,276 @@
+// Package cmd ...
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	analyzeMapSamples int
+	analyzeMapIn      string
+	analyzeMapSVG     string
+)
+
+// verdict thresholds for the text report
+const (
+	uniformCV      = 0.25 // largest ΔE00 step variation for a uniform map
+	cvdRetained    = 0.5  // smallest share of ΔE00 kept under a deficiency
+	grayPrintRange = 30.0 // smallest luma range that prints distinguishably
+)
+
+// analyzeMapCmd represents the analyze-map command
+var analyzeMapCmd = &cobra.Command{
+	Use:   "analyze-map <map.json>",
+	Short: "Judge a colormap's uniformity, lightness, CVD robustness and print",
+	Long: `Analyze a colormap and report:
+  uniformity   ΔE00 between adjacent samples (mean, min, max and its
+               coefficient of variation; lower is more uniform)
+  lightness    whether CIE L* runs one way along the map
+  cvd          share of the map's ΔE00 kept under simulated protan,
+               deutan and tritan vision, and the smallest step
+  gray         whether the Rec. 601 gray-scale (as printers and
+               copiers convert) stays ordered, and its range
+
+The map is JSON: an array of CSS colors, an array of [r, g, b] in 0–1
+(or 0–255), or an object with a "colors" or "range" array (Vega-Lite
+"scale" objects too). A file with one color per line works as well;
+"-" reads stdin. --samples resamples the map, interpolating in --in,
+which helps with maps given as a few control colors.
+
+--svg writes a diagnostic chart: the map under normal vision, in
+gray-scale and under each deficiency, above a plot of L*, C* and hue.
+
+The command exits with status 1 when the map cannot be read or
+analyzed.
+
+Example:
+  colors-cli analyze-map viridis.json
+  colors-cli analyze-map map.json --samples 256 --svg map.svg
+  colors-cli dataviz --type sequential --format vega | colors-cli analyze-map - --output json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		stops, err := loadColormap(args[0])
+		if err != nil {
+			fmt.Println("Error (Map):", err)
+			os.Exit(1)
+		}
+		if analyzeMapSamples > 0 {
+			if stops, err = resampleColormap(stops, analyzeMapSamples); err != nil {
+				fmt.Println("Error (Samples):", err)
+				os.Exit(1)
+			}
+		}
+		rep, err := colors.AnalyzeColormap(stops)
+		if err != nil {
+			fmt.Println("Error (Analyze):", err)
+			os.Exit(1)
+		}
+
+		if analyzeMapSVG != "" {
+			f, err := os.Create(analyzeMapSVG)
+			if err != nil {
+				fmt.Println("Error (SVG):", err)
+				os.Exit(1)
+			}
+			err = rep.WriteSVG(f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				fmt.Println("Error (SVG):", err)
+				os.Exit(1)
+			}
+		}
+
+		if machineOutput() {
+			emit(analyzeMapResult{rep})
+			return
+		}
+		printColormapReport(rep)
+	},
+}
+
+// analyzeMapResult renders a report as itself in json/yaml and as one
+// row per sample in csv/tsv.
+type analyzeMapResult struct {
+	colors.ColormapReport `yaml:",inline"`
+}
+
+func (a analyzeMapResult) table() ([]string, [][]string) {
+	records := make([]*record, len(a.Samples))
+	for i, s := range a.Samples {
+		records[i] = newRecord(
+			"index", i+1, "hex", s.Color.MapToSRGB().Hex(), "l", round(s.L, 2), "c", round(s.C, 2), "h", round(s.H, 2),
+			"gray", round(s.Gray, 2), "delta_e00", round(s.DeltaE, 4),
+		)
+	}
+	header, rows, _ := recordsTable(records)
+	return header, rows
+}
+
+func printColormapReport(rep colors.ColormapReport) {
+	verdict := func(ok bool) string {
+		if ok {
+			return "ok"
+		}
+		return "poor"
+	}
+	direction := func(t colors.ColormapTrend) string {
+		switch {
+		case t.Monotonic && t.Direction > 0:
+			return "monotonic, rising"
+		case t.Monotonic:
+			return "monotonic, falling"
+		case t.Direction == 0:
+			return fmt.Sprintf("not monotonic, ends level, %d reversals", t.Reversals)
+		}
+		return fmt.Sprintf("not monotonic, %d reversals", t.Reversals)
+	}
+
+	u := rep.Uniformity
+	first, last := rep.Samples[0], rep.Samples[len(rep.Samples)-1]
+	fmt.Printf("Samples    : %d\n", len(rep.Samples))
+	fmt.Printf("Uniformity : ΔE00 per step mean %.2f, min %.2f, max %.2f, CV %.2f [%s]\n",
+		u.Mean, u.Min, u.Max, u.CV, verdict(u.CV <= uniformCV))
+	fmt.Printf("Lightness  : %s, L* %.1f → %.1f [%s]\n",
+		direction(rep.Lightness), first.L, last.L, verdict(rep.Lightness.Monotonic))
+	for _, c := range rep.CVD {
+		fmt.Printf("%-11s: keeps %.0f%% of ΔE00, min step %.2f, lightness %s [%s]\n",
+			"CVD "+c.CVD.String(), c.Retained*100, c.MinStep, direction(c.Lightness),
+			verdict(c.Retained >= cvdRetained && c.Lightness.Monotonic))
+	}
+	fmt.Printf("Gray-scale : %s, range %.1f [%s]\n",
+		direction(rep.Gray), rep.Gray.Range, verdict(rep.Gray.Monotonic && rep.Gray.Range >= grayPrintRange))
+}
+
+// loadColormap reads a colormap from a JSON file (see the command help)
+// or a file with one color per line; "-" reads stdin.
+func loadColormap(path string) ([]colors.Color, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if json.Unmarshal(data, &doc) != nil {
+		var out []colors.Color
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			c, err := colors.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, c)
+		}
+		return out, scanner.Err()
+	}
+
+	// unwrap {"scale": {"range": [...]}}, {"colors": [...]} and {"range": [...]}
+	for {
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			break
+		}
+		switch {
+		case obj["scale"] != nil:
+			doc = obj["scale"]
+		case obj["colors"] != nil:
+			doc = obj["colors"]
+		case obj["range"] != nil:
+			doc = obj["range"]
+		default:
+			return nil, errors.New(`expected a "colors" or "range" array`)
+		}
+	}
+	list, ok := doc.([]any)
+	if !ok {
+		return nil, errors.New("expected an array of colors")
+	}
+
+	// numeric colors are 0–1 unless any channel is above 1
+	scale := 1.0
+	for _, item := range list {
+		if v, ok := item.([]any); ok {
+			for _, x := range v[:min(len(v), 3)] {
+				if f, ok := x.(float64); ok && f > 1 {
+					scale = 255
+				}
+			}
+		}
+	}
+
+	out := make([]colors.Color, len(list))
+	for i, item := range list {
+		switch v := item.(type) {
+		case string:
+			c, err := colors.Parse(v)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = c
+		case []any:
+			if len(v) < 3 || len(v) > 4 {
+				return nil, fmt.Errorf("color %d: expected [r, g, b] or [r, g, b, a]", i+1)
+			}
+			ch := make([]float64, len(v))
+			for j, x := range v {
+				f, ok := x.(float64)
+				if !ok {
+					return nil, fmt.Errorf("color %d: %v is not a number", i+1, x)
+				}
+				ch[j] = f
+			}
+			out[i] = colors.NewColor(colors.SpaceSRGB, ch[0]/scale, ch[1]/scale, ch[2]/scale)
+			if len(ch) == 4 {
+				out[i].Alpha = ch[3]
+			}
+		default:
+			return nil, fmt.Errorf("color %d: expected a string or an [r, g, b] array", i+1)
+		}
+	}
+	return out, nil
+}
+
+// resampleColormap interpolates n evenly spaced colors through the map.
+func resampleColormap(stops []colors.Color, n int) ([]colors.Color, error) {
+	space, err := colors.ParseSpace(analyzeMapIn)
+	if err != nil {
+		return nil, err
+	}
+	scale, err := colors.NewScale(stops, colors.ScaleOptions{Space: space})
+	if err != nil {
+		return nil, err
+	}
+	return scale.Colors(n), nil
+}
+
+func init() {
+	rootCmd.AddCommand(analyzeMapCmd)
+
+	analyzeMapCmd.Flags().IntVar(&analyzeMapSamples, "samples", 0, "Resample the map to this many colors (0 keeps it as given)")
+	analyzeMapCmd.Flags().StringVar(&analyzeMapIn, "in", "srgb", "Interpolation space for --samples")
+	analyzeMapCmd.Flags().StringVar(&analyzeMapSVG, "svg", "", "Write a diagnostic SVG chart to this file")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCLI runs the command line in a child process, since errors exit,
+// and returns its stdout and exit status.
+func runCLI(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCLIChild$")
+	cmd.Env = append(os.Environ(), "COLORS_CLI_CHILD=1", "COLORS_CLI_ARGS="+strings.Join(args, "\x1f"))
+	out, err := cmd.Output()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return string(out), exit.ExitCode()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out), 0
+}
+
+// TestCLIChild is the child side of runCLI.
+func TestCLIChild(t *testing.T) {
+	if os.Getenv("COLORS_CLI_CHILD") != "1" {
+		t.Skip("run by runCLI")
+	}
+	rootCmd.SetArgs(strings.Split(os.Getenv("COLORS_CLI_ARGS"), "\x1f"))
+	Execute()
+}
+
+func writeMap(t *testing.T, colors ...string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "map.json")
+	if err := os.WriteFile(file, []byte(`["`+strings.Join(colors, `", "`)+`"]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestAnalyzeMapVerdicts(t *testing.T) {
+	viridis := writeMap(t, "#440154", "#482475", "#414487", "#355F8D", "#2A788E", "#21918C",
+		"#22A884", "#44BF70", "#7AD151", "#BDDF26", "#FDE725")
+	out, status := runCLI(t, "analyze-map", viridis)
+	if status != 0 || !strings.Contains(out, "Lightness  : monotonic, rising") || strings.Contains(out, "[poor]") {
+		t.Errorf("viridis: status %d\n%s", status, out)
+	}
+
+	jet := writeMap(t, "#00007F", "#0000FF", "#007FFF", "#00FFFF", "#7FFF7F", "#FFFF00", "#FF7F00", "#FF0000", "#7F0000")
+	out, status = runCLI(t, "analyze-map", jet)
+	if status != 0 || !strings.Contains(out, "Lightness  : not monotonic") {
+		t.Errorf("jet: status %d\n%s", status, out)
+	}
+}
+
+func TestAnalyzeMapErrorsExit(t *testing.T) {
+	for name, args := range map[string][]string{
+		"single color": {"analyze-map", writeMap(t, "#440154")},
+		"missing file": {"analyze-map", filepath.Join(t.TempDir(), "none.json")},
+		"bad color":    {"analyze-map", writeMap(t, "#440154", "nope")},
+	} {
+		out, status := runCLI(t, args...)
+		if status == 0 || !strings.HasPrefix(out, "Error (") {
+			t.Errorf("%s: status %d, output %q", name, status, out)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Package cmd ...
+package cmd
+
+import (
+	"bufio"
+	"colors-cli/utils/colors"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// batchItem is one input color and the 1-based line it came from.
+type batchItem struct {
+	line int
+	text string
+	err  error
+	out  chan conversion
+}
+
+// convertBatch converts every color read from r with a pool of workers
+// and writes the results in input order. A bad line becomes an error
+// result; it never stops the batch.
+func convertBatch(r io.Reader, targets []string, column string, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan *batchItem)
+	// pending holds the items in input order; its capacity bounds how
+	// far the workers may run ahead of the writer.
+	pending := make(chan *batchItem, workers*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				item.out <- convertItem(item, targets)
+			}
+		}()
+	}
+
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		readErr <- readBatch(r, column, func(item *batchItem) {
+			item.out = make(chan conversion, 1)
+			pending <- item
+			jobs <- item
+		})
+	}()
+
+	out := newStream(os.Stdout)
+	total, failed := 0, 0
+	for item := range pending {
+		conv := <-item.out
+		total++
+		if conv.Error != "" {
+			failed++
+		}
+		if machineOutput() {
+			if err := out.write(conv); err != nil {
+				return err
+			}
+			continue
+		}
+		printBatchConversion(conv)
+	}
+	wg.Wait()
+
+	if machineOutput() {
+		if err := out.close(); err != nil {
+			return err
+		}
+	} else if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d colors failed\n", failed, total)
+	}
+	return <-readErr
+}
+
+// convertItem parses one batch entry and converts it to every target.
+func convertItem(item *batchItem, targets []string) conversion {
+	if item.err != nil {
+		return conversion{Line: item.line, Input: item.text, Error: item.err.Error(), Results: []targetResult{}}
+	}
+	c, err := colors.Parse(item.text)
+	if err != nil {
+		return conversion{Line: item.line, Input: item.text, Error: err.Error(), Results: []targetResult{}}
+	}
+	conv := newConversion(item.text, c, targets)
+	conv.Line = item.line
+	return conv
+}
+
+func printBatchConversion(conv conversion) {
+	if conv.Error != "" {
+		fmt.Printf("Error (Line %d): %s\n", conv.Line, conv.Error)
+		return
+	}
+	fmt.Printf("Input  : %s\n", conv.Input)
+	conv.print()
+	fmt.Println()
+}
+
+// readBatch calls emit for each non-blank line, or for each row's value
+// in column when reading CSV. A column given by name is looked up in
+// the header row; a 1-based index reads every row as data. A row whose
+// cell is empty or missing is emitted with an error.
+func readBatch(r io.Reader, column string, emit func(*batchItem)) error {
+	if column == "" {
+		scanner := bufio.NewScanner(r)
+		line := 0
+		for scanner.Scan() {
+			line++
+			if text := strings.TrimSpace(scanner.Text()); text != "" {
+				emit(&batchItem{line: line, text: text})
+			}
+		}
+		return scanner.Err()
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	index, err := strconv.Atoi(column)
+	byName := err != nil
+	index--
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if pe := (*csv.ParseError)(nil); errors.As(err, &pe) {
+			emit(&batchItem{line: pe.StartLine, err: pe.Err})
+			continue
+		} else if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if byName {
+			byName = false
+			index = -1
+			for i, name := range record {
+				if strings.EqualFold(strings.TrimSpace(name), column) {
+					index = i
+				}
+			}
+			if index < 0 {
+				return fmt.Errorf("no column %q in the CSV header", column)
+			}
+			continue
+		}
+		if index < 0 || index >= len(record) {
+			emit(&batchItem{line: line, err: fmt.Errorf("row has no column %s", column)})
+			continue
+		}
+		text := strings.TrimSpace(record[index])
+		if text == "" {
+			emit(&batchItem{line: line, err: fmt.Errorf("empty cell in column %s", column)})
+			continue
+		}
+		emit(&batchItem{line: line, text: text})
+	}
+}
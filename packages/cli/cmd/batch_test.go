@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout returns what f writes to os.Stdout.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	defer func() { os.Stdout = prev }()
+	f()
+	w.Close()
+	return <-done
+}
+
+func collectBatch(t *testing.T, input, column string) []*batchItem {
+	t.Helper()
+	var items []*batchItem
+	if err := readBatch(strings.NewReader(input), column, func(it *batchItem) { items = append(items, it) }); err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+func TestReadBatchLines(t *testing.T) {
+	items := collectBatch(t, "#ff0000\n\n  red  \n\t\nnotacolor\n", "")
+	want := []batchItem{{line: 1, text: "#ff0000"}, {line: 3, text: "red"}, {line: 5, text: "notacolor"}}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d", len(items), len(want))
+	}
+	for i, w := range want {
+		if items[i].line != w.line || items[i].text != w.text || items[i].err != nil {
+			t.Errorf("item %d = %+v, want %+v", i, *items[i], w)
+		}
+	}
+}
+
+func TestReadBatchCSV(t *testing.T) {
+	const data = "name,color\nbrick,#b22222\nshort\nsky, skyblue\nbad,\"un\"closed\"\nblank, \n"
+
+	byName := collectBatch(t, data, "Color")
+	if len(byName) != 5 {
+		t.Fatalf("by name: %d items", len(byName))
+	}
+	if byName[0].text != "#b22222" || byName[0].line != 2 {
+		t.Errorf("first item = %+v", *byName[0])
+	}
+	if byName[1].err == nil || byName[1].line != 3 {
+		t.Errorf("short row should be an error on line 3: %+v", *byName[1])
+	}
+	if byName[2].text != "skyblue" || byName[2].line != 4 {
+		t.Errorf("third item = %+v", *byName[2])
+	}
+	if byName[3].err == nil || byName[3].line != 5 {
+		t.Errorf("malformed row should be an error on line 5: %+v", *byName[3])
+	}
+	if byName[4].err == nil || byName[4].line != 6 {
+		t.Errorf("empty cell should be an error on line 6: %+v", *byName[4])
+	}
+
+	// a 1-based index reads the header as data too
+	byIndex := collectBatch(t, "#000000,x\n#ffffff,y\n", "1")
+	if len(byIndex) != 2 || byIndex[0].text != "#000000" || byIndex[1].line != 2 {
+		t.Errorf("by index = %+v", byIndex)
+	}
+
+	if err := readBatch(strings.NewReader(data), "hex", func(*batchItem) {}); err == nil {
+		t.Error("expected an error for a missing header column")
+	}
+}
+
+// With many workers the results still come out in input order, and a
+// bad line becomes an error result without stopping the batch.
+func TestConvertBatchOrder(t *testing.T) {
+	withOutput(t, "json")
+
+	var in strings.Builder
+	const n = 300
+	for i := 0; i < n; i++ {
+		switch {
+		case i%50 == 7:
+			in.WriteString("notacolor\n")
+		case i%40 == 3:
+			in.WriteString("\n")
+		default:
+			fmt.Fprintf(&in, "#%02x%02x%02x\n", i%256, (i*7)%256, (i*13)%256)
+		}
+	}
+
+	var err error
+	out := captureStdout(t, func() {
+		err = convertBatch(strings.NewReader(in.String()), []string{"hex"}, "", 8)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var convs []conversion
+	if err := json.Unmarshal([]byte(out), &convs); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	lines := strings.Split(in.String(), "\n")
+	k := 0
+	for i := 0; i < n; i++ {
+		text := lines[i]
+		if text == "" {
+			continue
+		}
+		if k >= len(convs) {
+			t.Fatalf("only %d results", len(convs))
+		}
+		c := convs[k]
+		k++
+		if c.Line != i+1 || c.Input != text {
+			t.Fatalf("result %d = line %d %q, want line %d %q", k, c.Line, c.Input, i+1, text)
+		}
+		if text == "notacolor" {
+			if c.Error == "" {
+				t.Errorf("line %d: expected an error", i+1)
+			}
+			continue
+		}
+		if c.Error != "" || len(c.Results) != 1 || !strings.EqualFold(c.Results[0].Value, text) {
+			t.Errorf("line %d: %+v", i+1, c)
+		}
+	}
+	if k != len(convs) {
+		t.Errorf("%d results, want %d", len(convs), k)
+	}
+}
+
+func TestConvertBatchEmpty(t *testing.T) {
+	withOutput(t, "json")
+	out := captureStdout(t, func() {
+		if err := convertBatch(strings.NewReader("\n\n"), []string{"hex"}, "", 4); err != nil {
+			t.Error(err)
+		}
+	})
+	if strings.TrimSpace(out) != "[\n]" {
+		t.Errorf("empty batch = %q", out)
+	}
+}
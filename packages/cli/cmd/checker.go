@@ -0,0 +1,209 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register JPEG decoding
+	_ "image/png"  // register PNG decoding
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkerGrid   string
+	checkerSample float64
+)
+
+// checkerCmd represents the checker command
+var checkerCmd = &cobra.Command{
+	Use:   "checker <photo.png|photo.jpg>",
+	Short: "Evaluate a photographed 24-patch ColorChecker",
+	Long: `Sample the 24 patches of a classic ColorChecker from a PNG or JPEG,
+convert them from sRGB to Lab D50 and report ΔE00 against the chart's
+reference values, then fit a 3×3 matrix in linear RGB that best maps
+the camera values to the reference.
+
+--grid is the pixel centre of the first patch (dark skin, top left)
+and of the last patch (black, bottom right): x0,y0,x1,y1. --sample is
+the size of the averaged square as a fraction of the patch pitch.
+
+Example:
+  colors-cli checker photo.png --grid 112,96,1480,980`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		grid, err := parseGrid(checkerGrid)
+		if err != nil {
+			fmt.Println("Error (Grid) :", err)
+			return
+		}
+		if checkerSample <= 0 || checkerSample > 1 {
+			fmt.Println("Error (Sample): --sample must be in (0, 1]")
+			return
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			fmt.Println("Error (Image):", err)
+			return
+		}
+		img, _, err := image.Decode(f)
+		f.Close()
+		if err != nil {
+			fmt.Println("Error (Image):", err)
+			return
+		}
+
+		measured, err := sampleChecker(img, grid, checkerSample)
+		if err != nil {
+			fmt.Println("Error (Sample):", err)
+			return
+		}
+
+		reference := make([][3]float64, len(colors.ColorChecker24))
+		for i, p := range colors.ColorChecker24 {
+			reference[i] = colors.LabToLinearRGB(p.Lab, colors.WhiteD50)
+		}
+		M, err := colors.FitColorMatrix(measured, reference)
+		if err != nil {
+			fmt.Println("Error (Matrix):", err)
+			return
+		}
+
+		var before, after []float64
+		patches := make([]*record, 0, len(colors.ColorChecker24))
+		if !machineOutput() {
+			fmt.Printf("%-3s %-14s %-22s %-22s %6s %6s\n", "#", "Patch", "Measured Lab", "Reference Lab", "ΔE00", "Fixed")
+		}
+		for i, p := range colors.ColorChecker24 {
+			lab := colors.LinearRGBToLab(measured[i], colors.WhiteD50)
+			fixed := colors.LinearRGBToLab(colors.ApplyMatrix(M, measured[i]), colors.WhiteD50)
+			d0 := colors.DeltaE2000(lab, p.Lab)
+			d1 := colors.DeltaE2000(fixed, p.Lab)
+			before, after = append(before, d0), append(after, d1)
+			if machineOutput() {
+				patches = append(patches, newRecord(
+					"patch", i+1,
+					"name", p.Name,
+					"measured", roundAll([]float64{lab.L, lab.A, lab.B}, 4),
+					"reference", []float64{p.Lab.L, p.Lab.A, p.Lab.B},
+					"delta_e", round(d0, 4),
+					"fixed", round(d1, 4),
+				))
+				continue
+			}
+			fmt.Printf("%-3d %-14s %6.2f %7.2f %7.2f  %6.2f %7.2f %7.2f  %6.2f %6.2f\n",
+				i+1, p.Name, lab.L, lab.A, lab.B, p.Lab.L, p.Lab.A, p.Lab.B, d0, d1)
+		}
+
+		if machineOutput() {
+			matrix := make([][]float64, len(M))
+			for i, row := range M {
+				matrix[i] = roundAll(row[:], 6)
+			}
+			emit(listing{newRecord(
+				"patches", patches,
+				"delta_e", newRecord("avg", round(mean(before), 4), "max", round(maxOf(before), 4)),
+				"fixed", newRecord("avg", round(mean(after), 4), "max", round(maxOf(after), 4)),
+				"matrix", matrix,
+			), patches})
+			return
+		}
+
+		fmt.Println()
+		fmt.Printf("ΔE00   : avg %.2f, max %.2f\n", mean(before), maxOf(before))
+		fmt.Printf("Fixed  : avg %.2f, max %.2f\n", mean(after), maxOf(after))
+		fmt.Println("Matrix (linear RGB, camera → reference):")
+		for _, row := range M {
+			fmt.Printf("  [%8.4f %8.4f %8.4f]\n", row[0], row[1], row[2])
+		}
+	},
+}
+
+// parseGrid reads x0,y0,x1,y1.
+func parseGrid(s string) ([4]float64, error) {
+	var g [4]float64
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return g, errors.New("expected x0,y0,x1,y1")
+	}
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return g, fmt.Errorf("invalid coordinate %q", p)
+		}
+		g[i] = v
+	}
+	return g, nil
+}
+
+// sampleChecker averages each patch in linear sRGB. Patches are laid
+// out in 6 columns and 4 rows between the two grid centres.
+func sampleChecker(img image.Image, grid [4]float64, sample float64) ([][3]float64, error) {
+	dx, dy := (grid[2]-grid[0])/5, (grid[3]-grid[1])/3
+	half := sample * math.Min(math.Abs(dx), math.Abs(dy)) / 2
+	if half < 0.5 {
+		half = 0.5
+	}
+
+	bounds := img.Bounds()
+	out := make([][3]float64, 0, 24)
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 6; col++ {
+			cx, cy := grid[0]+float64(col)*dx, grid[1]+float64(row)*dy
+			x0, x1 := int(math.Round(cx-half)), int(math.Round(cx+half))
+			y0, y1 := int(math.Round(cy-half)), int(math.Round(cy+half))
+			r := image.Rect(x0, y0, x1, y1).Intersect(bounds)
+			if r.Empty() {
+				return nil, fmt.Errorf("patch %d at (%.0f, %.0f) is outside the image", row*6+col+1, cx, cy)
+			}
+
+			var sum [3]float64
+			for y := r.Min.Y; y < r.Max.Y; y++ {
+				for x := r.Min.X; x < r.Max.X; x++ {
+					c := color.NRGBA64Model.Convert(img.At(x, y)).(color.NRGBA64)
+					sum[0] += colors.TransferSRGB.Decode(float64(c.R) / 0xffff)
+					sum[1] += colors.TransferSRGB.Decode(float64(c.G) / 0xffff)
+					sum[2] += colors.TransferSRGB.Decode(float64(c.B) / 0xffff)
+				}
+			}
+			n := float64(r.Dx() * r.Dy())
+			out = append(out, [3]float64{sum[0] / n, sum[1] / n, sum[2] / n})
+		}
+	}
+	return out, nil
+}
+
+func mean(v []float64) float64 {
+	s := 0.0
+	for _, x := range v {
+		s += x
+	}
+	return s / float64(len(v))
+}
+
+func maxOf(v []float64) float64 {
+	m := 0.0
+	for _, x := range v {
+		m = math.Max(m, x)
+	}
+	return m
+}
+
+func init() {
+	rootCmd.AddCommand(checkerCmd)
+
+	checkerCmd.Flags().StringVar(&checkerGrid, "grid", "", "Centres of the first and last patch: x0,y0,x1,y1")
+	checkerCmd.Flags().Float64Var(&checkerSample, "sample", 0.5, "Sampled square size as a fraction of the patch pitch")
+	_ = checkerCmd.MarkFlagRequired("grid")
+}
@@ -0,0 +1,60 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// cmykCmd represents the CMYK-to-other-color-spaces command
+var cmykCmd = &cobra.Command{
+	Use:   "cmyk [c m y k]",
+	Short: "Convert CMYK color to HEX, RGB, HSL, HCL, and OKLCH",
+	Long: `Convert a CMYK color (0–100 each, naive device-independent formula) to
+multiple color spaces:
+- HEX (Hexadecimal)
+- RGB (Red, Green, Blue)
+- HSL (Hue, Saturation, Lightness)
+- HCL (Hue, Chroma, Lightness)
+- OKLCH (Lightness, Chroma, Hue)
+
+The CMYK line shows the color separated again with the --gcr, --ucr,
+--tac, --rich-black and --dot-gain options, as percentages (0–100).
+
+Example:
+  colors-cli cmyk 0 66 80 0
+  colors-cli cmyk "cmyk(0, 66, 80, 0)"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		// Read CMYK input (arguments, or prompts)
+		input, err := readInput(args, "C (0–100): ", "M (0–100): ", "Y (0–100): ", "K (0–100): ")
+		if err != nil {
+			failConversion("Error (Input):", "", err)
+			return
+		}
+		values, err := parseNumbers(input, 4)
+		if err != nil {
+			failConversion("Error (CMYK) :", strings.Join(input, " "), err)
+			return
+		}
+
+		// CMYK → RGB
+		cmyk := colors.CMYK{C: values[0], M: values[1], Y: values[2], K: values[3]}
+		r, g, b, err := cmyk.ToRGB()
+		if err != nil {
+			failConversion("Error (RGB)  :", strings.Join(input, " "), err)
+			return
+		}
+		printColorSpaces(input, colors.RGB{R: r, G: g, B: b})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cmykCmd)
+
+	addCMYKFlags(cmykCmd)
+}
@@ -0,0 +1,60 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cmykGCR       string
+	cmykUCR       bool
+	cmykTAC       float64
+	cmykRichBlack bool
+	cmykDotGain   float64
+)
+
+// addCMYKFlags registers the separation options on a command that
+// prints CMYK.
+func addCMYKFlags(c *cobra.Command) {
+	c.Flags().StringVar(&cmykGCR, "gcr", "max", "Black generation (none, light, medium, heavy, max)")
+	c.Flags().BoolVar(&cmykUCR, "ucr", false, "Use under color removal (neutral shadows only)")
+	c.Flags().Float64Var(&cmykTAC, "tac", 0, "Total area coverage limit in % (e.g. 300)")
+	c.Flags().BoolVar(&cmykRichBlack, "rich-black", false, "Separate pure black as a rich black")
+	c.Flags().Float64Var(&cmykDotGain, "dot-gain", 0, "Midtone dot gain in % to compensate")
+}
+
+// cmykOptions collects the separation flags.
+func cmykOptions() (colors.CMYKOptions, error) {
+	gcr, err := colors.ParseBlackGeneration(cmykGCR)
+	if err != nil {
+		return colors.CMYKOptions{}, err
+	}
+	return colors.CMYKOptions{
+		GCR:       gcr,
+		UCR:       cmykUCR,
+		TAC:       cmykTAC,
+		RichBlack: cmykRichBlack,
+		DotGain:   cmykDotGain,
+	}, nil
+}
+
+// printCMYK separates an RGB color with the current flags and prints it
+// with full ink as scale: 100, or 1 for the hex command, which has
+// always printed fractions.
+func printCMYK(rgb colors.RGB, scale float64) {
+	opts, err := cmykOptions()
+	if err != nil {
+		fmt.Println("Error (CMYK) :", err)
+		return
+	}
+	cmyk, err := rgb.ToCMYKWith(opts)
+	if err != nil {
+		fmt.Println("Error (CMYK) :", err)
+		return
+	}
+	k := scale / 100
+	fmt.Printf("CMYK   : C=%.3f, M=%.3f, Y=%.3f, K=%.3f\n", cmyk.C*k, cmyk.M*k, cmyk.Y*k, cmyk.K*k)
+}
@@ -0,0 +1,450 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"colors-cli/utils/icc"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertTo          string
+	convertFromProfile string
+	convertToProfile   string
+	convertIntent      string
+	convertStdin       bool
+	convertFile        string
+	convertColumn      string
+	convertWorkers     int
+)
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert [color]",
+	Short: "Convert any CSS color to other color spaces or ICC profiles",
+	Long: `Convert a color written in any CSS syntax to one or more spaces:
+- #RGB, #RRGGBB(AA) and named colors
+- rgb(), hsl(), hwb(), lab(), lch(), oklab(), oklch()
+- color(srgb|srgb-linear|display-p3|a98-rgb|prophoto-rgb|rec2020|xyz-d50|xyz-d65 ...)
+- hcl(h c l) and cmyk(c m y k) / device-cmyk(...)
+
+--to takes a comma-separated list of: hex, rgb, hsl, hwb, lab, lch,
+oklab, oklch, hcl, cmyk, srgb-linear, display-p3, a98-rgb,
+prophoto-rgb, rec2020, xyz-d65, xyz-d50. Values outside a bounded
+target's gamut are clipped and marked. The cmyk target honours the
+--gcr, --ucr, --tac, --rich-black and --dot-gain options.
+
+With --from-profile the input is read as device values of that ICC
+profile's color space instead:
+- RGB : #RRGGBB, rgb(r, g, b) or r,g,b (0–255)
+- CMYK: cmyk(c, m, y, k) or c,m,y,k (0–100)
+- GRAY: g (0–100)
+and converted through the profile connection space (PCS) to sRGB or,
+with --to-profile, to the destination profile. --to-profile alone
+converts a CSS color into that profile. Matrix/TRC and LUT-based
+(A2B/B2A) profiles, v2 and v4, are supported.
+
+Intents: perceptual, relative, saturation, absolute.
+
+--stdin and --file convert one color per line, or with --column one
+column of a CSV file (a header name, or a 1-based index when the file
+has no header). Colors are converted concurrently by --workers workers
+and written in input order; a line that fails is reported with its
+line number and the batch carries on.
+
+Example:
+  colors-cli convert "#FF5733" --to hex,oklch,cmyk
+  colors-cli convert "oklch(70% 0.15 200 / 50%)" --to rgb,display-p3
+  colors-cli convert "cmyk(0, 100, 100, 0)" --from-profile FOGRA39.icc --to-profile sRGB.icc
+  colors-cli convert "#FF5733" --to-profile FOGRA39.icc --intent relative
+  colors-cli convert --file tokens.txt --to hex,oklch --output json
+  cat tokens.csv | colors-cli convert --stdin --column value --output csv`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		if convertStdin || convertFile != "" {
+			if err := runConvertBatch(args); err != nil {
+				fmt.Println("Error (Batch):", err)
+			}
+			return
+		}
+
+		input, err := readInput(args, "Color: ")
+		if err != nil {
+			failConversion("Error (Input):", "", err)
+			return
+		}
+		text := strings.Join(input, " ")
+
+		if convertFromProfile != "" {
+			convertProfiles(text)
+			return
+		}
+
+		c, err := colors.Parse(text)
+		if err != nil {
+			failConversion("Error (Input):", text, err)
+			return
+		}
+
+		if convertToProfile != "" {
+			intent, err := icc.ParseIntent(convertIntent)
+			if err != nil {
+				failConversion("Error (Intent):", text, err)
+				return
+			}
+			dst, err := icc.Open(convertToProfile)
+			if err != nil {
+				failConversion("Error (Profile):", text, err)
+				return
+			}
+			xyz := c.XYZ().Adapt(colors.WhiteD65, icc.D50)
+			out, err := dst.FromPCS(xyz, intent)
+			if err != nil {
+				failConversion("Error (Device):", text, err)
+				return
+			}
+			printDeviceConversion(text, xyz.ToLab(icc.D50), out, dst.ColorSpace)
+			return
+		}
+
+		conv := newConversion(text, c, strings.Split(convertTo, ","))
+		if machineOutput() {
+			emit(conv)
+			return
+		}
+		conv.print()
+	},
+}
+
+// runConvertBatch checks the batch flags and converts --file or stdin.
+func runConvertBatch(args []string) error {
+	switch {
+	case len(args) > 0:
+		return errors.New("pass colors as an argument or with --stdin/--file, not both")
+	case convertStdin && convertFile != "":
+		return errors.New("--stdin and --file are mutually exclusive")
+	case convertFromProfile != "" || convertToProfile != "":
+		return errors.New("batch mode does not support --from-profile or --to-profile")
+	}
+
+	var r io.Reader = os.Stdin
+	if convertFile != "" && convertFile != "-" {
+		f, err := os.Open(convertFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	return convertBatch(r, strings.Split(convertTo, ","), convertColumn, convertWorkers)
+}
+
+// -------------------------------
+// Conversion results
+// -------------------------------
+
+// conversion is the machine-readable result for one input color. Fields
+// are only ever added, never renamed, so scripts can rely on them.
+type conversion struct {
+	Line    int            `json:"line,omitempty" yaml:"line,omitempty"`
+	Input   string         `json:"input" yaml:"input"`
+	Space   string         `json:"space,omitempty" yaml:"space,omitempty"`
+	Alpha   *float64       `json:"alpha,omitempty" yaml:"alpha,omitempty"`
+	Error   string         `json:"error,omitempty" yaml:"error,omitempty"`
+	Results []targetResult `json:"results" yaml:"results"`
+}
+
+// targetResult is one target space. Channels mirror the numbers in
+// Value (rgb and hex 0–255, percentages as 0–100).
+type targetResult struct {
+	Target   string    `json:"target" yaml:"target"`
+	Value    string    `json:"value,omitempty" yaml:"value,omitempty"`
+	Channels []float64 `json:"channels,omitempty" yaml:"channels,omitempty,flow"`
+	Clipped  bool      `json:"clipped" yaml:"clipped"`
+	Error    string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// legacyTargets are the spaces reported by the single-space commands.
+var legacyTargets = []string{"hex", "rgb", "hsl", "hcl", "oklch", "cmyk"}
+
+func newConversion(input string, c colors.Color, targets []string) conversion {
+	alpha := c.Alpha
+	conv := conversion{Input: input, Space: c.Space.String(), Alpha: &alpha, Results: []targetResult{}}
+	for _, t := range targets {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" {
+			conv.Results = append(conv.Results, convertTarget(c, t))
+		}
+	}
+	return conv
+}
+
+// failConversion reports an input error as text or, in machine output,
+// as a conversion carrying the error so consumers see one schema.
+func failConversion(prefix, input string, err error) {
+	if machineOutput() {
+		emit(conversion{Input: input, Error: err.Error(), Results: []targetResult{}})
+		return
+	}
+	fmt.Println(prefix, err)
+}
+
+func (conv conversion) print() {
+	for _, r := range conv.Results {
+		label := strings.ToUpper(r.Target)
+		switch {
+		case r.Error != "":
+			fmt.Printf("Error (%s): %s\n", label, r.Error)
+		case r.Clipped:
+			fmt.Printf("%-7s: %s  (clipped)\n", label, r.Value)
+		default:
+			fmt.Printf("%-7s: %s\n", label, r.Value)
+		}
+	}
+}
+
+// table has one row per target. Batch results, which carry a line
+// number, get a leading line column.
+func (conv conversion) table() ([]string, [][]string) {
+	header := []string{"input", "target", "value", "channels", "clipped", "error"}
+	rows := [][]string{{conv.Input, "", "", "", "", conv.Error}}
+	if conv.Error == "" {
+		rows = make([][]string, len(conv.Results))
+		for i, r := range conv.Results {
+			rows[i] = []string{conv.Input, r.Target, r.Value, cell(r.Channels), strconv.FormatBool(r.Clipped), r.Error}
+		}
+	}
+	if conv.Line == 0 {
+		return header, rows
+	}
+	line := strconv.Itoa(conv.Line)
+	for i, row := range rows {
+		rows[i] = append([]string{line}, row...)
+	}
+	return append([]string{"line"}, header...), rows
+}
+
+// convertTarget converts a color to a target space and formats it as CSS.
+func convertTarget(c colors.Color, target string) targetResult {
+	res := targetResult{Target: target}
+	switch target {
+	case "hex":
+		rgb := c.RGB()
+		res.Value = c.Hex()
+		res.Channels = []float64{float64(rgb.R), float64(rgb.G), float64(rgb.B)}
+		res.Clipped = !c.To(colors.SpaceSRGB).InGamut()
+		return res
+	case "cmyk":
+		opts, err := cmykOptions()
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		cmyk, err := c.RGB().ToCMYKWith(opts)
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		res.Value = colors.NewColor(colors.SpaceCMYK, cmyk.C, cmyk.M, cmyk.Y, cmyk.K).String()
+		res.Channels = roundAll([]float64{cmyk.C, cmyk.M, cmyk.Y, cmyk.K}, 4)
+		res.Clipped = !c.To(colors.SpaceSRGB).InGamut()
+		return res
+	}
+
+	space, err := colors.ParseSpace(target)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	out := c.To(space)
+	if !out.InGamut() {
+		out, res.Clipped = out.Clip(), true
+	}
+	res.Value = out.String()
+	channels := append([]float64(nil), out.V[:space.Channels()]...)
+	if space == colors.SpaceSRGB {
+		for i := range channels {
+			channels[i] *= 255
+		}
+	}
+	res.Channels = roundAll(channels, 6)
+	return res
+}
+
+// convertProfiles converts device values from --from-profile to sRGB or
+// to --to-profile.
+func convertProfiles(input string) {
+	intent, err := icc.ParseIntent(convertIntent)
+	if err != nil {
+		failConversion("Error (Intent):", input, err)
+		return
+	}
+
+	src, err := icc.Open(convertFromProfile)
+	if err != nil {
+		failConversion("Error (Profile):", input, err)
+		return
+	}
+
+	device, err := parseDeviceValues(input, src.ColorSpace)
+	if err != nil {
+		failConversion("Error (Input):", input, err)
+		return
+	}
+
+	xyz, err := src.ToPCS(device, intent)
+	if err != nil {
+		failConversion("Error (PCS)  :", input, err)
+		return
+	}
+	lab := xyz.ToLab(icc.D50)
+
+	if convertToProfile == "" {
+		r, g, b, err := xyz.Adapt(icc.D50, colors.WhiteD65).ToRGB()
+		if err != nil {
+			failConversion("Error (RGB)  :", input, err)
+			return
+		}
+		printDeviceConversion(input, lab, []float64{float64(r) / 255, float64(g) / 255, float64(b) / 255}, "sRGB")
+		return
+	}
+
+	dst, err := icc.Open(convertToProfile)
+	if err != nil {
+		failConversion("Error (Profile):", input, err)
+		return
+	}
+	out, err := dst.FromPCS(xyz, intent)
+	if err != nil {
+		failConversion("Error (Device):", input, err)
+		return
+	}
+	printDeviceConversion(input, lab, out, dst.ColorSpace)
+}
+
+// printDeviceConversion reports the PCS Lab value and the device values
+// of a profile conversion.
+func printDeviceConversion(input string, lab colors.Lab, v []float64, space string) {
+	if machineOutput() {
+		emit(newRecord(
+			"input", input,
+			"lab_d50", roundAll([]float64{lab.L, lab.A, lab.B}, 4),
+			"space", space,
+			"values", roundAll(scaleDeviceValues(v, space), 4),
+		))
+		return
+	}
+	fmt.Printf("Lab D50: L=%.2f, a=%.2f, b=%.2f\n", lab.L, lab.A, lab.B)
+	fmt.Println(formatDeviceValues(v, space))
+}
+
+// parseDeviceValues reads a color in a profile's data color space and
+// returns normalised (0–1) channel values.
+func parseDeviceValues(input, space string) ([]float64, error) {
+	input = strings.TrimSpace(input)
+	channels := 3
+	scale := 255.0
+	switch space {
+	case "CMYK":
+		channels, scale = 4, 100
+	case "GRAY":
+		channels, scale = 1, 100
+	case "RGB":
+		if colors.IsValidHex(input) {
+			r, g, b, err := colors.Hex(input).ToRGB()
+			if err != nil {
+				return nil, err
+			}
+			return []float64{float64(r) / 255, float64(g) / 255, float64(b) / 255}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported profile color space %q", space)
+	}
+
+	// strip a function wrapper such as rgb(...) or cmyk(...)
+	if open := strings.Index(input, "("); open >= 0 && strings.HasSuffix(input, ")") {
+		input = input[open+1 : len(input)-1]
+	}
+	fields := strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' || r == '/' })
+	if len(fields) != channels {
+		return nil, fmt.Errorf("%s expects %d values, got %d", space, channels, len(fields))
+	}
+
+	out := make([]float64, channels)
+	for i, f := range fields {
+		s := scale
+		if strings.HasSuffix(f, "%") {
+			f, s = strings.TrimSuffix(f, "%"), 100
+		}
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", f)
+		}
+		if v < 0 || v > s {
+			return nil, fmt.Errorf("value %q out of range 0–%g", f, s)
+		}
+		out[i] = v / s
+	}
+	return out, nil
+}
+
+// formatDeviceValues prints normalised device values in their space.
+func formatDeviceValues(v []float64, space string) string {
+	switch space {
+	case "RGB", "sRGB":
+		r, g, b := int(v[0]*255+0.5), int(v[1]*255+0.5), int(v[2]*255+0.5)
+		hex, _ := colors.RGB{R: r, G: g, B: b}.ToHex()
+		return fmt.Sprintf("%-7s: %s  rgb(%d, %d, %d)", space, hex, r, g, b)
+	case "CMYK":
+		return fmt.Sprintf("CMYK   : C=%.3f, M=%.3f, Y=%.3f, K=%.3f", v[0]*100, v[1]*100, v[2]*100, v[3]*100)
+	case "GRAY":
+		return fmt.Sprintf("GRAY   : %.3f%%", v[0]*100)
+	}
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(x, 'f', 4, 64)
+	}
+	return fmt.Sprintf("%-7s: %s", space, strings.Join(parts, ", "))
+}
+
+// scaleDeviceValues maps normalised values to the ranges the input uses:
+// 0–255 for RGB, 0–100 for CMYK and GRAY.
+func scaleDeviceValues(v []float64, space string) []float64 {
+	scale := 1.0
+	switch space {
+	case "RGB", "sRGB":
+		scale = 255
+	case "CMYK", "GRAY":
+		scale = 100
+	}
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = x * scale
+	}
+	return out
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().StringVar(&convertTo, "to", "hex,rgb,hsl,hwb,lab,lch,oklab,oklch,cmyk", "Comma-separated target spaces")
+	convertCmd.Flags().StringVar(&convertFromProfile, "from-profile", "", "Source ICC profile")
+	convertCmd.Flags().StringVar(&convertToProfile, "to-profile", "", "Destination ICC profile")
+	convertCmd.Flags().StringVar(&convertIntent, "intent", "perceptual", "Rendering intent (perceptual, relative, saturation, absolute)")
+	convertCmd.Flags().BoolVar(&convertStdin, "stdin", false, "Read colors from stdin, one per line")
+	convertCmd.Flags().StringVar(&convertFile, "file", "", "Read colors from a file, one per line")
+	convertCmd.Flags().StringVar(&convertColumn, "column", "", "Read this CSV column (header name or 1-based index)")
+	convertCmd.Flags().IntVar(&convertWorkers, "workers", runtime.NumCPU(), "Number of concurrent workers")
+	addCMYKFlags(convertCmd)
+}
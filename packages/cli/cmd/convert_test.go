@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"math"
+	"testing"
+)
+
+// Channels that round to zero must not print as -0.
+func TestConvertTargetNoNegativeZero(t *testing.T) {
+	for _, in := range []string{"#ffffff", "#ff0000", "#00ffff", "#808080", "hwb(0 100% 0%)"} {
+		c := colors.MustParse(in)
+		for _, target := range []string{"hwb", "hsl", "lab", "lch", "oklab", "oklch", "cmyk"} {
+			res := convertTarget(c, target)
+			if res.Error != "" {
+				t.Fatalf("%s → %s: %s", in, target, res.Error)
+			}
+			for i, v := range res.Channels {
+				if v == 0 && math.Signbit(v) {
+					t.Errorf("%s → %s: channel %d is -0 (%s)", in, target, i, res.Value)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	datavizType      string
+	datavizCount     int
+	datavizLightness []float64
+	datavizChroma    []float64
+	datavizCVD       []string
+	datavizBase      string
+	datavizOpposite  string
+	datavizName      string
+	datavizFormat    string
+)
+
+// datavizCmd represents the dataviz command
+var datavizCmd = &cobra.Command{
+	Use:   "dataviz",
+	Short: "Generate categorical, sequential or diverging chart palettes",
+	Long: `Generate a palette for charts.
+
+--type categorical picks --count colors that are as far apart as
+possible: it maximizes the smallest CIEDE2000 difference between any
+two, under normal vision and as simulated (Machado et al.) for each
+--cvd deficiency (protan, deutan, tritan; "none" for normal vision
+only). --base keeps a brand color as the first.
+
+--type sequential runs from light to dark at --base's hue, and
+--type diverging from --base's hue through a light neutral middle to
+--opposite's (default: the complementary hue). Lightness changes
+monotonically along each run.
+
+--lightness and --chroma bound OKLCH L and C as min,max.
+
+--format exports the palette for d3, vega (Vega-Lite), matplotlib or
+ggplot2; --output json/yaml/csv gives the colors as records.
+
+Example:
+  colors-cli dataviz --type categorical --count 8
+  colors-cli dataviz --count 6 --base "#4E79A7" --format ggplot2
+  colors-cli dataviz --type sequential --count 9 --base teal --format matplotlib
+  colors-cli dataviz --type diverging --count 11 --base "#2166AC" --opposite "#B2182B" --format vega`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToLower(datavizFormat)
+		if format != "text" {
+			figlet.Quiet = true
+		}
+		figlet.LogProgramName()
+
+		switch format {
+		case "text", "d3", "vega", "matplotlib", "ggplot2":
+		default:
+			fmt.Println("Error (Format):", fmt.Errorf("unknown format %q (text, d3, vega, matplotlib, ggplot2)", datavizFormat))
+			return
+		}
+
+		opt, err := datavizOptions()
+		if err != nil {
+			fmt.Println("Error (Dataviz):", err)
+			return
+		}
+		kind := strings.ToLower(datavizType)
+		var palette []colors.Color
+		switch kind {
+		case "categorical", "qualitative":
+			kind = "categorical"
+			palette, err = colors.CategoricalPalette(opt)
+		case "sequential":
+			palette, err = colors.SequentialPalette(opt)
+		case "diverging":
+			palette, err = colors.DivergingPalette(opt)
+		default:
+			err = fmt.Errorf("unknown type %q (categorical, sequential, diverging)", datavizType)
+		}
+		if err != nil {
+			fmt.Println("Error (Dataviz):", err)
+			return
+		}
+
+		hexes := make([]string, len(palette))
+		for i, c := range palette {
+			hexes[i] = c.Hex()
+		}
+		if machineOutput() {
+			records := make([]*record, len(palette))
+			for i, c := range palette {
+				records[i] = newRecord("type", kind, "index", i+1, "hex", hexes[i], "color", c.String())
+			}
+			emit(records)
+			return
+		}
+
+		quoted := make([]string, len(hexes))
+		for i, h := range hexes {
+			quoted[i] = `"` + h + `"`
+		}
+		list := strings.Join(quoted, ", ")
+		ident := identifier(datavizName)
+		switch format {
+		case "text":
+			for i, c := range palette {
+				fmt.Printf("%3d  %s  %s\n", i+1, hexes[i], c)
+			}
+			if kind == "categorical" && len(palette) > 1 {
+				fmt.Println()
+				fmt.Println("Smallest ΔE2000:")
+				visions := append([]colors.CVD{colors.CVDNone}, opt.CVD...)
+				for _, v := range visions {
+					fmt.Printf("  %-7s %6.2f\n", v, colors.MinSeparation(palette, v))
+				}
+			}
+		case "d3":
+			fmt.Printf("const %s = [%s];\n", ident, list)
+			switch kind {
+			case "categorical":
+				fmt.Printf("const color = d3.scaleOrdinal(%s);\n", ident)
+			case "sequential":
+				fmt.Printf("const color = d3.scaleSequential(d3.interpolateRgbBasis(%s));\n", ident)
+			case "diverging":
+				fmt.Printf("const color = d3.scaleDiverging(d3.interpolateRgbBasis(%s));\n", ident)
+			}
+		case "vega":
+			scale := newRecord()
+			if kind != "categorical" {
+				scale.set("type", "linear")
+			}
+			scale.set("range", hexes)
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(newRecord("scale", scale)); err != nil {
+				fmt.Println("Error (Output):", err)
+			}
+		case "matplotlib":
+			if kind == "categorical" {
+				fmt.Println("from matplotlib.colors import ListedColormap")
+				fmt.Printf("cmap = ListedColormap([%s], name=%q)\n", list, datavizName)
+			} else {
+				fmt.Println("from matplotlib.colors import LinearSegmentedColormap")
+				fmt.Printf("cmap = LinearSegmentedColormap.from_list(%q, [%s])\n", datavizName, list)
+			}
+		case "ggplot2":
+			fmt.Printf("%s <- c(%s)\n", ident, list)
+			if kind == "categorical" {
+				fmt.Printf("scale_colour_manual(values = %s)\n", ident)
+			} else {
+				fmt.Printf("scale_colour_gradientn(colours = %s)\n", ident)
+			}
+		}
+	},
+}
+
+// datavizOptions reads the range, deficiency and color flags.
+func datavizOptions() (colors.DatavizOptions, error) {
+	opt := colors.DatavizOptions{Count: datavizCount}
+	for _, r := range []struct {
+		name   string
+		values []float64
+		dst    *[2]float64
+	}{{"lightness", datavizLightness, &opt.Lightness}, {"chroma", datavizChroma, &opt.Chroma}} {
+		switch len(r.values) {
+		case 0:
+		case 2:
+			*r.dst = [2]float64{r.values[0], r.values[1]}
+		default:
+			return opt, fmt.Errorf("--%s takes min,max", r.name)
+		}
+	}
+	for _, s := range datavizCVD {
+		d, err := colors.ParseCVD(s)
+		if err != nil {
+			return opt, err
+		}
+		if d != colors.CVDNone {
+			opt.CVD = append(opt.CVD, d)
+		}
+	}
+	for _, c := range []struct {
+		spec string
+		dst  **colors.Color
+	}{{datavizBase, &opt.Base}, {datavizOpposite, &opt.Opposite}} {
+		if c.spec == "" {
+			continue
+		}
+		parsed, err := colors.Parse(c.spec)
+		if err != nil {
+			return opt, err
+		}
+		*c.dst = &parsed
+	}
+	return opt, nil
+}
+
+// identifier turns a palette name into a camelCase identifier valid in
+// JavaScript and R.
+func identifier(name string) string {
+	var b strings.Builder
+	upper := false
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_' || (i > 0 && r >= '0' && r <= '9'):
+			if upper {
+				r = []rune(strings.ToUpper(string(r)))[0]
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = b.Len() > 0
+		}
+	}
+	if b.Len() == 0 {
+		return "palette"
+	}
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(datavizCmd)
+
+	datavizCmd.Flags().StringVar(&datavizType, "type", "categorical", "Palette type (categorical, sequential, diverging)")
+	datavizCmd.Flags().IntVarP(&datavizCount, "count", "n", 8, "Number of colors")
+	datavizCmd.Flags().Float64SliceVar(&datavizLightness, "lightness", nil, "OKLCH lightness range as min,max (default per type)")
+	datavizCmd.Flags().Float64SliceVar(&datavizChroma, "chroma", nil, "OKLCH chroma range as min,max (default per type)")
+	datavizCmd.Flags().StringSliceVar(&datavizCVD, "cvd", []string{"protan", "deutan", "tritan"}, "Deficiencies categorical colors must stay apart under (none for normal vision only)")
+	datavizCmd.Flags().StringVar(&datavizBase, "base", "", "Brand color: the first categorical color, or the sequential/diverging hue")
+	datavizCmd.Flags().StringVar(&datavizOpposite, "opposite", "", "Color of a diverging palette's high end")
+	datavizCmd.Flags().StringVar(&datavizName, "name", "palette", "Palette name in d3, matplotlib and ggplot2 output")
+	datavizCmd.Flags().StringVar(&datavizFormat, "format", "text", "Text output format (text, d3, vega, matplotlib, ggplot2)")
+}
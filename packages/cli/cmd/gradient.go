@@ -0,0 +1,223 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	gradientSteps     int
+	gradientIn        string
+	gradientHue       string
+	gradientEasings   []string
+	gradientDirection string
+	gradientFormat    string
+)
+
+// gradientCmd represents the gradient command
+var gradientCmd = &cobra.Command{
+	Use:   "gradient <color> <color> [color...]",
+	Short: "Generate a multi-stop gradient and its CSS",
+	Long: `Sample --steps evenly spaced colors across two or more color stops and
+print them with a ready-made CSS linear-gradient().
+
+A stop may carry a position, as in CSS ("red 20%"); stops without one
+are spread evenly between their neighbours. Segments are interpolated
+in --in (oklch by default) with CSS color-mix() semantics and --hue
+(shorter, longer, increasing, decreasing; --in "oklch longer" works
+too). --easing sets a timing function (linear, ease, ease-in, ease-out,
+ease-in-out, cubic-bezier(x1, y1, x2, y2)) for every segment, or is
+repeated once per segment.
+
+The CSS is a declaration with sRGB hex stops for older browsers
+followed by linear-gradient(in <space>, ...). Eased segments cannot be
+expressed in CSS, so then the modern gradient lists the sampled colors.
+--format css prints only the CSS; --output json/yaml/csv gives the
+sampled colors as records.
+
+Example:
+  colors-cli gradient "#f00" "#0f0" "#00f" --steps 9 --in oklch
+  colors-cli gradient red "gold 30%" teal --in "oklch longer" --direction "to right"
+  colors-cli gradient black white --easing ease-in-out --steps 5 --format css`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToLower(gradientFormat)
+		if format != "text" {
+			figlet.Quiet = true
+		}
+		figlet.LogProgramName()
+
+		if format != "text" && format != "css" {
+			fmt.Println("Error (Format):", fmt.Errorf("unknown format %q (text, css)", gradientFormat))
+			return
+		}
+
+		opt, err := gradientOptions()
+		if err != nil {
+			fmt.Println("Error (Gradient):", err)
+			return
+		}
+
+		stops := make([]colors.GradientStop, len(args))
+		labels := make([]string, len(args))
+		placed := false
+		for i, arg := range args {
+			if labels[i], stops[i], err = parseGradientStop(arg); err != nil {
+				fmt.Println("Error (Color):", err)
+				return
+			}
+			placed = placed || !math.IsNaN(stops[i].Position)
+		}
+
+		samples, err := colors.Gradient(stops, gradientSteps, opt)
+		if err != nil {
+			fmt.Println("Error (Gradient):", err)
+			return
+		}
+		resolved := colors.ResolveStops(stops)
+		start, end := resolved[0].Position, resolved[len(resolved)-1].Position
+		positions := make([]float64, len(samples))
+		hexes := make([]string, len(samples))
+		for i, c := range samples {
+			positions[i] = start + (end-start)*float64(i)/float64(len(samples)-1)
+			hexes[i] = c.MapToSRGB().Hex()
+		}
+
+		if machineOutput() {
+			records := make([]*record, len(samples))
+			for i, c := range samples {
+				records[i] = newRecord("step", i+1, "position", round(positions[i]*100, 2), "hex", hexes[i], "color", c.String())
+			}
+			emit(records)
+			return
+		}
+
+		// sRGB fallback, then the gradient in the interpolation space
+		fallback := gradientCSS("", hexes, positions, start != 0 || end != 1)
+		var modern string
+		if eased(opt.Easings) {
+			css := make([]string, len(samples))
+			for i, c := range samples {
+				css[i] = c.String()
+			}
+			modern = gradientCSS(interpolationCSS(opt), css, positions, true)
+		} else {
+			at := make([]float64, len(resolved))
+			for i, s := range resolved {
+				at[i] = s.Position
+			}
+			modern = gradientCSS(interpolationCSS(opt), labels, at, placed)
+		}
+
+		if format == "text" {
+			for i, c := range samples {
+				fmt.Printf("%3d  %6s%%  %s  %s\n", i+1, strconv.FormatFloat(round(positions[i]*100, 2), 'f', -1, 64), hexes[i], c)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("background: %s;\n", fallback)
+		fmt.Printf("background: %s;\n", modern)
+	},
+}
+
+// gradientOptions reads --in, --hue and --easing.
+func gradientOptions() (colors.GradientOptions, error) {
+	var opt colors.GradientOptions
+	spaceName, hueName, _ := strings.Cut(strings.TrimSpace(gradientIn), " ")
+	if strings.TrimSpace(hueName) == "" {
+		hueName = gradientHue
+	}
+	var err error
+	if opt.Space, err = colors.ParseSpace(spaceName); err != nil {
+		return opt, err
+	}
+	if opt.Space == colors.SpaceHCL || opt.Space == colors.SpaceCMYK {
+		return opt, fmt.Errorf("%s is not a CSS interpolation space", opt.Space)
+	}
+	if opt.Hue, err = colors.ParseHueMethod(hueName); err != nil {
+		return opt, err
+	}
+	for _, s := range gradientEasings {
+		e, err := colors.ParseEasing(s)
+		if err != nil {
+			return opt, err
+		}
+		opt.Easings = append(opt.Easings, e)
+	}
+	return opt, nil
+}
+
+// parseGradientStop splits "color [position%]" into the color text and
+// a stop whose position is NaN when none is given.
+func parseGradientStop(arg string) (string, colors.GradientStop, error) {
+	arg = strings.TrimSpace(arg)
+	stop := colors.GradientStop{Position: math.NaN()}
+	if i := strings.LastIndexAny(arg, " \t"); i > 0 && strings.HasSuffix(arg, "%") && !strings.Contains(arg[i:], ")") {
+		p, err := strconv.ParseFloat(strings.TrimSuffix(arg[i+1:], "%"), 64)
+		if err != nil {
+			return "", stop, fmt.Errorf("invalid stop position %q", arg[i+1:])
+		}
+		stop.Position = p / 100
+		arg = strings.TrimSpace(arg[:i])
+	}
+	c, err := colors.Parse(arg)
+	if err != nil {
+		return "", stop, err
+	}
+	stop.Color = c
+	return arg, stop, nil
+}
+
+// interpolationCSS is the <color-interpolation-method>, e.g. "in oklch
+// longer hue".
+func interpolationCSS(opt colors.GradientOptions) string {
+	method := "in " + opt.Space.String()
+	if opt.Space.HueChannel() >= 0 && opt.Hue != colors.HueShorter {
+		method += " " + opt.Hue.String() + " hue"
+	}
+	return method
+}
+
+// eased reports whether any segment has a non-linear easing.
+func eased(easings []colors.Easing) bool {
+	for _, e := range easings {
+		if !e.Linear() {
+			return true
+		}
+	}
+	return false
+}
+
+// gradientCSS formats linear-gradient() with an optional interpolation
+// method and, when withPositions is set, a position for every stop.
+func gradientCSS(method string, stops []string, positions []float64, withPositions bool) string {
+	var parts []string
+	if head := strings.TrimSpace(gradientDirection + " " + method); head != "" {
+		parts = append(parts, head)
+	}
+	for i, s := range stops {
+		if withPositions {
+			s += " " + strconv.FormatFloat(round(positions[i]*100, 2), 'f', -1, 64) + "%"
+		}
+		parts = append(parts, s)
+	}
+	return "linear-gradient(" + strings.Join(parts, ", ") + ")"
+}
+
+func init() {
+	rootCmd.AddCommand(gradientCmd)
+
+	gradientCmd.Flags().IntVar(&gradientSteps, "steps", 9, "Number of colors to sample")
+	gradientCmd.Flags().StringVar(&gradientIn, "in", "oklch", "Interpolation space (e.g. oklch, lab, srgb, \"hsl longer\")")
+	gradientCmd.Flags().StringVar(&gradientHue, "hue", "shorter", "Hue interpolation for polar spaces (shorter, longer, increasing, decreasing)")
+	gradientCmd.Flags().StringArrayVar(&gradientEasings, "easing", nil, "Easing for every segment, or repeated once per segment")
+	gradientCmd.Flags().StringVar(&gradientDirection, "direction", "", "CSS gradient direction (e.g. \"to right\", 45deg)")
+	gradientCmd.Flags().StringVar(&gradientFormat, "format", "text", "Text output format (text, css)")
+}
@@ -5,45 +5,54 @@ import (
 	"colors-cli/utils/colors"
 	"colors-cli/utils/figlet"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // hclCmd represents the colorsHCL command
 var hclCmd = &cobra.Command{
-	Use:   "hcl",
+	Use:   "hcl [h c l]",
 	Short: "Convert HCL color to HEX, RGB, HSL, OKLCH, and CMYK",
 	Long: `Convert a HCL color (Hue, Chroma, Lightness) to multiple color spaces:
 - HEX (Hexadecimal)
 - RGB (Red, Green, Blue)
 - HSL (Hue, Saturation, Lightness)
 - OKLCH (Lightness, Chroma, Hue)
-- CMYK (Cyan, Magenta, Yellow, Black)
+- CMYK (Cyan, Magenta, Yellow, Black), as percentages (0–100)
 
 Example:
   colors-cli hcl 30 80 50`,
 	Run: func(cmd *cobra.Command, args []string) {
 		figlet.LogProgramName()
 
-		// Prompt for HCL input
-		var h, c, l float64
-		fmt.Print("Hue (0–360)      : ")
-		fmt.Scanln(&h)
-		fmt.Print("Chroma (0–100)   : ")
-		fmt.Scanln(&c)
-		fmt.Print("Lightness (0–100): ")
-		fmt.Scanln(&l)
+		// Read HCL input (arguments, or prompts)
+		input, err := readInput(args, "Hue (0–360)      : ", "Chroma (0–100)   : ", "Lightness (0–100): ")
+		if err != nil {
+			failConversion("Error (Input):", "", err)
+			return
+		}
+		values, err := parseNumbers(input, 3)
+		if err != nil {
+			failConversion("Error (HCL)  :", strings.Join(input, " "), err)
+			return
+		}
 
-		hcl := colors.HCL{H: h, C: c, L: l}
+		hcl := colors.HCL{H: values[0], C: values[1], L: values[2]}
 
 		// HCL → RGB
 		r, g, b, err := hcl.ToRGB()
 		if err != nil {
-			fmt.Println("Error (RGB)  :", err)
+			failConversion("Error (RGB)  :", strings.Join(input, " "), err)
 			return
 		}
 		rgb := colors.RGB{R: r, G: g, B: b}
 
+		if machineOutput() {
+			emitConversion(input, rgb)
+			return
+		}
+
 		// RGB → HEX
 		hex, err := rgb.ToHex()
 		if err != nil {
@@ -76,15 +85,12 @@ Example:
 		}
 
 		// RGB → CMYK
-		C, M, Y, K, err := rgb.ToCMYK()
-		if C < 0 || M < 0 || Y < 0 || K < 0 || err != nil {
-			fmt.Println("Error (CMYK) : conversion failed")
-		} else {
-			fmt.Printf("CMYK   : C=%.3f, M=%.3f, Y=%.3f, K=%.3f\n", C, M, Y, K)
-		}
+		printCMYK(rgb, 100)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(hclCmd)
+
+	addCMYKFlags(hclCmd)
 }
@@ -5,39 +5,47 @@ import (
 	"colors-cli/utils/colors"
 	"colors-cli/utils/figlet"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // hexCmd represents the colorsHexToRgb command
 var hexCmd = &cobra.Command{
-	Use:   "hex",
+	Use:   "hex [color]",
 	Short: "Convert HEX color to RGB, HSL, HCL, OKLCH, and CMYK",
 	Long: `Convert a HEX color to multiple color spaces:
 - RGB (Red, Green, Blue)
 - HSL (Hue, Saturation, Lightness)
 - HCL (Hue, Chroma, Lightness)
 - OKLCH (Lightness, Chroma, Hue)
-- CMYK (Cyan, Magenta, Yellow, Black)
+- CMYK (Cyan, Magenta, Yellow, Black), as fractions (0–1)
 
 Example:
   colors-cli hex #FF5733`,
 	Run: func(cmd *cobra.Command, args []string) {
 		figlet.LogProgramName()
 
-		// Prompt for HEX input
-		fmt.Print("HEX: ")
-		var hexInput string
-		fmt.Scanln(&hexInput)
+		// Read HEX input (argument, or prompt)
+		input, err := readInput(args, "HEX: ")
+		if err != nil {
+			failConversion("Error (Input):", "", err)
+			return
+		}
 
-		hex := colors.Hex(hexInput) // wrap input in Hex type
+		hex := colors.Hex(strings.TrimSpace(input[0])) // wrap input in Hex type
 
 		// HEX → RGB
 		r, g, b, err := hex.ToRGB()
 		if err != nil {
-			fmt.Println("Error (RGB)  :", err)
+			failConversion("Error (RGB)  :", strings.Join(input, " "), err)
+			return
+		}
+		if machineOutput() {
+			emitConversion(input, colors.RGB{R: r, G: g, B: b})
 			return
 		}
+
 		fmt.Printf("RGB    : rgb(%d, %d, %d)\n", r, g, b)
 
 		// HEX → HSL
@@ -65,15 +73,12 @@ Example:
 		}
 
 		// HEX → CMYK
-		C, M, Y, K, err := hex.ToCMYK()
-		if err != nil {
-			fmt.Println("Error (CMYK) :", err)
-		} else {
-			fmt.Printf("CMYK   : C=%.3f, M=%.3f, Y=%.3f, K=%.3f\n", C, M, Y, K)
-		}
+		printCMYK(colors.RGB{R: r, G: g, B: b}, 1)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(hexCmd)
+
+	addCMYKFlags(hexCmd)
 }
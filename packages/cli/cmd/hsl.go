@@ -0,0 +1,59 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// hslCmd represents the HSL-to-other-color-spaces command
+var hslCmd = &cobra.Command{
+	Use:   "hsl [h s l]",
+	Short: "Convert HSL color to HEX, RGB, HCL, OKLCH, and CMYK",
+	Long: `Convert an HSL color (Hue, Saturation, Lightness) to multiple color spaces:
+- HEX (Hexadecimal)
+- RGB (Red, Green, Blue)
+- HCL (Hue, Chroma, Lightness)
+- OKLCH (Lightness, Chroma, Hue)
+- CMYK (Cyan, Magenta, Yellow, Black), as percentages (0–100)
+
+Saturation and lightness are percentages (0–100).
+
+Example:
+  colors-cli hsl 11 100 60
+  colors-cli hsl "hsl(11, 100%, 60%)"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		// Read HSL input (arguments, or prompts)
+		input, err := readInput(args, "Hue (0–360)       : ", "Saturation (0–100): ", "Lightness (0–100) : ")
+		if err != nil {
+			failConversion("Error (Input):", "", err)
+			return
+		}
+		values, err := parseNumbers(input, 3)
+		if err != nil {
+			failConversion("Error (HSL)  :", strings.Join(input, " "), err)
+			return
+		}
+		h, s, l := values[0], values[1], values[2]
+		if h < 0 || h > 360 || s < 0 || s > 100 || l < 0 || l > 100 {
+			failConversion("Error (HSL)  :", strings.Join(input, " "), errors.New("values out of range"))
+			return
+		}
+
+		// HSL → RGB
+		r, g, b := colors.HSLToRGB(h, s, l)
+		printColorSpaces(input, colors.RGB{R: r, G: g, B: b})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hslCmd)
+
+	addCMYKFlags(hslCmd)
+}
@@ -0,0 +1,193 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"colors-cli/utils/icc"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	iccPrimaries   string
+	iccWhite       string
+	iccTRC         string
+	iccOutput      string
+	iccDescription string
+	iccCopyright   string
+)
+
+// iccCmd represents the icc command
+var iccCmd = &cobra.Command{
+	Use:   "icc",
+	Short: "Create and inspect ICC profiles",
+}
+
+// iccCreateCmd represents the icc create command
+var iccCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Write an ICC v4 matrix/TRC profile for an RGB space",
+	Long: `Write an ICC v4.3 display profile for an RGB color space. The
+colorants are chromatically adapted to the D50 PCS with Bradford and
+the adaptation is recorded in the chad tag. The profile is read back
+and checked before it is reported.
+
+--primaries takes a named space (srgb, display-p3, a98-rgb, rec2020,
+prophoto-rgb) or "xr,yr,xg,yg,xb,yb". A named space supplies default
+--white and --trc values.
+
+--white: A, C, E, D50, D55, D65, D75 or "x,y"
+--trc  : srgb, rec709, linear or gamma:<g>
+
+Example:
+  colors-cli icc create --primaries display-p3 --out p3.icc
+  colors-cli icc create --primaries 0.68,0.32,0.265,0.69,0.15,0.06 --white D65 --trc srgb -o out.icc`,
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		space, err := parseRGBSpace(iccPrimaries)
+		if err != nil {
+			fmt.Println("Error (Primaries):", err)
+			return
+		}
+		if cmd.Flags().Changed("white") || space.White == (colors.XYZ{}) {
+			if space.White, err = colors.WhitePointByName(iccWhite); err != nil {
+				fmt.Println("Error (White):", err)
+				return
+			}
+		}
+		if cmd.Flags().Changed("trc") || space.TRC.Gamma == 0 {
+			if space.TRC, err = colors.ParseTransferFunction(iccTRC); err != nil {
+				fmt.Println("Error (TRC)  :", err)
+				return
+			}
+		}
+
+		desc := iccDescription
+		if desc == "" {
+			desc = space.Name
+		}
+		data, err := icc.MatrixProfile{Description: desc, Copyright: iccCopyright, Space: space}.Encode()
+		if err != nil {
+			fmt.Println("Error (Profile):", err)
+			return
+		}
+
+		worst, err := icc.ValidateMatrixProfile(data, space)
+		if err != nil {
+			fmt.Println("Error (Validate):", err)
+			return
+		}
+		if err := os.WriteFile(iccOutput, data, 0o644); err != nil {
+			fmt.Println("Error (Write):", err)
+			return
+		}
+
+		id := icc.ProfileID(data)
+		if machineOutput() {
+			emit(newRecord(
+				"profile", iccOutput,
+				"bytes", len(data),
+				"name", desc,
+				"trc", space.TRC.Name,
+				"id", fmt.Sprintf("%x", id),
+				"max_delta_e00", round(worst, 6),
+			))
+			return
+		}
+		fmt.Printf("Profile: %s (%d bytes)\n", iccOutput, len(data))
+		fmt.Printf("Name   : %s\n", desc)
+		fmt.Printf("TRC    : %s\n", space.TRC.Name)
+		fmt.Printf("ID     : %x\n", id)
+		fmt.Printf("Check  : max ΔE00 %.4f\n", worst)
+	},
+}
+
+// iccInfoCmd represents the icc info command
+var iccInfoCmd = &cobra.Command{
+	Use:   "info <profile.icc>",
+	Short: "Print an ICC profile's header and tags",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		p, err := icc.Open(args[0])
+		if err != nil {
+			fmt.Println("Error (Profile):", err)
+			return
+		}
+
+		tags := p.Tags()
+		sort.Strings(tags)
+		if machineOutput() {
+			emit(newRecord(
+				"name", p.Description,
+				"version", p.VersionString(),
+				"class", p.Class,
+				"space", p.ColorSpace,
+				"pcs", p.PCS,
+				"intent", p.Intent.String(),
+				"white", roundAll([]float64{p.MediaWhite.X, p.MediaWhite.Y, p.MediaWhite.Z}, 6),
+				"tags", tags,
+			))
+			return
+		}
+		fmt.Printf("Name   : %s\n", p.Description)
+		fmt.Printf("Version: %s\n", p.VersionString())
+		fmt.Printf("Class  : %s\n", p.Class)
+		fmt.Printf("Space  : %s → %s\n", p.ColorSpace, p.PCS)
+		fmt.Printf("Intent : %s\n", p.Intent)
+		fmt.Printf("White  : X=%.4f, Y=%.4f, Z=%.4f\n", p.MediaWhite.X, p.MediaWhite.Y, p.MediaWhite.Z)
+		fmt.Printf("Tags   : %s\n", strings.Join(tags, ", "))
+	},
+}
+
+// parseRGBSpace resolves a named space or six primary chromaticities.
+// Custom primaries carry no white or TRC; the caller fills those in.
+func parseRGBSpace(s string) (colors.RGBSpace, error) {
+	if space, err := colors.RGBSpaceByName(s); err == nil {
+		return space, nil
+	}
+	fields := strings.Split(s, ",")
+	if len(fields) != 6 {
+		return colors.RGBSpace{}, fmt.Errorf("expected a space name or xr,yr,xg,yg,xb,yb, got %q", s)
+	}
+	v := make([]float64, 6)
+	for i, f := range fields {
+		x, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil || x < 0 || x > 1 {
+			return colors.RGBSpace{}, fmt.Errorf("invalid chromaticity %q", f)
+		}
+		v[i] = x
+	}
+	for i := 1; i < 6; i += 2 {
+		if v[i] == 0 {
+			return colors.RGBSpace{}, fmt.Errorf("chromaticity y must be positive")
+		}
+	}
+	return colors.RGBSpace{
+		Name:  "Custom RGB",
+		Red:   [2]float64{v[0], v[1]},
+		Green: [2]float64{v[2], v[3]},
+		Blue:  [2]float64{v[4], v[5]},
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(iccCmd)
+	iccCmd.AddCommand(iccCreateCmd)
+	iccCmd.AddCommand(iccInfoCmd)
+
+	iccCreateCmd.Flags().StringVar(&iccPrimaries, "primaries", "srgb", "Named RGB space or xr,yr,xg,yg,xb,yb")
+	iccCreateCmd.Flags().StringVar(&iccWhite, "white", "D65", "White point (A, C, E, D50, D55, D65, D75 or x,y)")
+	iccCreateCmd.Flags().StringVar(&iccTRC, "trc", "srgb", "Transfer function (srgb, rec709, linear, gamma:<g>)")
+	iccCreateCmd.Flags().StringVarP(&iccOutput, "out", "o", "out.icc", "Output file")
+	iccCreateCmd.Flags().StringVar(&iccDescription, "description", "", "Profile description (defaults to the space name)")
+	iccCreateCmd.Flags().StringVar(&iccCopyright, "copyright", "No copyright, use freely", "Copyright text")
+}
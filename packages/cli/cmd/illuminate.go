@@ -0,0 +1,126 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	illuminateUnder   string
+	illuminateRef     string
+	illuminateAgainst string
+)
+
+// illuminateCmd represents the illuminate command
+var illuminateCmd = &cobra.Command{
+	Use:   "illuminate <color>",
+	Short: "Predict how a HEX color shifts under another illuminant",
+	Long: `Upsample an sRGB color to a reflectance spectrum (Jakob–Hanika) and
+render it under a different light source:
+- Lab under the reference illuminant (D65 by default)
+- Lab under the test illuminant
+- Perceived sRGB after chromatic adaptation to the test light
+- Color inconstancy (ΔE*ab between reference and test)
+
+Illuminants: A, E, D50, D55, D65, D75, D<cct>, <kelvin>K (blackbody).
+With --against, also prints the CIE metamerism index of the two samples.
+
+Example:
+  colors-cli illuminate #FF5733 --under A
+  colors-cli illuminate #7A6E5D --against #7B6D5E --under 3000K`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		under, err := colors.IlluminantByName(illuminateUnder)
+		if err != nil {
+			fmt.Println("Error (Illuminant):", err)
+			return
+		}
+		reference, err := colors.IlluminantByName(illuminateRef)
+		if err != nil {
+			fmt.Println("Error (Illuminant):", err)
+			return
+		}
+
+		sample, err := upsampleHex(args[0])
+		if err != nil {
+			fmt.Println("Error (Spectrum):", err)
+			return
+		}
+
+		labRef := sample.LabUnder(reference)
+		labTest := sample.LabUnder(under)
+		r, g, b, rgbErr := sample.AppearanceUnder(under)
+		inconstancy := colors.DeltaE76(labRef, labTest)
+
+		var other colors.Spectrum
+		if illuminateAgainst != "" {
+			if other, err = upsampleHex(illuminateAgainst); err != nil {
+				fmt.Println("Error (Spectrum):", err)
+				return
+			}
+		}
+
+		if machineOutput() {
+			rec := newRecord(
+				"input", args[0],
+				"reference", illuminateRef,
+				"under", illuminateUnder,
+				"lab_reference", roundAll([]float64{labRef.L, labRef.A, labRef.B}, 4),
+				"lab_under", roundAll([]float64{labTest.L, labTest.A, labTest.B}, 4),
+			)
+			if rgbErr == nil {
+				hex, _ := colors.RGB{R: r, G: g, B: b}.ToHex()
+				rec.set("appears", hex)
+			}
+			rec.set("delta_e", round(inconstancy, 4))
+			if illuminateAgainst != "" {
+				rec.set("against", illuminateAgainst)
+				rec.set("delta_e_against", round(colors.DeltaE76(labRef, other.LabUnder(reference)), 4))
+				rec.set("metamerism_index", round(colors.MetamerismIndex(sample, other, reference, under), 4))
+			}
+			emit(rec)
+			return
+		}
+
+		fmt.Printf("Lab %-4s: L=%.2f, a=%.2f, b=%.2f\n", illuminateRef, labRef.L, labRef.A, labRef.B)
+		fmt.Printf("Lab %-4s: L=%.2f, a=%.2f, b=%.2f\n", illuminateUnder, labTest.L, labTest.A, labTest.B)
+		if rgbErr != nil {
+			fmt.Println("Error (RGB)  :", rgbErr)
+		} else {
+			hex, _ := colors.RGB{R: r, G: g, B: b}.ToHex()
+			fmt.Printf("Appears : %s  rgb(%d, %d, %d)\n", hex, r, g, b)
+		}
+		fmt.Printf("ΔE*ab   : %.2f\n", inconstancy)
+
+		if illuminateAgainst == "" {
+			return
+		}
+		fmt.Printf("ΔE*ab %s vs %s under %s: %.2f\n", args[0], illuminateAgainst, illuminateRef,
+			colors.DeltaE76(labRef, other.LabUnder(reference)))
+		fmt.Printf("Metamerism index (%s → %s): %.2f\n", illuminateRef, illuminateUnder,
+			colors.MetamerismIndex(sample, other, reference, under))
+	},
+}
+
+// upsampleHex validates a HEX string and returns its estimated reflectance.
+func upsampleHex(input string) (colors.Spectrum, error) {
+	rgb, err := hexToRGB(input)
+	if err != nil {
+		return colors.Spectrum{}, err
+	}
+	return colors.UpsampleRGB(rgb)
+}
+
+func init() {
+	rootCmd.AddCommand(illuminateCmd)
+
+	illuminateCmd.Flags().StringVarP(&illuminateUnder, "under", "u", "A", "Test illuminant")
+	illuminateCmd.Flags().StringVar(&illuminateRef, "reference", "D65", "Reference illuminant")
+	illuminateCmd.Flags().StringVar(&illuminateAgainst, "against", "", "Second HEX color for the metamerism index")
+}
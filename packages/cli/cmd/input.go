@@ -0,0 +1,114 @@
+// Package cmd ...
+package cmd
+
+import (
+	"bufio"
+	"colors-cli/utils/colors"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// stdinIsTerminal reports whether the user can be prompted.
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readInput returns the positional arguments, or prompts for each field
+// when none are given and stdin is a terminal.
+func readInput(args []string, prompts ...string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	if !stdinIsTerminal() {
+		return nil, errors.New("no color given (pass it as an argument)")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	values := make([]string, 0, len(prompts))
+	for _, p := range prompts {
+		fmt.Print(p)
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" && err != nil {
+			return nil, err
+		}
+		values = append(values, line)
+	}
+	return values, nil
+}
+
+// parseNumbers reads n numbers separated by commas, spaces or slashes,
+// optionally wrapped in a function such as rgb(...).
+func parseNumbers(args []string, n int) ([]float64, error) {
+	input := strings.TrimSpace(strings.Join(args, " "))
+	if open := strings.Index(input, "("); open >= 0 && strings.HasSuffix(input, ")") {
+		input = input[open+1 : len(input)-1]
+	}
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '/'
+	})
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d values, got %d", n, len(fields))
+	}
+
+	out := make([]float64, n)
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSuffix(f, "%"), "°"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", f)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// emitConversion writes the machine-readable result of a single-space
+// command.
+func emitConversion(input []string, rgb colors.RGB) {
+	if !rgb.IsValid() {
+		failConversion("", strings.Join(input, " "), errors.New("invalid RGB values"))
+		return
+	}
+	emit(newConversion(strings.Join(input, " "), colors.FromRGB(rgb), legacyTargets))
+}
+
+// printColorSpaces prints an sRGB color in every space the single-space
+// commands report.
+func printColorSpaces(input []string, rgb colors.RGB) {
+	if machineOutput() {
+		emitConversion(input, rgb)
+		return
+	}
+	hex, err := rgb.ToHex()
+	if err != nil {
+		fmt.Println("Error (HEX)  :", err)
+		return
+	}
+	fmt.Printf("HEX    : %s\n", hex)
+	fmt.Printf("RGB    : rgb(%d, %d, %d)\n", rgb.R, rgb.G, rgb.B)
+
+	if h, s, l, err := rgb.ToHSL(); err != nil {
+		fmt.Println("Error (HSL)  :", err)
+	} else {
+		fmt.Printf("HSL    : h=%.2f°, s=%.2f%%, l=%.2f%%\n", h, s, l)
+	}
+
+	if h, c, l, err := rgb.ToHCL(); err != nil {
+		fmt.Println("Error (HCL)  :", err)
+	} else {
+		fmt.Printf("HCL    : h=%.2f°, c=%.2f, l=%.2f\n", h, c, l)
+	}
+
+	if L, C, H, err := rgb.ToOKLCH(); err != nil {
+		fmt.Println("Error (OKLCH):", err)
+	} else {
+		fmt.Printf("OKLCH  : L=%.3f, C=%.3f, H=%.2f°\n", L, C, H)
+	}
+
+	printCMYK(rgb, 100)
+}
@@ -0,0 +1,176 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mixModel  string
+	mixAmount string
+	mixIn     string
+	mixHue    string
+)
+
+// mixCmd represents the mix command
+var mixCmd = &cobra.Command{
+	Use:   "mix <color> <color>",
+	Short: "Mix two colors",
+	Long: `Mix two CSS colors (HEX, names, rgb(), oklch(), …) using one of the
+mixing models, which work on the opaque 8-bit sRGB of each color:
+- rgb     (channel-wise interpolation in sRGB)
+- pigment (subtractive, Kubelka–Munk paint mixing)
+
+With --in, any two CSS colors are interpolated as CSS color-mix() does,
+in srgb, srgb-linear, lab, lch, oklab, oklch, hsl, hwb, xyz (xyz-d65,
+xyz-d50) or another supported space, with premultiplied alpha. Polar
+spaces take --hue (shorter, longer, increasing, decreasing), and
+--in "oklch longer" is accepted too.
+
+--amount is the share of the second color, as 0–1 or a percentage.
+
+Example:
+  colors-cli mix #0000FF #FFFF00 --model pigment
+  colors-cli mix blue yellow --model pigment
+  colors-cli mix #FF0000 #FFFFFF --amount 30%
+  colors-cli mix red blue --in oklch --amount 30%
+  colors-cli mix "hsl(30 80% 50%)" "hsl(300 80% 50% / 0.5)" --in "hsl longer"`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		t, err := parseAmount(mixAmount)
+		if err != nil {
+			fmt.Println("Error (Amount):", err)
+			return
+		}
+		if mixIn != "" {
+			mixInSpace(cmd, args, t)
+			return
+		}
+
+		in, err := parseColors(args)
+		if err != nil {
+			fmt.Println("Error (Color):", err)
+			return
+		}
+		a, b := in[0].RGB(), in[1].RGB()
+		var mixed colors.RGB
+		switch strings.ToLower(mixModel) {
+		case "rgb":
+			mixed, err = colors.MixRGB(a, b, t)
+		case "pigment":
+			mixed, err = colors.MixPigment(a, b, t)
+		default:
+			err = fmt.Errorf("unknown model %q (rgb, pigment)", mixModel)
+		}
+		if err != nil {
+			fmt.Println("Error (Mix)  :", err)
+			return
+		}
+
+		hex, _ := mixed.ToHex()
+		if machineOutput() {
+			emit(newRecord("model", strings.ToLower(mixModel), "amount", t, "hex", hex, "rgb", []int{mixed.R, mixed.G, mixed.B}))
+			return
+		}
+		fmt.Printf("HEX    : %s\n", hex)
+		fmt.Printf("RGB    : rgb(%d, %d, %d)\n", mixed.R, mixed.G, mixed.B)
+	},
+}
+
+// mixInSpace mixes two CSS colors with color-mix() semantics.
+func mixInSpace(cmd *cobra.Command, args []string, t float64) {
+	if cmd.Flags().Changed("model") {
+		fmt.Println("Error (Mix)  :", fmt.Errorf("--model cannot be combined with --in"))
+		return
+	}
+	spaceName, hueName, _ := strings.Cut(strings.TrimSpace(mixIn), " ")
+	if strings.TrimSpace(hueName) == "" {
+		hueName = mixHue
+	}
+	space, err := colors.ParseSpace(spaceName)
+	if err != nil {
+		fmt.Println("Error (Space):", err)
+		return
+	}
+	hue, err := colors.ParseHueMethod(hueName)
+	if err != nil {
+		fmt.Println("Error (Hue)  :", err)
+		return
+	}
+
+	in, err := parseColors(args)
+	if err != nil {
+		fmt.Println("Error (Color):", err)
+		return
+	}
+
+	mixed := colors.Mix(in[0], in[1], t, space, hue)
+	hex := mixed.MapToSRGB().Hex()
+	if machineOutput() {
+		emit(newRecord("in", space.String(), "hue", hue.String(), "amount", t, "hex", hex, "color", mixed.String()))
+		return
+	}
+	fmt.Printf("HEX    : %s\n", hex)
+	fmt.Printf("COLOR  : %s\n", mixed)
+}
+
+// parseColors parses the two colors to mix.
+func parseColors(args []string) ([2]colors.Color, error) {
+	var in [2]colors.Color
+	for i, arg := range args {
+		c, err := colors.Parse(arg)
+		if err != nil {
+			return in, err
+		}
+		in[i] = c
+	}
+	return in, nil
+}
+
+// hexToRGB validates a HEX string and converts it to RGB.
+func hexToRGB(input string) (colors.RGB, error) {
+	if !colors.IsValidHex(input) {
+		return colors.RGB{}, fmt.Errorf("invalid hex color %q", input)
+	}
+	r, g, b, err := colors.Hex(input).ToRGB()
+	if err != nil {
+		return colors.RGB{}, err
+	}
+	return colors.RGB{R: r, G: g, B: b}, nil
+}
+
+// parseAmount accepts "0.3" or "30%" and returns a value in 0–1.
+func parseAmount(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	if strings.HasSuffix(s, "%") {
+		s = strings.TrimSuffix(s, "%")
+		scale = 100
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	v /= scale
+	if v < 0 || v > 1 {
+		return 0, fmt.Errorf("amount %v out of range 0–1", v)
+	}
+	return v, nil
+}
+
+func init() {
+	rootCmd.AddCommand(mixCmd)
+
+	mixCmd.Flags().StringVarP(&mixModel, "model", "m", "rgb", "Mixing model (rgb, pigment)")
+	mixCmd.Flags().StringVarP(&mixAmount, "amount", "a", "0.5", "Share of the second color (0–1 or %)")
+	mixCmd.Flags().StringVar(&mixIn, "in", "", "Interpolate any CSS colors in this space, as CSS color-mix() (e.g. oklch, \"oklch longer\")")
+	mixCmd.Flags().StringVar(&mixHue, "hue", "shorter", "Hue interpolation for polar spaces (shorter, longer, increasing, decreasing)")
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// Both the model and the --in paths take any CSS color, not only hex.
+func TestMixParsesCSSColors(t *testing.T) {
+	withOutput(t, "json")
+	for _, tc := range []struct {
+		model, in string
+		args      []string
+		want      string
+	}{
+		{"pigment", "", []string{"blue", "yellow"}, `"hex": "#437047"`},
+		{"pigment", "", []string{"#0000FF", "#FFFF00"}, `"hex": "#437047"`},
+		{"rgb", "", []string{"rgb(255 0 0)", "white"}, `"hex": "#FF8080"`},
+		{"rgb", "srgb", []string{"red", "blue"}, `"hex": "#800080"`},
+	} {
+		mixModel, mixIn, mixAmount = tc.model, tc.in, "0.5"
+		out := captureStdout(t, func() { mixCmd.Run(mixCmd, tc.args) })
+		if !strings.Contains(out, tc.want) {
+			t.Errorf("mix %v --model %s --in %q: got %s, want %s", tc.args, tc.model, tc.in, out, tc.want)
+		}
+	}
+	mixModel, mixIn = "rgb", ""
+	out := captureStdout(t, func() { mixCmd.Run(mixCmd, []string{"nope", "blue"}) })
+	if !strings.HasPrefix(out, "Error (Color):") {
+		t.Errorf("mix nope blue: got %q", out)
+	}
+}
@@ -0,0 +1,133 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var munsellRenotation string
+
+// munsellCmd represents the munsell command
+var munsellCmd = &cobra.Command{
+	Use:   "munsell <notation|color>",
+	Short: "Convert between Munsell notation and HEX, xyY and Lab",
+	Long: `Convert a Munsell notation (e.g. "5R 4/14", "N 5/") to:
+- HEX and RGB
+- CIE xyY (D65)
+- CIELAB (D65)
+
+or a HEX color to its Munsell notation.
+
+Conversions interpolate the Munsell renotation table (RIT "real.dat")
+built into the binary; --renotation loads another table with rows of
+"H V C x y Y". A build without the table falls back to an approximate
+CIELAB-based model and says so on stderr.
+
+Example:
+  colors-cli munsell "5R 4/14"
+  colors-cli munsell #FF5733 --renotation real.dat`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		if munsellRenotation != "" {
+			f, err := os.Open(munsellRenotation)
+			if err != nil {
+				fmt.Println("Error (Renotation):", err)
+				return
+			}
+			defer f.Close()
+
+			data, err := colors.LoadMunsellRenotation(f)
+			if err != nil {
+				fmt.Println("Error (Renotation):", err)
+				return
+			}
+			colors.DefaultMunsell = data
+		}
+		if colors.DefaultMunsell == nil {
+			fmt.Fprintln(os.Stderr, "Warning (Renotation): no renotation table built in, using the approximate CIELAB model")
+		}
+
+		input := strings.Join(args, " ")
+
+		if colors.IsValidHex(input) {
+			rgb, err := hexToRGB(input)
+			if err != nil {
+				fmt.Println("Error (HEX)  :", err)
+				return
+			}
+			m, err := rgb.ToMunsell()
+			if err != nil {
+				fmt.Println("Error (Munsell):", err)
+				return
+			}
+			if machineOutput() {
+				emit(newRecord("input", input, "munsell", m.String()))
+				return
+			}
+			fmt.Printf("Munsell: %s\n", m)
+			return
+		}
+
+		m, err := colors.ParseMunsell(input)
+		if err != nil {
+			fmt.Println("Error (Munsell):", err)
+			return
+		}
+
+		xyz, err := m.ToXYZ()
+		if err != nil {
+			fmt.Println("Error (XYZ)  :", err)
+			return
+		}
+
+		if machineOutput() {
+			xyY := xyz.ToXYY()
+			lab := xyz.ToLab(colors.WhiteD65)
+			rec := newRecord("input", input)
+			if hex, err := m.ToHex(); err == nil {
+				rec.set("hex", string(hex))
+			}
+			if r, g, b, err := xyz.ToRGB(); err == nil {
+				rec.set("rgb", []int{r, g, b})
+			}
+			rec.set("xyY", roundAll([]float64{xyY.X, xyY.Y, xyY.Lum}, 6))
+			rec.set("lab", roundAll([]float64{lab.L, lab.A, lab.B}, 4))
+			emit(rec)
+			return
+		}
+
+		hex, err := m.ToHex()
+		if err != nil {
+			fmt.Println("Error (HEX)  :", err)
+		} else {
+			fmt.Printf("HEX    : %s\n", hex)
+		}
+
+		r, g, b, err := xyz.ToRGB()
+		if err != nil {
+			fmt.Println("Error (RGB)  :", err)
+		} else {
+			fmt.Printf("RGB    : rgb(%d, %d, %d)\n", r, g, b)
+		}
+
+		xyY := xyz.ToXYY()
+		fmt.Printf("xyY    : x=%.4f, y=%.4f, Y=%.4f\n", xyY.X, xyY.Y, xyY.Lum)
+
+		lab := xyz.ToLab(colors.WhiteD65)
+		fmt.Printf("Lab    : L=%.2f, a=%.2f, b=%.2f\n", lab.L, lab.A, lab.B)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(munsellCmd)
+
+	munsellCmd.Flags().StringVarP(&munsellRenotation, "renotation", "r", "", "Munsell renotation data file")
+}
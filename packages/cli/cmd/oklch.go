@@ -5,45 +5,54 @@ import (
 	"colors-cli/utils/colors"
 	"colors-cli/utils/figlet"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 // oklchCmd represents the colorsOKLCH command
 var oklchCmd = &cobra.Command{
-	Use:   "oklch",
+	Use:   "oklch [L C H]",
 	Short: "Convert OKLCH color to HEX, RGB, HSL, HCL, and CMYK",
 	Long: `Convert an OKLCH color (Lightness, Chroma, Hue) to multiple color spaces:
 - HEX (Hexadecimal)
 - RGB (Red, Green, Blue)
 - HSL (Hue, Saturation, Lightness)
 - HCL (Hue, Chroma, Lightness)
-- CMYK (Cyan, Magenta, Yellow, Black)
+- CMYK (Cyan, Magenta, Yellow, Black), as percentages (0–100)
 
 Example:
   colors-cli oklch 0.8 0.1 120`,
 	Run: func(cmd *cobra.Command, args []string) {
 		figlet.LogProgramName()
 
-		// Prompt for OKLCH input
-		var L, C, H float64
-		fmt.Print("Lightness (0–1) : ")
-		fmt.Scanln(&L)
-		fmt.Print("Chroma (0–1)    : ")
-		fmt.Scanln(&C)
-		fmt.Print("Hue (0–360)     : ")
-		fmt.Scanln(&H)
+		// Read OKLCH input (arguments, or prompts)
+		input, err := readInput(args, "Lightness (0–1) : ", "Chroma (0–1)    : ", "Hue (0–360)     : ")
+		if err != nil {
+			failConversion("Error (Input):", "", err)
+			return
+		}
+		values, err := parseNumbers(input, 3)
+		if err != nil {
+			failConversion("Error (OKLCH):", strings.Join(input, " "), err)
+			return
+		}
 
-		oklch := colors.OKLCH{L: L, C: C, H: H}
+		oklch := colors.OKLCH{L: values[0], C: values[1], H: values[2]}
 
 		// OKLCH → RGB
 		r, g, b, err := oklch.ToRGB()
 		if err != nil {
-			fmt.Println("Error (RGB)  :", err)
+			failConversion("Error (RGB)  :", strings.Join(input, " "), err)
 			return
 		}
 		rgb := colors.RGB{R: r, G: g, B: b}
 
+		if machineOutput() {
+			emitConversion(input, rgb)
+			return
+		}
+
 		// RGB → HEX
 		hex, err := rgb.ToHex()
 		if err != nil {
@@ -84,15 +93,12 @@ Example:
 		}
 
 		// RGB → CMYK
-		Cy, M, Y, K, err := rgb.ToCMYK()
-		if C < 0 || M < 0 || Y < 0 || K < 0 || err != nil {
-			fmt.Println("Error (CMYK) : conversion failed")
-		} else {
-			fmt.Printf("CMYK   : C=%.3f, M=%.3f, Y=%.3f, K=%.3f\n", Cy, M, Y, K)
-		}
+		printCMYK(rgb, 100)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(oklchCmd)
+
+	addCMYKFlags(oklchCmd)
 }
@@ -0,0 +1,331 @@
+// Package cmd ...
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormat is the global --output flag.
+var outputFormat string
+
+var outputFormats = []string{"text", "json", "yaml", "csv", "tsv"}
+
+// machineOutput reports whether --output asks for structured data
+// instead of aligned text.
+func machineOutput() bool {
+	return outputFormat != "text"
+}
+
+// -------------------------------
+// Ordered records
+// -------------------------------
+
+// record is a set of fields that keeps insertion order in every format.
+type record struct {
+	keys   []string
+	values map[string]any
+}
+
+// newRecord builds a record from alternating keys and values.
+func newRecord(kv ...any) *record {
+	r := &record{values: map[string]any{}}
+	for i := 0; i+1 < len(kv); i += 2 {
+		r.set(kv[i].(string), kv[i+1])
+	}
+	return r
+}
+
+func (r *record) set(key string, value any) *record {
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.values[key] = value
+	return r
+}
+
+func (r *record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		val, err := json.Marshal(r.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (r *record) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, k := range r.keys {
+		var v yaml.Node
+		if err := v.Encode(r.values[k]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: k}, &v)
+	}
+	return node, nil
+}
+
+// listing is a record whose csv/tsv form is one row per item, such as
+// the patches of a chart; json and yaml show the whole record.
+type listing struct {
+	*record
+	items []*record
+}
+
+func (l listing) table() ([]string, [][]string) {
+	header, rows, _ := recordsTable(l.items)
+	return header, rows
+}
+
+// -------------------------------
+// Rendering
+// -------------------------------
+
+// tabular values supply their own csv/tsv rows.
+type tabular interface {
+	table() (header []string, rows [][]string)
+}
+
+// render writes v to stdout in the --output format.
+func render(v any) error {
+	return renderTo(os.Stdout, v)
+}
+
+// renderTo writes v to w in the --output format. For csv and tsv, v
+// must be tabular, a record or a slice of records.
+func renderTo(w io.Writer, v any) error {
+	switch outputFormat {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(v)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "csv", "tsv":
+		header, rows, err := tableOf(v)
+		if err != nil {
+			return err
+		}
+		cw := csv.NewWriter(w)
+		if outputFormat == "tsv" {
+			cw.Comma = '\t'
+		}
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown output format %q", outputFormat)
+}
+
+// emit renders v, reporting failures the way commands report errors.
+func emit(v any) {
+	if err := render(v); err != nil {
+		fmt.Fprintln(os.Stderr, "Error (Output):", err)
+	}
+}
+
+// -------------------------------
+// Streams
+// -------------------------------
+
+// stream writes a sequence of values in the --output format as they
+// arrive: a JSON array, a YAML sequence or one csv/tsv table.
+type stream struct {
+	w      *bufio.Writer
+	csv    *csv.Writer
+	count  int
+	header bool
+}
+
+func newStream(w io.Writer) *stream {
+	s := &stream{w: bufio.NewWriter(w)}
+	if outputFormat == "csv" || outputFormat == "tsv" {
+		s.csv = csv.NewWriter(s.w)
+		if outputFormat == "tsv" {
+			s.csv.Comma = '\t'
+		}
+	}
+	return s
+}
+
+// write appends one value; for csv and tsv the first value's header is
+// written once.
+func (s *stream) write(v any) error {
+	defer func() { s.count++ }()
+	switch outputFormat {
+	case "json":
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("  ", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		if s.count == 0 {
+			s.w.WriteString("[\n  ")
+		} else {
+			s.w.WriteString(",\n  ")
+		}
+		s.w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	case "yaml":
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode([]any{v}); err != nil {
+			return err
+		}
+		enc.Close()
+		s.w.Write(buf.Bytes())
+	case "csv", "tsv":
+		header, rows, err := tableOf(v)
+		if err != nil {
+			return err
+		}
+		if !s.header {
+			s.csv.Write(header)
+			s.header = true
+		}
+		s.csv.WriteAll(rows)
+		return s.csv.Error()
+	default:
+		return fmt.Errorf("unknown output format %q", outputFormat)
+	}
+	return nil
+}
+
+// close terminates the sequence and flushes it.
+func (s *stream) close() error {
+	if outputFormat == "json" {
+		if s.count == 0 {
+			s.w.WriteString("[")
+		}
+		s.w.WriteString("\n]\n")
+	}
+	if outputFormat == "yaml" && s.count == 0 {
+		s.w.WriteString("[]\n")
+	}
+	return s.w.Flush()
+}
+
+func tableOf(v any) ([]string, [][]string, error) {
+	switch t := v.(type) {
+	case tabular:
+		h, rows := t.table()
+		return h, rows, nil
+	case *record:
+		return recordsTable([]*record{t})
+	case []*record:
+		return recordsTable(t)
+	}
+	return nil, nil, fmt.Errorf("%s output is not supported by this command", outputFormat)
+}
+
+// recordsTable uses the union of keys, in first-seen order, as header.
+func recordsTable(records []*record) ([]string, [][]string, error) {
+	var header []string
+	seen := map[string]bool{}
+	for _, r := range records {
+		for _, k := range r.keys {
+			if !seen[k] {
+				seen[k] = true
+				header = append(header, k)
+			}
+		}
+	}
+	rows := make([][]string, len(records))
+	for i, r := range records {
+		row := make([]string, len(header))
+		for j, k := range header {
+			if v, ok := r.values[k]; ok {
+				row[j] = cell(v)
+			}
+		}
+		rows[i] = row
+	}
+	return header, rows, nil
+}
+
+// cell formats a value for csv/tsv; lists are space-separated and
+// nested records become key=value pairs.
+func cell(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case []float64:
+		parts := make([]string, len(t))
+		for i, f := range t {
+			parts[i] = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return strings.Join(parts, " ")
+	case []int:
+		parts := make([]string, len(t))
+		for i, n := range t {
+			parts[i] = strconv.Itoa(n)
+		}
+		return strings.Join(parts, " ")
+	case []string:
+		return strings.Join(t, " ")
+	case *record:
+		parts := make([]string, len(t.keys))
+		for i, k := range t.keys {
+			parts[i] = k + "=" + cell(t.values[k])
+		}
+		return strings.Join(parts, " ")
+	case []*record:
+		parts := make([]string, len(t))
+		for i, r := range t {
+			parts[i] = cell(r)
+		}
+		return strings.Join(parts, "; ")
+	}
+	return fmt.Sprint(v)
+}
+
+// round keeps machine output readable without losing useful precision.
+// Tiny negatives that round to zero come back as 0, not -0.
+func round(v float64, places int) float64 {
+	p, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'f', places, 64), 64)
+	if p == 0 {
+		return 0
+	}
+	return p
+}
+
+// roundAll rounds every value of a slice.
+func roundAll(v []float64, places int) []float64 {
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = round(x, places)
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withOutput sets --output for the duration of a test.
+func withOutput(t *testing.T, format string) {
+	t.Helper()
+	prev := outputFormat
+	outputFormat = format
+	t.Cleanup(func() { outputFormat = prev })
+}
+
+func sampleRecord() *record {
+	return newRecord("input", "#FF5733", "rgb", []int{255, 87, 51}).
+		set("lab", []float64{60.1, 62.3, 58.4}).
+		set("input", "#ff5733") // replacing keeps the key's position
+}
+
+func TestRenderKeepsKeyOrder(t *testing.T) {
+	for _, tc := range []struct {
+		format, want string
+	}{
+		{"json", `{
+  "input": "#ff5733",
+  "rgb": [
+    255,
+    87,
+    51
+  ],
+  "lab": [
+    60.1,
+    62.3,
+    58.4
+  ]
+}
+`},
+		{"yaml", `input: '#ff5733'
+rgb:
+  - 255
+  - 87
+  - 51
+lab:
+  - 60.1
+  - 62.3
+  - 58.4
+`},
+		{"csv", "input,rgb,lab\n#ff5733,255 87 51,60.1 62.3 58.4\n"},
+		{"tsv", "input\trgb\tlab\n#ff5733\t255 87 51\t60.1 62.3 58.4\n"},
+	} {
+		withOutput(t, tc.format)
+		var buf bytes.Buffer
+		if err := renderTo(&buf, sampleRecord()); err != nil {
+			t.Fatalf("%s: %v", tc.format, err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("%s:\n%s\nwant:\n%s", tc.format, buf.String(), tc.want)
+		}
+	}
+}
+
+func TestRenderTableUnionAndCells(t *testing.T) {
+	withOutput(t, "csv")
+	records := []*record{
+		newRecord("a", "x, y", "b", 1.5),
+		newRecord("c", newRecord("k", "v", "n", []string{"p", "q"}), "a", "z"),
+		newRecord("d", []*record{newRecord("i", 1.0), newRecord("i", 2.0)}),
+	}
+	var buf bytes.Buffer
+	if err := renderTo(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+	want := "a,b,c,d\n\"x, y\",1.5,,\nz,,k=v n=p q,\n,,,i=1; i=2\n"
+	if buf.String() != want {
+		t.Errorf("csv:\n%q\nwant:\n%q", buf.String(), want)
+	}
+}
+
+func TestRenderUnsupported(t *testing.T) {
+	for _, format := range []string{"csv", "tsv"} {
+		withOutput(t, format)
+		if err := renderTo(&bytes.Buffer{}, map[string]int{"a": 1}); err == nil {
+			t.Errorf("%s: expected an error for a non-tabular value", format)
+		}
+	}
+	withOutput(t, "xml")
+	if err := renderTo(&bytes.Buffer{}, sampleRecord()); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestStream(t *testing.T) {
+	items := []*record{newRecord("i", 1.0, "hex", "#000000"), newRecord("i", 2.0, "hex", "#ffffff")}
+	for _, format := range []string{"json", "yaml", "csv", "tsv"} {
+		for n := 0; n <= len(items); n++ {
+			withOutput(t, format)
+			var buf bytes.Buffer
+			s := newStream(&buf)
+			for _, it := range items[:n] {
+				if err := s.write(it); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.close(); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []map[string]any
+			switch format {
+			case "json":
+				if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+					t.Fatalf("json, %d items: %v\n%s", n, err, buf.String())
+				}
+			case "yaml":
+				if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+					t.Fatalf("yaml, %d items: %v\n%s", n, err, buf.String())
+				}
+			default:
+				lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+				if n == 0 {
+					if buf.Len() != 0 {
+						t.Errorf("%s, no items: %q", format, buf.String())
+					}
+					continue
+				}
+				if len(lines) != n+1 {
+					t.Errorf("%s, %d items: header written more than once?\n%s", format, n, buf.String())
+				}
+				continue
+			}
+			if len(got) != n {
+				t.Fatalf("%s: %d items decoded, want %d", format, len(got), n)
+			}
+			for i, m := range got {
+				if m["hex"] != items[i].values["hex"] {
+					t.Errorf("%s item %d = %v", format, i, m)
+				}
+			}
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	for _, tc := range []struct {
+		v      float64
+		places int
+		want   float64
+	}{{1.23456, 2, 1.23}, {-1e-9, 4, 0}, {math.Copysign(0, -1), 2, 0}, {2.6, 0, 3}, {-0.0151, 2, -0.02}} {
+		got := round(tc.v, tc.places)
+		if got != tc.want || math.Signbit(got) != math.Signbit(tc.want) {
+			t.Errorf("round(%v, %d) = %v, want %v", tc.v, tc.places, got, tc.want)
+		}
+	}
+}
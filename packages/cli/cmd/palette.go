@@ -4,120 +4,109 @@ Copyright © 2025
 package cmd
 
 import (
-	"bufio"
 	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
 	"fmt"
-	"os"
-	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 )
 
+var (
+	paletteHarmony string
+	paletteCount   int
+	paletteSpace   string
+	paletteAngle   float64
+	paletteAngles  []float64
+)
+
 // paletteCmd represents the palette command
 var paletteCmd = &cobra.Command{
-	Use:   "palette",
-	Short: "Generate a 3-color palette from a HEX input",
-	Long: `Generate a 3-color palette using 1 of 3 design styles:
-1. Balanced professional (Triadic)
-2. High-contrast (Complementary)
-3. Soft aesthetic (Analogous)`,
+	Use:   "palette <color>",
+	Short: "Generate a color harmony palette from a base color",
+	Long: `Generate a palette by rotating the hue of a base color (any CSS color).
+Harmonies:
+- complementary        base, +180
+- analogous            base, ±angle (default 30)
+- triadic              base, +120, +240
+- split-complementary  base, 180±angle (default 30)
+- tetradic             base, +angle, +180, +180+angle (default 60)
+- square               base, +90, +180, +270
+- monochromatic        the base hue in steps of lightness
+- compound             base, +angle, +180, +180-angle (default 30)
+- custom               base plus the offsets given with --angles
+
+The hue is rotated in OKLCH by default, or HCL, so lightness stays
+perceptually constant; hsl keeps the old saturation/lightness rotation.
+--count sets the palette size: analogous spreads further around the
+wheel, monochromatic takes more lightness steps, and the others add
+lighter and darker variants of their hues.
+
+Example:
+  colors-cli palette "#FF6600"
+  colors-cli palette "#FF6600" --harmony split-complementary --count 6
+  colors-cli palette "oklch(0.6 0.15 250)" --harmony monochromatic --count 7
+  colors-cli palette tomato --angles 72,144,216,288 --space hcl`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
 
-		reader := bufio.NewReader(os.Stdin)
-
-		// ----------------------------
-		// 1) Ask for base HEX input
-		// ----------------------------
-		fmt.Print("Enter base HEX (e.g. #ff6600): ")
-		hexInput, _ := reader.ReadString('\n')
-		hexInput = strings.TrimSpace(hexInput)
-
-		if !colors.IsValidHex(hexInput) {
-			fmt.Println("❌ Invalid hex color.")
+		base, err := colors.Parse(args[0])
+		if err != nil {
+			fmt.Println("Error (Color):", err)
 			return
 		}
 
-		hex := colors.Hex(hexInput) // wrap input in Hex type
-
-		// HEX → HSL
-		h, s, l, err := hex.ToHSL()
+		harmony, err := colors.ParseHarmony(paletteHarmony)
 		if err != nil {
-			fmt.Println("Error converting to HSL:", err)
+			fmt.Println("Error (Harmony):", err)
 			return
 		}
-		baseHue := h
-		baseS := s
-		baseL := l
-
-		// ----------------------------
-		// 2) Ask for palette style
-		// ----------------------------
-		paletteOptions := []string{
-			"Balanced professional (Triadic)",
-			"High-contrast (Complementary)",
-			"Soft aesthetic (Analogous)",
+		if len(paletteAngles) > 0 && !cmd.Flags().Changed("harmony") {
+			harmony = colors.HarmonyCustom
 		}
 
-		var selected string
-		prompt := &survey.Select{
-			Message: "Choose palette style:",
-			Options: paletteOptions,
+		space, err := colors.ParseSpace(paletteSpace)
+		if err != nil {
+			fmt.Println("Error (Space):", err)
+			return
 		}
 
-		survey.AskOne(prompt, &selected)
-
-		// ----------------------------
-		// 3) Compute palette hues
-		// ----------------------------
-		var base, support, accent float64
-
-		switch selected {
-		case "Balanced professional (Triadic)":
-			base = baseHue
-			support = baseHue + 120
-			accent = baseHue + 240
-
-		case "High-contrast (Complementary)":
-			base = baseHue
-			support = baseHue + 30
-			accent = baseHue + 180
-
-		case "Soft aesthetic (Analogous)":
-			base = baseHue
-			support = baseHue - 30
-			accent = baseHue + 30
+		palette, err := colors.Harmonize(base, harmony, colors.HarmonyOptions{
+			Space:  space,
+			Count:  paletteCount,
+			Angle:  paletteAngle,
+			Angles: paletteAngles,
+		})
+		if err != nil {
+			fmt.Println("Error (Palette):", err)
+			return
 		}
 
-		// Normalize hues
-		hues := []*float64{&base, &support, &accent}
-		for _, h := range hues {
-			for *h < 0 {
-				*h += 360
-			}
-			for *h >= 360 {
-				*h -= 360
+		// the palette is in the space the hue was rotated in
+		hue := palette[0].Space.HueChannel()
+		if machineOutput() {
+			records := make([]*record, len(palette))
+			for i, c := range palette {
+				records[i] = newRecord("harmony", harmony.String(), "hex", c.Hex(), "color", c.String(), "hue", round(c.V[hue], 2))
 			}
+			emit(records)
+			return
 		}
 
-		// ----------------------------
-		// 4) Convert back to HEX
-		// ----------------------------
-		baseHex := colors.HSLToHex(colors.HSL{H: base, S: baseS, L: baseL})
-		supportHex := colors.HSLToHex(colors.HSL{H: support, S: baseS, L: baseL})
-		accentHex := colors.HSLToHex(colors.HSL{H: accent, S: baseS, L: baseL})
-
-		// ----------------------------
-		// 5) Output
-		// ----------------------------
-		fmt.Println("\n🎨 Generated Palette:")
-		fmt.Printf("Base:    %s  (H=%.0f)\n", baseHex, base)
-		fmt.Printf("Support: %s  (H=%.0f)\n", supportHex, support)
-		fmt.Printf("Accent:  %s  (H=%.0f)\n", accentHex, accent)
+		fmt.Printf("\n🎨 %s palette (%s):\n", harmony, space)
+		for i, c := range palette {
+			fmt.Printf("%2d  %s  %-28s (H=%.0f)\n", i+1, c.Hex(), c, c.V[hue])
+		}
 		fmt.Println()
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(paletteCmd)
+
+	paletteCmd.Flags().StringVar(&paletteHarmony, "harmony", "triadic", "Harmony (complementary, analogous, triadic, split-complementary, tetradic, square, monochromatic, compound, custom)")
+	paletteCmd.Flags().IntVarP(&paletteCount, "count", "n", 0, "Number of colors (0 = the harmony's own size)")
+	paletteCmd.Flags().StringVar(&paletteSpace, "space", "oklch", "Space to rotate the hue in (oklch, hcl, hsl)")
+	paletteCmd.Flags().Float64Var(&paletteAngle, "angle", 0, "Spread in degrees for analogous, split-complementary, tetradic and compound (0 = default)")
+	paletteCmd.Flags().Float64SliceVar(&paletteAngles, "angles", nil, "Hue offsets for the custom harmony, e.g. 72,144,216,288")
 }
@@ -21,6 +21,7 @@ Example:
 	Run: func(cmd *cobra.Command, args []string) {
 		figlet.LogProgramName()
 
+		var records []*record
 		for i := 0; i < maxHEX; i++ {
 			newHEX := colors.GenerateRandomHexColor()
 			hex := colors.Hex(newHEX) // wrap string in Hex type
@@ -31,8 +32,15 @@ Example:
 				continue
 			}
 
+			if machineOutput() {
+				records = append(records, newRecord("hex", newHEX, "rgb", []int{r, g, b}))
+				continue
+			}
 			fmt.Printf("%s - rgb(%d, %d, %d)\n", newHEX, r, g, b)
 		}
+		if machineOutput() {
+			emit(records)
+		}
 	},
 }
 
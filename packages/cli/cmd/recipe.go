@@ -0,0 +1,154 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var recipeBases string
+
+// baseColorant is one entry of the --bases file: either a masstone
+// color or a measured reflectance spectrum.
+type baseColorant struct {
+	Name     string `json:"name"`
+	Color    string `json:"color,omitempty"`
+	Spectrum *struct {
+		Start  float64   `json:"start"`
+		Step   float64   `json:"step"`
+		Values []float64 `json:"values"`
+	} `json:"spectrum,omitempty"`
+}
+
+// recipeCmd represents the recipe command
+var recipeCmd = &cobra.Command{
+	Use:   "recipe <color>",
+	Short: "Find how to mix a HEX color from a set of base inks or paints",
+	Long: `Solve for the proportions of the given base colorants whose
+Kubelka–Munk mixture is closest to the target (CIEDE2000).
+
+The bases file is a JSON list; each entry has a name and either a
+masstone color or a reflectance spectrum (0–1):
+
+  [
+    {"name": "Cyan",   "color": "#00AEEF"},
+    {"name": "Yellow", "spectrum": {"start": 400, "step": 10, "values": [0.05, ...]}}
+  ]
+
+Example:
+  colors-cli recipe #2E8B57 --bases inks.json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		target, err := hexToRGB(args[0])
+		if err != nil {
+			fmt.Println("Error (HEX)  :", err)
+			return
+		}
+
+		set, err := loadColorants(recipeBases)
+		if err != nil {
+			fmt.Println("Error (Bases):", err)
+			return
+		}
+
+		recipe, err := set.Recipe(target)
+		if err != nil {
+			fmt.Println("Error (Recipe):", err)
+			return
+		}
+
+		order := make([]int, len(set))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return recipe.Proportions[order[i]] > recipe.Proportions[order[j]]
+		})
+
+		hex, _ := recipe.Result.ToHex()
+		if machineOutput() {
+			parts := []*record{}
+			for _, i := range order {
+				if recipe.Proportions[i] >= 0.0005 {
+					parts = append(parts, newRecord("name", set[i].Name, "percent", round(recipe.Proportions[i]*100, 2)))
+				}
+			}
+			emit(newRecord("target", args[0], "recipe", parts, "result", hex, "delta_e", round(recipe.DeltaE, 4)))
+			return
+		}
+
+		fmt.Println("Recipe:")
+		for _, i := range order {
+			if recipe.Proportions[i] < 0.0005 {
+				continue
+			}
+			fmt.Printf("  %-20s %6.2f%%\n", set[i].Name, recipe.Proportions[i]*100)
+		}
+
+		fmt.Printf("Result : %s\n", hex)
+		fmt.Printf("ΔE00   : %.2f\n", recipe.DeltaE)
+	},
+}
+
+// loadColorants reads a bases file into a pigment set.
+func loadColorants(path string) (colors.PigmentSet, error) {
+	if path == "" {
+		return nil, fmt.Errorf("--bases is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bases []baseColorant
+	if err := json.Unmarshal(data, &bases); err != nil {
+		return nil, err
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("%s lists no colorants", path)
+	}
+
+	set := make(colors.PigmentSet, 0, len(bases))
+	for i, b := range bases {
+		name := b.Name
+		if name == "" {
+			name = fmt.Sprintf("base %d", i+1)
+		}
+
+		switch {
+		case b.Spectrum != nil:
+			s, err := colors.NewSpectrum(b.Spectrum.Start, b.Spectrum.Step, b.Spectrum.Values)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			set = append(set, colors.PigmentFromReflectance(name, s))
+		case b.Color != "":
+			rgb, err := hexToRGB(b.Color)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			p, err := colors.PigmentFromRGB(name, rgb)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			set = append(set, p)
+		default:
+			return nil, fmt.Errorf("%s: needs a color or a spectrum", name)
+		}
+	}
+	return set, nil
+}
+
+func init() {
+	rootCmd.AddCommand(recipeCmd)
+
+	recipeCmd.Flags().StringVarP(&recipeBases, "bases", "b", "", "JSON file of base colorants")
+}
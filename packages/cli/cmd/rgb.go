@@ -2,12 +2,10 @@
 package cmd
 
 import (
-	"bufio"
 	"colors-cli/utils/colors"
 	"colors-cli/utils/figlet"
 	"fmt"
-	"os"
-	"strconv"
+	"math"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,54 +13,41 @@ import (
 
 // rgbCmd represents the RGB-to-other-color-spaces command
 var rgbCmd = &cobra.Command{
-	Use:   "rgb",
+	Use:   "rgb [r g b]",
 	Short: "Convert RGB color to HEX, HSL, HCL, OKLCH, and CMYK",
 	Long: `Convert an RGB color to multiple color spaces:
 - HEX
 - HSL (Hue, Saturation, Lightness)
 - HCL (Hue, Chroma, Lightness)
 - OKLCH (Lightness, Chroma, Hue)
-- CMYK (Cyan, Magenta, Yellow, Black)
+- CMYK (Cyan, Magenta, Yellow, Black), as percentages (0–100)
 
 Example:
-  colors-cli rgb`,
+  colors-cli rgb 255 87 51
+  colors-cli rgb "rgb(255, 87, 51)"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		figlet.LogProgramName()
 
-		reader := bufio.NewReader(os.Stdin)
-
-		// Prompt for R
-		fmt.Print("R (0-255): ")
-		rStr, _ := reader.ReadString('\n')
-		rStr = strings.TrimSpace(rStr)
-		r, err := strconv.Atoi(rStr)
+		// Read R, G, B (arguments, or prompts)
+		input, err := readInput(args, "R (0-255): ", "G (0-255): ", "B (0-255): ")
 		if err != nil {
-			fmt.Println("Error (R)    :", err)
+			failConversion("Error (Input):", "", err)
 			return
 		}
-
-		// Prompt for G
-		fmt.Print("G (0-255): ")
-		gStr, _ := reader.ReadString('\n')
-		gStr = strings.TrimSpace(gStr)
-		g, err := strconv.Atoi(gStr)
+		values, err := parseNumbers(input, 3)
 		if err != nil {
-			fmt.Println("Error (G)    :", err)
+			failConversion("Error (RGB)  :", strings.Join(input, " "), err)
 			return
 		}
+		r, g, b := int(math.Round(values[0])), int(math.Round(values[1])), int(math.Round(values[2]))
 
-		// Prompt for B
-		fmt.Print("B (0-255): ")
-		bStr, _ := reader.ReadString('\n')
-		bStr = strings.TrimSpace(bStr)
-		b, err := strconv.Atoi(bStr)
-		if err != nil {
-			fmt.Println("Error (B)    :", err)
+		rgb := colors.RGB{R: r, G: g, B: b}
+
+		if machineOutput() {
+			emitConversion(input, rgb)
 			return
 		}
 
-		rgb := colors.RGB{R: r, G: g, B: b}
-
 		// RGB → HEX
 		hexStr, err := rgb.ToHex()
 		if err != nil {
@@ -96,15 +81,12 @@ Example:
 		}
 
 		// RGB → CMYK
-		C, M, Y, K, err := rgb.ToCMYK()
-		if err != nil {
-			fmt.Println("Error (CMYK) :", err)
-		} else {
-			fmt.Printf("CMYK   : C=%.3f, M=%.3f, Y=%.3f, K=%.3f\n", C, M, Y, K)
-		}
+		printCMYK(rgb, 100)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(rgbCmd)
+
+	addCMYKFlags(rgbCmd)
 }
@@ -4,7 +4,11 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"colors-cli/utils/figlet"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -22,6 +26,14 @@ to quickly create a Cobra application.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		outputFormat = strings.ToLower(outputFormat)
+		if !slices.Contains(outputFormats, outputFormat) {
+			return fmt.Errorf("unknown --output %q (%s)", outputFormat, strings.Join(outputFormats, ", "))
+		}
+		figlet.Quiet = machineOutput()
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -39,6 +51,7 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.colors-cli.yaml)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "text", "Output format (text, json, yaml, csv, tsv)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,237 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scaleSteps    int
+	scaleHueShift float64
+	scaleLightest float64
+	scaleDarkest  float64
+	scaleName     string
+	scaleFormat   string
+	scaleSystem   string
+	scaleMode     string
+	scaleLightBg  string
+	scaleDarkBg   string
+)
+
+// scaleCmd represents the scale command
+var scaleCmd = &cobra.Command{
+	Use:   "scale <color>",
+	Short: "Generate a tint/shade ramp (50–950) or a Radix 12-step scale",
+	Long: `Generate a Tailwind-style ramp from light to dark around a color.
+
+Lightness is distributed in OKLCH from --lightest to --darkest, and the
+input lands on the step nearest its own lightness. Chroma tapers toward
+both ends and every step is mapped into the sRGB gamut. --hue-shift
+turns the hue up to that many degrees toward yellow at the light end
+and blue at the dark end.
+
+--system radix builds a Radix-style 12-step scale instead, with the
+color as step 9 (solid background):
+  1–2  app and subtle backgrounds     6–8  borders (subtle, element, hover)
+  3–5  element backgrounds (hover,    9–10 solid backgrounds (hover)
+       active)                        11–12 low- and high-contrast text
+in light and dark variants (--appearance), each with a matching alpha
+scale: translucent colors that show the same over the page background
+(--background, --dark-background).
+
+--format css prints CSS custom properties and --format tailwind a
+Tailwind v4 @theme block; --output json/yaml/csv gives the steps as
+records.
+
+Example:
+  colors-cli scale "#3B82F6"
+  colors-cli scale "oklch(0.62 0.19 260)" --steps 11 --hue-shift 10
+  colors-cli scale tomato --name accent --format tailwind
+  colors-cli scale "#0090FF" --system radix --name blue --format css`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToLower(scaleFormat)
+		if format != "text" {
+			figlet.Quiet = true
+		}
+		figlet.LogProgramName()
+
+		if format != "text" && format != "css" && format != "tailwind" {
+			fmt.Println("Error (Format):", fmt.Errorf("unknown format %q (text, css, tailwind)", scaleFormat))
+			return
+		}
+
+		base, err := colors.Parse(args[0])
+		if err != nil {
+			fmt.Println("Error (Color):", err)
+			return
+		}
+		switch strings.ToLower(scaleSystem) {
+		case "radix":
+			runRadixScale(base, format)
+			return
+		case "tailwind":
+		default:
+			fmt.Println("Error (System):", fmt.Errorf("unknown system %q (tailwind, radix)", scaleSystem))
+			return
+		}
+
+		if scaleSteps < 2 {
+			fmt.Println("Error (Steps):", "--steps must be at least 2")
+			return
+		}
+		if scaleDarkest < 0 || scaleLightest > 1 || scaleLightest <= scaleDarkest {
+			fmt.Println("Error (Lightness):", "need 0 ≤ --darkest < --lightest ≤ 1")
+			return
+		}
+
+		shades := colors.Shades(base, colors.ShadeOptions{
+			Steps:    scaleSteps,
+			Lightest: scaleLightest,
+			Darkest:  scaleDarkest,
+			HueShift: scaleHueShift,
+		})
+
+		if machineOutput() {
+			records := make([]*record, len(shades))
+			for i, s := range shades {
+				records[i] = newRecord("name", s.Name, "hex", s.Color.Hex(), "color", s.Color.String(), "base", s.Base)
+			}
+			emit(records)
+			return
+		}
+
+		vars := make([][2]string, len(shades))
+		for i, s := range shades {
+			vars[i] = [2]string{scaleName + "-" + s.Name, s.Color.String()}
+		}
+		switch format {
+		case "css":
+			printCSSVariables(":root", vars)
+		case "tailwind":
+			printTailwindTheme(vars)
+		case "text":
+			for _, s := range shades {
+				line := fmt.Sprintf("%-5s %s  %s", s.Name, s.Color.Hex(), s.Color)
+				if s.Base {
+					line = fmt.Sprintf("%-44s ← base", line)
+				}
+				fmt.Println(line)
+			}
+		}
+	},
+}
+
+// runRadixScale prints light and/or dark 12-step scales with their alpha
+// scales.
+func runRadixScale(base colors.Color, format string) {
+	var modes []bool
+	switch strings.ToLower(scaleMode) {
+	case "light":
+		modes = []bool{false}
+	case "dark":
+		modes = []bool{true}
+	case "both":
+		modes = []bool{false, true}
+	default:
+		fmt.Println("Error (Appearance):", fmt.Errorf("unknown appearance %q (light, dark, both)", scaleMode))
+		return
+	}
+	lightBg, err := colors.Parse(scaleLightBg)
+	if err != nil {
+		fmt.Println("Error (Background):", err)
+		return
+	}
+	darkBg, err := colors.Parse(scaleDarkBg)
+	if err != nil {
+		fmt.Println("Error (Background):", err)
+		return
+	}
+
+	var records []*record
+	for _, dark := range modes {
+		appearance, bg := "light", lightBg
+		if dark {
+			appearance, bg = "dark", darkBg
+		}
+		steps := colors.RadixScale(base, dark, bg)
+
+		if machineOutput() {
+			for _, s := range steps {
+				records = append(records, newRecord(
+					"appearance", appearance, "step", s.Step, "role", s.Role,
+					"hex", s.Color.Hex(), "alpha", s.Alpha.Hex(), "color", s.Color.String(),
+				))
+			}
+			continue
+		}
+
+		vars := make([][2]string, 0, 24)
+		for _, s := range steps {
+			vars = append(vars, [2]string{fmt.Sprintf("%s-%d", scaleName, s.Step), s.Color.Hex()})
+		}
+		for _, s := range steps {
+			vars = append(vars, [2]string{fmt.Sprintf("%s-a%d", scaleName, s.Step), s.Alpha.Hex()})
+		}
+		switch {
+		case format == "text":
+			fmt.Printf("%s (over %s):\n", appearance, bg.Hex())
+			for _, s := range steps {
+				fmt.Printf("%3d  %s  %-9s  %s\n", s.Step, s.Color.Hex(), s.Alpha.Hex(), s.Role)
+			}
+			fmt.Println()
+		case format == "css" && dark:
+			printCSSVariables(".dark, .dark-theme", vars)
+		case format == "css":
+			printCSSVariables(":root, .light, .light-theme", vars)
+		case format == "tailwind" && dark:
+			for i := range vars {
+				vars[i][0] = "color-" + vars[i][0]
+			}
+			printCSSVariables(".dark", vars)
+		case format == "tailwind":
+			printTailwindTheme(vars)
+		}
+	}
+	if machineOutput() {
+		emit(records)
+	}
+}
+
+// printCSSVariables prints name/value pairs as custom properties.
+func printCSSVariables(selector string, vars [][2]string) {
+	fmt.Printf("%s {\n", selector)
+	for _, v := range vars {
+		fmt.Printf("  --%s: %s;\n", v[0], v[1])
+	}
+	fmt.Println("}")
+}
+
+// printTailwindTheme prints name/value pairs as Tailwind v4 theme colors.
+func printTailwindTheme(vars [][2]string) {
+	fmt.Println("@theme {")
+	for _, v := range vars {
+		fmt.Printf("  --color-%s: %s;\n", v[0], v[1])
+	}
+	fmt.Println("}")
+}
+
+func init() {
+	rootCmd.AddCommand(scaleCmd)
+
+	scaleCmd.Flags().IntVar(&scaleSteps, "steps", colors.DefaultShadeOptions.Steps, "Number of steps (11 gives 50–950)")
+	scaleCmd.Flags().Float64Var(&scaleHueShift, "hue-shift", colors.DefaultShadeOptions.HueShift, "Degrees the hue turns toward warm (light end) and cool (dark end)")
+	scaleCmd.Flags().Float64Var(&scaleLightest, "lightest", colors.DefaultShadeOptions.Lightest, "OKLCH lightness of the lightest step")
+	scaleCmd.Flags().Float64Var(&scaleDarkest, "darkest", colors.DefaultShadeOptions.Darkest, "OKLCH lightness of the darkest step")
+	scaleCmd.Flags().StringVar(&scaleName, "name", "brand", "Variable name prefix for css and tailwind output")
+	scaleCmd.Flags().StringVar(&scaleFormat, "format", "text", "Text output format (text, css, tailwind)")
+	scaleCmd.Flags().StringVar(&scaleSystem, "system", "tailwind", "Scale system (tailwind, radix)")
+	scaleCmd.Flags().StringVar(&scaleMode, "appearance", "both", "Radix variants to build (light, dark, both)")
+	scaleCmd.Flags().StringVar(&scaleLightBg, "background", "#FFFFFF", "Page background the light alpha scale composites over")
+	scaleCmd.Flags().StringVar(&scaleDarkBg, "dark-background", "#111111", "Page background the dark alpha scale composites over")
+}
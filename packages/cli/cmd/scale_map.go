@@ -0,0 +1,266 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scaleMapColors      []string
+	scaleMapDomain      []float64
+	scaleMapIn          string
+	scaleMapHue         string
+	scaleMapBezier      bool
+	scaleMapLightness   bool
+	scaleMapClasses     int
+	scaleMapClassMethod string
+	scaleMapBreaks      []float64
+	scaleMapFile        string
+	scaleMapColumn      string
+)
+
+// scaleMapCmd represents the scale-map command
+var scaleMapCmd = &cobra.Command{
+	Use:   "scale-map",
+	Short: "Map a column of numbers to colors with a data scale",
+	Long: `Read numbers from stdin or --file, one per line or from one --column of
+a CSV file (a header name, or a 1-based index), and map each to a color.
+
+The scale runs through --colors (repeat the flag or separate colors
+with commas) over --domain, which defaults to the data's minimum and
+maximum; give one domain value per color to pin each color to a value.
+Colors are interpolated in --in (lab by default) with --hue for polar
+spaces, or along one Bézier curve in Lab with --bezier.
+--correct-lightness evens out lightness from the first color to the
+last.
+
+--classes makes the scale discrete with that many classes split by
+--class-method:
+  equal     equal-width intervals
+  quantile  the same number of values per class
+  kmeans    1-D k-means clusters
+  jenks     Jenks natural breaks
+or give the boundaries yourself with --breaks.
+
+Example:
+  colors-cli scale-map --file data.csv --column revenue
+  seq 0 10 | colors-cli scale-map --colors "#FFFFE0,#00429D" --correct-lightness
+  colors-cli scale-map --file data.csv --column 2 --classes 5 --class-method jenks --output csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		figlet.LogProgramName()
+
+		rows, err := readScaleValues()
+		if err != nil {
+			fmt.Println("Error (Input):", err)
+			return
+		}
+		var values []scaleValue
+		for _, v := range rows {
+			if v.err == nil {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			fmt.Println("Error (Input):", errors.New("no numbers to map"))
+			return
+		}
+
+		opt, err := scaleMapOptions(values)
+		if err != nil {
+			fmt.Println("Error (Scale):", err)
+			return
+		}
+		var stops []colors.Color
+		for _, arg := range scaleMapColors {
+			for _, s := range splitColorList(arg) {
+				c, err := colors.Parse(s)
+				if err != nil {
+					fmt.Println("Error (Color):", err)
+					return
+				}
+				stops = append(stops, c)
+			}
+		}
+		scale, err := colors.NewScale(stops, opt)
+		if err != nil {
+			fmt.Println("Error (Scale):", err)
+			return
+		}
+
+		var records []*record
+		if !machineOutput() && len(opt.Breaks) > 1 {
+			fmt.Println("Classes:")
+			for i, c := range scale.Colors(0) {
+				fmt.Printf("  %d  %s  %s – %s\n", i+1, c.MapToSRGB().Hex(), formatValue(opt.Breaks[i]), formatValue(opt.Breaks[i+1]))
+			}
+			fmt.Println()
+		}
+		for _, v := range rows {
+			if v.err != nil {
+				if !machineOutput() {
+					fmt.Printf("Error (Line %d): %v\n", v.line, v.err)
+					continue
+				}
+				records = append(records, newRecord("line", v.line, "input", v.text, "error", v.err.Error()))
+				continue
+			}
+			c := scale.At(v.value)
+			hex := c.MapToSRGB().Hex()
+			if !machineOutput() {
+				fmt.Printf("%5d  %12s  %s  %s\n", v.line, formatValue(v.value), hex, c)
+				continue
+			}
+			rec := newRecord("line", v.line, "value", v.value)
+			if len(opt.Breaks) > 1 {
+				rec.set("class", scale.Class(v.value)+1)
+			}
+			records = append(records, rec.set("hex", hex).set("color", c.String()))
+		}
+		if failed := len(rows) - len(values); failed > 0 && !machineOutput() {
+			fmt.Fprintf(os.Stderr, "%d of %d values failed\n", failed, len(rows))
+		}
+		if machineOutput() {
+			emit(records)
+		}
+	},
+}
+
+// scaleValue is one row read for scale-map: a number, or the error
+// that kept its text from being one.
+type scaleValue struct {
+	line  int
+	text  string
+	value float64
+	err   error
+}
+
+// readScaleValues reads the rows from --file or stdin with readBatch.
+// Rows that are not a finite number keep their error, so that they are
+// reported in place rather than dropped.
+func readScaleValues() ([]scaleValue, error) {
+	var r io.Reader = os.Stdin
+	if scaleMapFile != "" && scaleMapFile != "-" {
+		f, err := os.Open(scaleMapFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	} else if stdinIsTerminal() {
+		return nil, errors.New("pipe numbers on stdin or pass --file")
+	}
+
+	var rows []scaleValue
+	err := readBatch(r, scaleMapColumn, func(item *batchItem) {
+		row := scaleValue{line: item.line, text: item.text, err: item.err}
+		if row.err == nil {
+			v, err := strconv.ParseFloat(item.text, 64)
+			if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				row.err = fmt.Errorf("not a number: %q", item.text)
+			}
+			row.value = v
+		}
+		rows = append(rows, row)
+	})
+	return rows, err
+}
+
+// scaleMapOptions builds the scale options, defaulting the domain to
+// the data's range and computing class breaks from the data.
+func scaleMapOptions(values []scaleValue) (colors.ScaleOptions, error) {
+	opt := colors.ScaleOptions{Bezier: scaleMapBezier, CorrectLightness: scaleMapLightness}
+	spaceName, hueName, _ := strings.Cut(strings.TrimSpace(scaleMapIn), " ")
+	if strings.TrimSpace(hueName) == "" {
+		hueName = scaleMapHue
+	}
+	var err error
+	if opt.Space, err = colors.ParseSpace(spaceName); err != nil {
+		return opt, err
+	}
+	if opt.Hue, err = colors.ParseHueMethod(hueName); err != nil {
+		return opt, err
+	}
+
+	data := make([]float64, len(values))
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, v := range values {
+		data[i] = v.value
+		lo, hi = math.Min(lo, v.value), math.Max(hi, v.value)
+	}
+	opt.Domain = scaleMapDomain
+	if len(opt.Domain) == 0 {
+		if lo == hi {
+			hi = lo + 1
+		}
+		opt.Domain = []float64{lo, hi}
+	}
+
+	switch {
+	case len(scaleMapBreaks) > 0 && scaleMapClasses > 0:
+		return opt, errors.New("--classes and --breaks are mutually exclusive")
+	case len(scaleMapBreaks) > 0:
+		opt.Breaks = scaleMapBreaks
+	case scaleMapClasses > 0:
+		method, err := colors.ParseClassMethod(scaleMapClassMethod)
+		if err != nil {
+			return opt, err
+		}
+		if opt.Breaks, err = colors.ClassBreaks(data, scaleMapClasses, method); err != nil {
+			return opt, err
+		}
+	}
+	return opt, nil
+}
+
+// splitColorList splits a comma-separated list of colors, leaving the
+// commas inside functions such as rgb(255, 0, 0) alone.
+func splitColorList(s string) []string {
+	var out []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(out, strings.TrimSpace(s[start:]))
+}
+
+// formatValue prints a number without trailing zeros.
+func formatValue(v float64) string {
+	return strconv.FormatFloat(round(v, 4), 'f', -1, 64)
+}
+
+func init() {
+	rootCmd.AddCommand(scaleMapCmd)
+
+	scaleMapCmd.Flags().StringArrayVar(&scaleMapColors, "colors", []string{"#440154,#3B528B,#21918C,#5EC962,#FDE725"}, "Scale colors, comma-separated or repeated")
+	scaleMapCmd.Flags().Float64SliceVar(&scaleMapDomain, "domain", nil, "Domain values (default: the data's min and max)")
+	scaleMapCmd.Flags().StringVar(&scaleMapIn, "in", "lab", "Interpolation space (e.g. lab, oklch, srgb, \"hsl longer\")")
+	scaleMapCmd.Flags().StringVar(&scaleMapHue, "hue", "shorter", "Hue interpolation for polar spaces (shorter, longer, increasing, decreasing)")
+	scaleMapCmd.Flags().BoolVar(&scaleMapBezier, "bezier", false, "Interpolate a Bézier curve in Lab through the colors")
+	scaleMapCmd.Flags().BoolVar(&scaleMapLightness, "correct-lightness", false, "Even out lightness along the scale")
+	scaleMapCmd.Flags().IntVar(&scaleMapClasses, "classes", 0, "Number of classes (0 for a continuous scale)")
+	scaleMapCmd.Flags().StringVar(&scaleMapClassMethod, "class-method", "equal", "Class breaks (equal, quantile, kmeans, jenks)")
+	scaleMapCmd.Flags().Float64SliceVar(&scaleMapBreaks, "breaks", nil, "Explicit class boundaries, lowest first")
+	scaleMapCmd.Flags().StringVar(&scaleMapFile, "file", "", "Read numbers from this file instead of stdin")
+	scaleMapCmd.Flags().StringVar(&scaleMapColumn, "column", "", "Read this CSV column (header name or 1-based index)")
+}
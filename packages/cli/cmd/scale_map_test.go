@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Rows that are empty or not numbers stay in the output as errors.
+func TestScaleMapReportsBadRows(t *testing.T) {
+	withOutput(t, "json")
+	file := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(file, []byte("name,v\na,1\nb,\nc,x\nd,3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	prevFile, prevColumn := scaleMapFile, scaleMapColumn
+	scaleMapFile, scaleMapColumn = file, "v"
+	t.Cleanup(func() { scaleMapFile, scaleMapColumn = prevFile, prevColumn })
+
+	out := captureStdout(t, func() { scaleMapCmd.Run(scaleMapCmd, nil) })
+	var rows []struct {
+		Line  int
+		Hex   string
+		Error string
+	}
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+	want := []struct {
+		line int
+		bad  bool
+	}{{2, false}, {3, true}, {4, true}, {5, false}}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %s", len(rows), len(want), out)
+	}
+	for i, w := range want {
+		r := rows[i]
+		if r.Line != w.line || (r.Error != "") != w.bad || (r.Hex == "") != w.bad {
+			t.Errorf("row %d = %+v, want line %d with error %v", i, r, w.line, w.bad)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/colors"
+	"colors-cli/utils/figlet"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	themePrimary  string
+	themeAccent   string
+	themeStatus   = map[string]*string{}
+	themeContrast string
+	themeFormat   string
+)
+
+// themeCmd represents the theme command
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "Generate light and dark semantic color tokens from brand colors",
+	Long: `Generate a semantic token set for light and dark modes from a primary
+(and optional accent) brand color:
+  background, surface, on-surface, muted, border,
+  primary, on-primary, [accent, on-accent,]
+  success, warning, danger, info
+
+Neutrals are tinted with the primary hue. on-surface, muted and the
+status colors are checked as text over background and surface, and
+on-primary/on-accent as text over their fills; colors are moved in
+OKLCH lightness until every pair meets --contrast:
+  aa, aaa, aa-large, wcag:<ratio>, apca (Lc 75), apca:<Lc>
+
+--format dtcg prints Design Tokens (DTCG) JSON, css custom properties
+and scss Sass maps; --output json/yaml/csv gives the tokens as records.
+
+Example:
+  colors-cli theme --primary "#3B82F6"
+  colors-cli theme --primary "#7C3AED" --accent "#F59E0B" --contrast apca --format css
+  colors-cli theme --primary teal --danger "#E11D48" --format dtcg > tokens.json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToLower(themeFormat)
+		if format != "text" {
+			figlet.Quiet = true
+		}
+		figlet.LogProgramName()
+
+		if format != "text" && format != "dtcg" && format != "css" && format != "scss" {
+			fmt.Println("Error (Format):", fmt.Errorf("unknown format %q (text, dtcg, css, scss)", themeFormat))
+			return
+		}
+
+		opt := colors.ThemeOptions{Status: map[string]colors.Color{}}
+		var err error
+		if opt.Primary, err = colors.Parse(themePrimary); err != nil {
+			fmt.Println("Error (Primary):", err)
+			return
+		}
+		if themeAccent != "" {
+			accent, err := colors.Parse(themeAccent)
+			if err != nil {
+				fmt.Println("Error (Accent):", err)
+				return
+			}
+			opt.Accent = &accent
+		}
+		for _, status := range colors.ThemeStatuses {
+			if *themeStatus[status] == "" {
+				continue
+			}
+			c, err := colors.Parse(*themeStatus[status])
+			if err != nil {
+				fmt.Printf("Error (%s): %v\n", status, err)
+				return
+			}
+			opt.Status[status] = c
+		}
+		if opt.Target, err = colors.ParseContrastTarget(themeContrast); err != nil {
+			fmt.Println("Error (Contrast):", err)
+			return
+		}
+
+		modes := []string{"light", "dark"}
+		themes := make([][]colors.ThemeToken, len(modes))
+		for i, mode := range modes {
+			if themes[i], err = colors.BuildTheme(opt, mode == "dark"); err != nil {
+				fmt.Printf("Error (%s): %v\n", mode, err)
+				return
+			}
+		}
+
+		if machineOutput() {
+			var records []*record
+			for i, mode := range modes {
+				for _, t := range themes[i] {
+					records = append(records, newRecord(
+						"mode", mode, "token", t.Name, "hex", t.Color.Hex(), "color", t.Color.String(),
+						"on", strings.Join(t.On, " "), "contrast", round(t.Contrast, 2),
+					))
+				}
+			}
+			emit(records)
+			return
+		}
+
+		switch format {
+		case "text":
+			for i, mode := range modes {
+				fmt.Printf("%s (%s):\n", mode, opt.Target)
+				for _, t := range themes[i] {
+					line := fmt.Sprintf("  %-12s %s  %s", t.Name, t.Color.Hex(), t.Color)
+					if len(t.On) > 0 {
+						line = fmt.Sprintf("%-56s %6.2f over %s", line, t.Contrast, strings.Join(t.On, ", "))
+					}
+					fmt.Println(line)
+				}
+				fmt.Println()
+			}
+		case "dtcg":
+			doc := newRecord()
+			for i, mode := range modes {
+				group := newRecord("$type", "color")
+				for _, t := range themes[i] {
+					group.set(t.Name, newRecord("$value", t.Color.Hex()))
+				}
+				doc.set(mode, group)
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(doc); err != nil {
+				fmt.Println("Error (Output):", err)
+			}
+		case "css":
+			selectors := []string{`:root, [data-theme="light"]`, `[data-theme="dark"]`}
+			for i := range modes {
+				vars := make([][2]string, len(themes[i]))
+				for j, t := range themes[i] {
+					vars[j] = [2]string{"color-" + t.Name, t.Color.Hex()}
+				}
+				printCSSVariables(selectors[i], vars)
+			}
+		case "scss":
+			for i, mode := range modes {
+				fmt.Printf("$theme-%s: (\n", mode)
+				for _, t := range themes[i] {
+					fmt.Printf("  \"%s\": %s,\n", t.Name, t.Color.Hex())
+				}
+				fmt.Println(");")
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(themeCmd)
+
+	themeCmd.Flags().StringVar(&themePrimary, "primary", "", "Primary brand color (required)")
+	themeCmd.Flags().StringVar(&themeAccent, "accent", "", "Accent brand color")
+	for _, status := range colors.ThemeStatuses {
+		themeStatus[status] = new(string)
+		themeCmd.Flags().StringVar(themeStatus[status], status, "", "Seed for the "+status+" color (default: built-in hue)")
+	}
+	themeCmd.Flags().StringVar(&themeContrast, "contrast", "aa", "Contrast every pair must meet (aa, aaa, aa-large, wcag:<ratio>, apca, apca:<Lc>)")
+	themeCmd.Flags().StringVar(&themeFormat, "format", "text", "Text output format (text, dtcg, css, scss)")
+	themeCmd.MarkFlagRequired("primary")
+}
@@ -0,0 +1,160 @@
+// Package cmd ...
+package cmd
+
+import (
+	"colors-cli/utils/cgats"
+	"colors-cli/utils/figlet"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyTolerances cgats.Tolerances
+	verifyFormat     string
+	verifyReport     string
+	verifyWorst      int
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify <measured.txt> <reference.txt>",
+	Short: "Compare CGATS measurements against a reference",
+	Long: `Compare a CGATS.17 / IT8 measurement file against a reference, patch
+by patch, using CIEDE2000. Patches are matched by SAMPLE_ID, or by row
+when either file lacks one. LAB_* fields are used, or XYZ_* converted
+to Lab D50.
+
+The average, 95th-percentile and maximum ΔE00 are checked against the
+tolerances; a tolerance of 0 disables that check. The command exits
+with status 1 when any check fails.
+
+Example:
+  colors-cli verify press.txt FOGRA39.txt
+  colors-cli verify press.it8 ref.it8 --avg 2 --p95 4 --max 6 --output json
+  colors-cli verify press.txt ref.txt --report report.txt`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flags().Changed("format") {
+			switch verifyFormat {
+			case "text", "json":
+				outputFormat = verifyFormat
+				figlet.Quiet = machineOutput()
+			default:
+				fmt.Println("Error (Format): unknown format", verifyFormat)
+				os.Exit(2)
+			}
+		}
+		figlet.LogProgramName()
+
+		measured, err := cgats.Open(args[0])
+		if err != nil {
+			fmt.Println("Error (Measured):", err)
+			os.Exit(2)
+		}
+		reference, err := cgats.Open(args[1])
+		if err != nil {
+			fmt.Println("Error (Reference):", err)
+			os.Exit(2)
+		}
+
+		rep, err := cgats.Verify(measured, reference, verifyTolerances)
+		if err != nil {
+			fmt.Println("Error (Verify):", err)
+			os.Exit(2)
+		}
+
+		if verifyReport != "" {
+			f, err := os.Create(verifyReport)
+			if err != nil {
+				fmt.Println("Error (Report):", err)
+				os.Exit(2)
+			}
+			err = rep.ReportFile().Write(f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				fmt.Println("Error (Report):", err)
+				os.Exit(2)
+			}
+		}
+
+		if machineOutput() {
+			if err := render(verifyResult{rep}); err != nil {
+				fmt.Fprintln(os.Stderr, "Error (Output):", err)
+				os.Exit(2)
+			}
+		} else {
+			printVerifyReport(rep)
+		}
+
+		if !rep.Pass {
+			os.Exit(1)
+		}
+	},
+}
+
+// verifyResult renders a report as itself in json/yaml and as one row
+// per patch in csv/tsv.
+type verifyResult struct {
+	cgats.Report `yaml:",inline"`
+}
+
+func (v verifyResult) table() ([]string, [][]string) {
+	header := []string{"id", "measured_l", "measured_a", "measured_b", "reference_l", "reference_a", "reference_b", "delta_e00"}
+	f := func(x float64) string { return strconv.FormatFloat(x, 'f', 4, 64) }
+	rows := make([][]string, len(v.Patches))
+	for i, p := range v.Patches {
+		rows[i] = []string{
+			p.ID,
+			f(p.Measured.Color.L), f(p.Measured.Color.A), f(p.Measured.Color.B),
+			f(p.Reference.Color.L), f(p.Reference.Color.A), f(p.Reference.Color.B),
+			f(p.DeltaE),
+		}
+	}
+	return header, rows
+}
+
+func printVerifyReport(rep cgats.Report) {
+	worst := append([]cgats.PatchResult(nil), rep.Patches...)
+	sort.SliceStable(worst, func(i, j int) bool { return worst[i].DeltaE > worst[j].DeltaE })
+	if verifyWorst >= 0 && len(worst) > verifyWorst {
+		worst = worst[:verifyWorst]
+	}
+
+	fmt.Printf("%-10s %22s %22s %7s\n", "Patch", "Measured Lab", "Reference Lab", "ΔE00")
+	for _, p := range worst {
+		fmt.Printf("%-10s %6.2f %7.2f %7.2f %6.2f %7.2f %7.2f %7.2f\n",
+			p.ID, p.Measured.Color.L, p.Measured.Color.A, p.Measured.Color.B,
+			p.Reference.Color.L, p.Reference.Color.A, p.Reference.Color.B, p.DeltaE)
+	}
+	fmt.Println()
+	fmt.Printf("Patches: %d\n", len(rep.Patches))
+	fmt.Printf("Average: %.2f\n", rep.Average)
+	fmt.Printf("P95    : %.2f\n", rep.P95)
+	fmt.Printf("Max    : %.2f (%s)\n", rep.Max, rep.MaxID)
+	for _, f := range rep.Failures {
+		fmt.Println("FAIL   :", f)
+	}
+	if rep.Pass {
+		fmt.Println("Result : PASS")
+	} else {
+		fmt.Println("Result : FAIL")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().Float64Var(&verifyTolerances.Average, "avg", 3, "Maximum average ΔE00 (0 disables)")
+	verifyCmd.Flags().Float64Var(&verifyTolerances.P95, "p95", 5, "Maximum 95th-percentile ΔE00 (0 disables)")
+	verifyCmd.Flags().Float64Var(&verifyTolerances.Max, "max", 6, "Maximum single-patch ΔE00 (0 disables)")
+	verifyCmd.Flags().StringVarP(&verifyFormat, "format", "f", "text", "Output format (text, json)")
+	verifyCmd.Flags().MarkDeprecated("format", "use --output instead")
+	verifyCmd.Flags().StringVar(&verifyReport, "report", "", "Also write a CGATS report with per-patch ΔE00")
+	verifyCmd.Flags().IntVar(&verifyWorst, "worst", 10, "Number of worst patches to list (-1 for all)")
+}
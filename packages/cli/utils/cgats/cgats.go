@@ -0,0 +1,339 @@
+// Package cgats reads and writes CGATS.17 / IT8.7 measurement files.
+package cgats
+
+import (
+	"bufio"
+	"colors-cli/utils/colors"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// File
+// -------------------------------
+
+// Keyword is a header line such as ORIGINATOR "X-Rite".
+type Keyword struct {
+	Key   string
+	Value string
+}
+
+// File is a single-table CGATS file. Values are kept as written.
+type File struct {
+	Type     string // identifier on the first line, e.g. CGATS.17
+	Keywords []Keyword
+	Fields   []string
+	Rows     [][]string
+}
+
+// Get returns a keyword's value.
+func (f *File) Get(key string) (string, bool) {
+	for _, k := range f.Keywords {
+		if strings.EqualFold(k.Key, key) {
+			return k.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set adds or replaces a keyword.
+func (f *File) Set(key, value string) {
+	for i, k := range f.Keywords {
+		if strings.EqualFold(k.Key, key) {
+			f.Keywords[i].Value = value
+			return
+		}
+	}
+	f.Keywords = append(f.Keywords, Keyword{Key: key, Value: value})
+}
+
+// Column returns the index of a field, or -1.
+func (f *File) Column(name string) int {
+	for i, field := range f.Fields {
+		if strings.EqualFold(field, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HasFields reports whether every named field is present.
+func (f *File) HasFields(names ...string) bool {
+	for _, n := range names {
+		if f.Column(n) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Float reads a numeric field of a row.
+func (f *File) Float(row int, field string) (float64, error) {
+	col := f.Column(field)
+	if col < 0 {
+		return 0, fmt.Errorf("no %s field", field)
+	}
+	v, err := strconv.ParseFloat(f.Rows[row][col], 64)
+	if err != nil {
+		return 0, fmt.Errorf("row %d: invalid %s %q", row+1, field, f.Rows[row][col])
+	}
+	return v, nil
+}
+
+func (f *File) floats(row int, fields ...string) ([]float64, error) {
+	out := make([]float64, len(fields))
+	for i, field := range fields {
+		v, err := f.Float(row, field)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// SampleID returns the SAMPLE_ID (or SAMPLE_NAME) of a row, falling
+// back to its 1-based position.
+func (f *File) SampleID(row int) string {
+	for _, field := range []string{"SAMPLE_ID", "SAMPLE_NAME"} {
+		if col := f.Column(field); col >= 0 {
+			return f.Rows[row][col]
+		}
+	}
+	return strconv.Itoa(row + 1)
+}
+
+// Lab returns a row's colour as CIELAB D50, from LAB_* fields or, when
+// absent, XYZ_* fields (Y = 100 for the white).
+func (f *File) Lab(row int) (colors.Lab, error) {
+	if f.HasFields("LAB_L", "LAB_A", "LAB_B") {
+		v, err := f.floats(row, "LAB_L", "LAB_A", "LAB_B")
+		if err != nil {
+			return colors.Lab{}, err
+		}
+		return colors.Lab{L: v[0], A: v[1], B: v[2]}, nil
+	}
+	if f.HasFields("XYZ_X", "XYZ_Y", "XYZ_Z") {
+		v, err := f.floats(row, "XYZ_X", "XYZ_Y", "XYZ_Z")
+		if err != nil {
+			return colors.Lab{}, err
+		}
+		return colors.XYZ{X: v[0] / 100, Y: v[1] / 100, Z: v[2] / 100}.ToLab(colors.WhiteD50), nil
+	}
+	return colors.Lab{}, errors.New("no LAB_L/LAB_A/LAB_B or XYZ_X/XYZ_Y/XYZ_Z fields")
+}
+
+// CMYK returns a row's CMYK_* values (0–100), if present.
+func (f *File) CMYK(row int) ([]float64, bool) {
+	if !f.HasFields("CMYK_C", "CMYK_M", "CMYK_Y", "CMYK_K") {
+		return nil, false
+	}
+	v, err := f.floats(row, "CMYK_C", "CMYK_M", "CMYK_Y", "CMYK_K")
+	return v, err == nil
+}
+
+// -------------------------------
+// Reading
+// -------------------------------
+
+// Open reads a CGATS file from disk.
+func Open(path string) (*File, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	f, err := Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Parse reads the first table of a CGATS.17 stream. Comments start with
+// '#'; data values may wrap across lines.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	const (
+		header = iota
+		format
+		data
+		done
+	)
+	state := header
+	var pending []string
+	line := 0
+
+	for sc.Scan() && state != done {
+		line++
+		tokens, err := tokenize(sc.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+
+		switch state {
+		case header:
+			key := strings.ToUpper(tokens[0])
+			switch {
+			case key == "BEGIN_DATA_FORMAT":
+				state = format
+			case key == "BEGIN_DATA":
+				if len(f.Fields) == 0 {
+					return nil, fmt.Errorf("line %d: BEGIN_DATA before data format", line)
+				}
+				state = data
+			case f.Type == "" && len(f.Keywords) == 0 && len(tokens) == 1:
+				f.Type = tokens[0]
+			case key == "KEYWORD":
+				// declares a custom keyword; nothing to record
+			default:
+				f.Keywords = append(f.Keywords, Keyword{Key: tokens[0], Value: strings.Join(tokens[1:], " ")})
+			}
+
+		case format:
+			if strings.EqualFold(tokens[0], "END_DATA_FORMAT") {
+				state = header
+				continue
+			}
+			f.Fields = append(f.Fields, tokens...)
+
+		case data:
+			if strings.EqualFold(tokens[0], "END_DATA") {
+				state = done
+				continue
+			}
+			pending = append(pending, tokens...)
+			for len(pending) >= len(f.Fields) {
+				f.Rows = append(f.Rows, pending[:len(f.Fields):len(f.Fields)])
+				pending = pending[len(f.Fields):]
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(f.Fields) == 0:
+		return nil, errors.New("missing BEGIN_DATA_FORMAT section")
+	case state == data:
+		return nil, errors.New("missing END_DATA")
+	case state != done:
+		return nil, errors.New("missing BEGIN_DATA section")
+	case len(pending) != 0:
+		return nil, fmt.Errorf("incomplete last row: %d of %d values", len(pending), len(f.Fields))
+	}
+	if v, ok := f.Get("NUMBER_OF_SETS"); ok {
+		if n, err := strconv.Atoi(v); err == nil && n != len(f.Rows) {
+			return nil, fmt.Errorf("NUMBER_OF_SETS is %d but %d rows were read", n, len(f.Rows))
+		}
+	}
+	return f, nil
+}
+
+// tokenize splits a line on whitespace, keeping quoted strings whole
+// and dropping comments.
+func tokenize(s string) ([]string, error) {
+	var out []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#':
+			return out, nil
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, errors.New("unterminated string")
+			}
+			out = append(out, s[i+1:i+1+end])
+			i += end + 2
+		default:
+			j := i
+			for j < len(s) && s[j] != ' ' && s[j] != '\t' && s[j] != '\r' {
+				j++
+			}
+			out = append(out, s[i:j])
+			i = j
+		}
+	}
+	return out, nil
+}
+
+// -------------------------------
+// Writing
+// -------------------------------
+
+// Write emits the file in CGATS.17 form. NUMBER_OF_FIELDS and
+// NUMBER_OF_SETS are written from the table itself.
+func (f *File) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	typ := f.Type
+	if typ == "" {
+		typ = "CGATS.17"
+	}
+	fmt.Fprintln(bw, typ)
+
+	for _, k := range f.Keywords {
+		switch strings.ToUpper(k.Key) {
+		case "NUMBER_OF_FIELDS", "NUMBER_OF_SETS":
+			continue
+		}
+		fmt.Fprintf(bw, "%s\t%s\n", k.Key, quoteKeyword(k.Value))
+	}
+
+	fmt.Fprintf(bw, "NUMBER_OF_FIELDS\t%d\n", len(f.Fields))
+	fmt.Fprintln(bw, "BEGIN_DATA_FORMAT")
+	fmt.Fprintln(bw, strings.Join(f.Fields, "\t"))
+	fmt.Fprintln(bw, "END_DATA_FORMAT")
+
+	fmt.Fprintf(bw, "NUMBER_OF_SETS\t%d\n", len(f.Rows))
+	fmt.Fprintln(bw, "BEGIN_DATA")
+	for i, row := range f.Rows {
+		if len(row) != len(f.Fields) {
+			return fmt.Errorf("row %d has %d values, expected %d", i+1, len(row), len(f.Fields))
+		}
+		vals := make([]string, len(row))
+		for j, v := range row {
+			vals[j] = quote(v)
+		}
+		fmt.Fprintln(bw, strings.Join(vals, "\t"))
+	}
+	fmt.Fprintln(bw, "END_DATA")
+	return bw.Flush()
+}
+
+// quote wraps values that are not plain numbers or identifiers.
+func quote(v string) string {
+	if v == "" {
+		return `""`
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v
+	}
+	if strings.ContainsAny(v, " \t#\"'") {
+		return `"` + strings.ReplaceAll(v, `"`, `'`) + `"`
+	}
+	return v
+}
+
+// quoteKeyword quotes every non-numeric keyword value, as CGATS.17
+// recommends.
+func quoteKeyword(v string) string {
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v
+	}
+	return `"` + strings.ReplaceAll(v, `"`, `'`) + `"`
+}
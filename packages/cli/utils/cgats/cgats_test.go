@@ -0,0 +1,145 @@
+package cgats
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `CGATS.17
+# measured on the proofer
+ORIGINATOR	"X-Rite  i1Pro 3"
+DESCRIPTOR	"Proof #12, 'matte'"
+KEYWORD	"PRINT_CONDITION"
+PRINT_CONDITION	FOGRA51
+WEIGHTING	2
+NUMBER_OF_FIELDS	5
+BEGIN_DATA_FORMAT
+SAMPLE_ID	SAMPLE_NAME	LAB_L	LAB_A
+LAB_B
+END_DATA_FORMAT
+NUMBER_OF_SETS	3
+BEGIN_DATA
+1	"paper white"	95.12	0.45	-2.10
+2	A2	50.00	-1.5	# value wraps
+3.25
+3	"black ink"
+16.2	0.1	0.3
+END_DATA
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Type != "CGATS.17" {
+		t.Errorf("Type = %q", f.Type)
+	}
+	for key, want := range map[string]string{
+		"ORIGINATOR":      "X-Rite  i1Pro 3",
+		"descriptor":      "Proof #12, 'matte'",
+		"PRINT_CONDITION": "FOGRA51",
+		"WEIGHTING":       "2",
+		"NUMBER_OF_SETS":  "3",
+	} {
+		if got, ok := f.Get(key); !ok || got != want {
+			t.Errorf("Get(%s) = %q, %v; want %q", key, got, ok, want)
+		}
+	}
+	if _, ok := f.Get("KEYWORD"); ok {
+		t.Error("KEYWORD declarations should not be recorded as keywords")
+	}
+
+	wantFields := []string{"SAMPLE_ID", "SAMPLE_NAME", "LAB_L", "LAB_A", "LAB_B"}
+	if !reflect.DeepEqual(f.Fields, wantFields) {
+		t.Errorf("Fields = %q, want %q", f.Fields, wantFields)
+	}
+	wantRows := [][]string{
+		{"1", "paper white", "95.12", "0.45", "-2.10"},
+		{"2", "A2", "50.00", "-1.5", "3.25"},
+		{"3", "black ink", "16.2", "0.1", "0.3"},
+	}
+	if !reflect.DeepEqual(f.Rows, wantRows) {
+		t.Errorf("Rows = %q, want %q", f.Rows, wantRows)
+	}
+
+	lab, err := f.Lab(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lab.L != 50 || lab.A != -1.5 || lab.B != 3.25 {
+		t.Errorf("Lab(1) = %+v", lab)
+	}
+	if id := f.SampleID(2); id != "3" {
+		t.Errorf("SampleID(2) = %q", id)
+	}
+}
+
+// Parsing what Write produced gives the same table, and writing that
+// again gives the same bytes.
+func TestWriteRoundTrip(t *testing.T) {
+	f, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Set("NOTE", `said "hello"`)
+	f.Rows = append(f.Rows, []string{"4", "", "1", "2", "3"})
+
+	var first bytes.Buffer
+	if err := f.Write(&first); err != nil {
+		t.Fatal(err)
+	}
+	g, err := Parse(bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatalf("re-parse: %v\n%s", err, first.String())
+	}
+
+	if g.Type != f.Type || !reflect.DeepEqual(g.Fields, f.Fields) || !reflect.DeepEqual(g.Rows, f.Rows) {
+		t.Errorf("table changed in the round trip:\n%s", first.String())
+	}
+	for _, k := range f.Keywords {
+		want := k.Value
+		switch k.Key {
+		case "NUMBER_OF_SETS":
+			want = "4"
+		case "NOTE":
+			want = `said 'hello'` // double quotes cannot be escaped
+		}
+		if got, _ := g.Get(k.Key); got != want {
+			t.Errorf("%s = %q after the round trip, want %q", k.Key, got, want)
+		}
+	}
+
+	var second bytes.Buffer
+	if err := g.Write(&second); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Errorf("second write differs:\n%s\n---\n%s", first.String(), second.String())
+	}
+}
+
+func TestWriteRaggedRow(t *testing.T) {
+	f := &File{Fields: []string{"A", "B"}, Rows: [][]string{{"1", "2"}, {"3"}}}
+	if err := f.Write(&bytes.Buffer{}); err == nil {
+		t.Error("expected an error for a short row")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"sets mismatch": "CGATS.17\nNUMBER_OF_SETS 3\nBEGIN_DATA_FORMAT\nA B\nEND_DATA_FORMAT\nBEGIN_DATA\n1 2\n3 4\nEND_DATA\n",
+		"sets too few":  "CGATS.17\nBEGIN_DATA_FORMAT\nA\nEND_DATA_FORMAT\nNUMBER_OF_SETS 1\nBEGIN_DATA\n1\n2\nEND_DATA\n",
+		"partial row":   "CGATS.17\nBEGIN_DATA_FORMAT\nA B\nEND_DATA_FORMAT\nBEGIN_DATA\n1 2\n3\nEND_DATA\n",
+		"no END_DATA":   "CGATS.17\nBEGIN_DATA_FORMAT\nA B\nEND_DATA_FORMAT\nBEGIN_DATA\n1 2\n",
+		"no format":     "CGATS.17\nBEGIN_DATA\n1 2\nEND_DATA\n",
+		"no data":       "CGATS.17\nBEGIN_DATA_FORMAT\nA B\nEND_DATA_FORMAT\n",
+		"open quote":    "CGATS.17\nORIGINATOR \"X-Rite\nBEGIN_DATA_FORMAT\nA\nEND_DATA_FORMAT\nBEGIN_DATA\n1\nEND_DATA\n",
+	} {
+		if _, err := Parse(strings.NewReader(src)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package cgats
+
+import (
+	"colors-cli/utils/colors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// -------------------------------
+// Verification
+// -------------------------------
+
+// Tolerances are CIEDE2000 limits; zero disables a check.
+type Tolerances struct {
+	Average float64 `json:"average" yaml:"average"`
+	P95     float64 `json:"p95" yaml:"p95"`
+	Max     float64 `json:"max" yaml:"max"`
+}
+
+// PatchResult compares one measured patch with its reference.
+type PatchResult struct {
+	ID        string                    `json:"id" yaml:"id"`
+	Measured  colors.Object[colors.Lab] `json:"measured" yaml:"measured"`
+	Reference colors.Object[colors.Lab] `json:"reference" yaml:"reference"`
+	CMYK      []float64                 `json:"cmyk,omitempty" yaml:"cmyk,omitempty"`
+	DeltaE    float64                   `json:"deltaE00" yaml:"deltaE00"`
+}
+
+// Report summarises a verification run.
+type Report struct {
+	Patches    []PatchResult `json:"patches" yaml:"patches"`
+	Average    float64       `json:"average" yaml:"average"`
+	P95        float64       `json:"p95" yaml:"p95"`
+	Max        float64       `json:"max" yaml:"max"`
+	MaxID      string        `json:"maxId" yaml:"maxId"`
+	Tolerances Tolerances    `json:"tolerances" yaml:"tolerances"`
+	Failures   []string      `json:"failures" yaml:"failures"`
+	Pass       bool          `json:"pass" yaml:"pass"`
+}
+
+// Verify compares measured against reference patch by patch. Patches
+// are matched by SAMPLE_ID when both files have one, by row otherwise.
+func Verify(measured, reference *File, tol Tolerances) (Report, error) {
+	pairs, err := matchRows(measured, reference)
+	if err != nil {
+		return Report{}, err
+	}
+
+	rep := Report{Tolerances: tol, Failures: []string{}}
+	deltas := make([]float64, 0, len(pairs))
+	for _, p := range pairs {
+		m, err := measured.Lab(p[0])
+		if err != nil {
+			return Report{}, fmt.Errorf("measured: %w", err)
+		}
+		r, err := reference.Lab(p[1])
+		if err != nil {
+			return Report{}, fmt.Errorf("reference: %w", err)
+		}
+		res := PatchResult{
+			ID:        measured.SampleID(p[0]),
+			Measured:  colors.Object[colors.Lab]{Color: m},
+			Reference: colors.Object[colors.Lab]{Color: r},
+			DeltaE:    colors.DeltaE2000(m, r),
+		}
+		if cmyk, ok := measured.CMYK(p[0]); ok {
+			res.CMYK = cmyk
+		} else if cmyk, ok := reference.CMYK(p[1]); ok {
+			res.CMYK = cmyk
+		}
+		rep.Patches = append(rep.Patches, res)
+		deltas = append(deltas, res.DeltaE)
+
+		rep.Average += res.DeltaE
+		if res.DeltaE > rep.Max {
+			rep.Max, rep.MaxID = res.DeltaE, res.ID
+		}
+	}
+	rep.Average /= float64(len(deltas))
+	rep.P95 = Percentile(deltas, 95)
+
+	check := func(name string, got, limit float64) {
+		if limit > 0 && got > limit {
+			rep.Failures = append(rep.Failures, fmt.Sprintf("%s ΔE00 %.2f exceeds %.2f", name, got, limit))
+		}
+	}
+	check("average", rep.Average, tol.Average)
+	check("95th percentile", rep.P95, tol.P95)
+	check("max", rep.Max, tol.Max)
+	rep.Pass = len(rep.Failures) == 0
+	return rep, nil
+}
+
+// Percentile returns the p-th percentile (nearest rank).
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	s := append([]float64(nil), values...)
+	sort.Float64s(s)
+	rank := int(math.Ceil(p / 100 * float64(len(s))))
+	if rank < 1 {
+		rank = 1
+	}
+	return s[rank-1]
+}
+
+// matchRows pairs measured and reference row indices.
+func matchRows(measured, reference *File) ([][2]int, error) {
+	if len(measured.Rows) == 0 {
+		return nil, fmt.Errorf("measured file has no patches")
+	}
+	byID := measured.Column("SAMPLE_ID") >= 0 && reference.Column("SAMPLE_ID") >= 0
+	if !byID {
+		if len(measured.Rows) != len(reference.Rows) {
+			return nil, fmt.Errorf("patch counts differ (%d measured, %d reference) and there is no SAMPLE_ID to match on", len(measured.Rows), len(reference.Rows))
+		}
+		pairs := make([][2]int, len(measured.Rows))
+		for i := range pairs {
+			pairs[i] = [2]int{i, i}
+		}
+		return pairs, nil
+	}
+
+	index := map[string]int{}
+	for i := range reference.Rows {
+		index[reference.SampleID(i)] = i
+	}
+	pairs := make([][2]int, 0, len(measured.Rows))
+	for i := range measured.Rows {
+		id := measured.SampleID(i)
+		j, ok := index[id]
+		if !ok {
+			return nil, fmt.Errorf("patch %s is missing from the reference", id)
+		}
+		pairs = append(pairs, [2]int{i, j})
+	}
+	return pairs, nil
+}
+
+// ReportFile renders a report as a CGATS table with per-patch ΔE00.
+func (r Report) ReportFile() *File {
+	f := &File{
+		Type:   "CGATS.17",
+		Fields: []string{"SAMPLE_ID", "LAB_L", "LAB_A", "LAB_B", "REF_L", "REF_A", "REF_B", "DE2000"},
+	}
+	f.Set("ORIGINATOR", "colors-cli verify")
+	f.Set("DESCRIPTOR", "Verification report")
+	num := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, p := range r.Patches {
+		f.Rows = append(f.Rows, []string{
+			p.ID,
+			num(p.Measured.Color.L), num(p.Measured.Color.A), num(p.Measured.Color.B),
+			num(p.Reference.Color.L), num(p.Reference.Color.A), num(p.Reference.Color.B),
+			num(p.DeltaE),
+		})
+	}
+	return f
+}
@@ -0,0 +1,117 @@
+package cgats
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	values := make([]float64, 20)
+	for i := range values {
+		values[i] = float64(20 - i) // 20 … 1, unsorted
+	}
+	for _, tc := range []struct {
+		p, want float64
+	}{{0, 1}, {5, 1}, {50, 10}, {90, 18}, {95, 19}, {96, 20}, {100, 20}} {
+		if got := Percentile(values, tc.p); got != tc.want {
+			t.Errorf("Percentile(1…20, %v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+	if values[0] != 20 {
+		t.Error("Percentile sorted its input")
+	}
+	if got := Percentile([]float64{3.5}, 95); got != 3.5 {
+		t.Errorf("single value: %v", got)
+	}
+	if got := Percentile(nil, 95); got != 0 {
+		t.Errorf("empty: %v", got)
+	}
+}
+
+func mustParse(t *testing.T, src string) *File {
+	t.Helper()
+	f, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestVerify(t *testing.T) {
+	reference := mustParse(t, `CGATS.17
+BEGIN_DATA_FORMAT
+SAMPLE_ID LAB_L LAB_A LAB_B
+END_DATA_FORMAT
+BEGIN_DATA
+A1 50 0 0
+A2 60 10 10
+A3 70 -20 30
+END_DATA
+`)
+	// rows in another order, matched on SAMPLE_ID
+	measured := mustParse(t, `CGATS.17
+BEGIN_DATA_FORMAT
+SAMPLE_ID LAB_L LAB_A LAB_B CMYK_C CMYK_M CMYK_Y CMYK_K
+END_DATA_FORMAT
+BEGIN_DATA
+A3 70 -20 30 10 0 80 0
+A1 51 0 0 0 0 0 50
+A2 60 10 10 0 40 40 0
+END_DATA
+`)
+
+	rep, err := Verify(measured, reference, Tolerances{Average: 1, Max: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Patches) != 3 || rep.Patches[1].ID != "A1" {
+		t.Fatalf("patches = %+v", rep.Patches)
+	}
+	if rep.Patches[0].DeltaE != 0 || rep.Patches[2].DeltaE != 0 {
+		t.Errorf("identical patches gave ΔE %v and %v", rep.Patches[0].DeltaE, rep.Patches[2].DeltaE)
+	}
+	if rep.Patches[0].CMYK[2] != 80 {
+		t.Errorf("CMYK = %v", rep.Patches[0].CMYK)
+	}
+
+	dE := rep.Patches[1].DeltaE
+	if dE <= 0 || rep.MaxID != "A1" || rep.Max != dE || rep.P95 != dE {
+		t.Errorf("max %v (%s), p95 %v; want %v (A1)", rep.Max, rep.MaxID, rep.P95, dE)
+	}
+	if math.Abs(rep.Average-dE/3) > 1e-12 {
+		t.Errorf("average = %v, want %v", rep.Average, dE/3)
+	}
+	if rep.Pass || len(rep.Failures) != 1 || !strings.HasPrefix(rep.Failures[0], "max") {
+		t.Errorf("pass %v, failures %q; want only the max check to fail", rep.Pass, rep.Failures)
+	}
+
+	report := rep.ReportFile()
+	if len(report.Rows) != 3 || report.Rows[1][0] != "A1" {
+		t.Errorf("report rows = %q", report.Rows)
+	}
+}
+
+func TestVerifyMatching(t *testing.T) {
+	withID := mustParse(t, "CGATS.17\nBEGIN_DATA_FORMAT\nSAMPLE_ID LAB_L LAB_A LAB_B\nEND_DATA_FORMAT\nBEGIN_DATA\nA1 50 0 0\nEND_DATA\n")
+	otherID := mustParse(t, "CGATS.17\nBEGIN_DATA_FORMAT\nSAMPLE_ID LAB_L LAB_A LAB_B\nEND_DATA_FORMAT\nBEGIN_DATA\nB1 50 0 0\nEND_DATA\n")
+	if _, err := Verify(withID, otherID, Tolerances{}); err == nil {
+		t.Error("expected an error for a patch missing from the reference")
+	}
+
+	// without SAMPLE_ID rows pair up by position, XYZ standing in for Lab
+	xyz := mustParse(t, "CGATS.17\nBEGIN_DATA_FORMAT\nXYZ_X XYZ_Y XYZ_Z\nEND_DATA_FORMAT\nBEGIN_DATA\n96.42 100 82.49\n0 0 0\nEND_DATA\n")
+	lab := mustParse(t, "CGATS.17\nBEGIN_DATA_FORMAT\nLAB_L LAB_A LAB_B\nEND_DATA_FORMAT\nBEGIN_DATA\n100 0 0\n0 0 0\nEND_DATA\n")
+	rep, err := Verify(xyz, lab, Tolerances{Max: 0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rep.Pass {
+		t.Errorf("D50 white and black should match: %+v", rep)
+	}
+
+	short := mustParse(t, "CGATS.17\nBEGIN_DATA_FORMAT\nLAB_L LAB_A LAB_B\nEND_DATA_FORMAT\nBEGIN_DATA\n100 0 0\nEND_DATA\n")
+	if _, err := Verify(short, lab, Tolerances{}); err == nil {
+		t.Error("expected an error for differing patch counts")
+	}
+}
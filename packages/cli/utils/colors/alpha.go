@@ -0,0 +1,69 @@
+package colors
+
+import "math"
+
+// -------------------------------
+// Alpha compositing
+// -------------------------------
+
+// Composite paints fg over bg (source-over in gamma-encoded sRGB, as
+// browsers blend) and returns the sRGB result.
+func Composite(fg, bg Color) Color {
+	f, b := fg.To(SpaceSRGB).Clip(), bg.To(SpaceSRGB).Clip()
+	a := clamp01(f.Alpha)
+	ab := clamp01(b.Alpha)
+	out := a + ab*(1-a)
+	c := Color{Space: SpaceSRGB, Alpha: out}
+	if out == 0 {
+		return c
+	}
+	for i := 0; i < 3; i++ {
+		c.V[i] = (f.V[i]*a + b.V[i]*ab*(1-a)) / out
+	}
+	return c
+}
+
+// SolveAlpha finds the most transparent sRGB colour that, composited
+// over an opaque background, gives target. Alpha and channels are
+// quantised to 8 bits and the result reproduces target's 8-bit value
+// exactly where any 8-bit colour can.
+func SolveAlpha(target, background Color) Color {
+	t, b := target.MapToSRGB(), background.To(SpaceSRGB).Clip()
+	b.Alpha = 1
+
+	// per channel, the least alpha keeping the foreground within 0–1
+	need := 0.0
+	for i := 0; i < 3; i++ {
+		switch d := t.V[i] - b.V[i]; {
+		case d > 0:
+			need = math.Max(need, d/(1-b.V[i]))
+		case d < 0:
+			need = math.Max(need, -d/b.V[i])
+		}
+	}
+
+	want := t.RGB()
+	best, bestErr := Color{}, math.Inf(1)
+	for a8 := int(math.Floor(need * 255)); a8 <= 255; a8++ {
+		if a8 == 0 {
+			if want == b.RGB() {
+				return Color{Space: SpaceSRGB, V: b.V}
+			}
+			continue
+		}
+		a := float64(a8) / 255
+		fg := Color{Space: SpaceSRGB, Alpha: a}
+		for i := 0; i < 3; i++ {
+			fg.V[i] = float64(roundToCode(b.V[i]+(t.V[i]-b.V[i])/a)) / 255
+		}
+		got := Composite(fg, b).RGB()
+		e := math.Abs(float64(got.R-want.R)) + math.Abs(float64(got.G-want.G)) + math.Abs(float64(got.B-want.B))
+		if e == 0 {
+			return fg
+		}
+		if e < bestErr {
+			best, bestErr = fg, e
+		}
+	}
+	return best
+}
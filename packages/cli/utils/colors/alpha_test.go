@@ -0,0 +1,87 @@
+package colors
+
+import "testing"
+
+// compositeHex paints the alpha colour, as written in hex, over bg.
+func compositeHex(t *testing.T, fg, bg Color) string {
+	t.Helper()
+	c, err := Parse(fg.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Composite(c, bg).Hex()
+}
+
+func TestSolveAlpha(t *testing.T) {
+	backgrounds := []Color{RadixLightBackground, RadixDarkBackground, MustParse("#808080"), MustParse("#000000")}
+	targets := []string{
+		"#FFFFFF", "#000000", "#111111", "#FF0000", "#00FF00", "#0000FF",
+		"#FF00FF", "#00FFFF", "#FFFF00", "#FEFEFE", "#010101", "#3B82F6",
+		"#E5484D", "#F0F0F0", "#121212", "#7F7F80", "#0090FF",
+	}
+	for _, bg := range backgrounds {
+		for _, hex := range targets {
+			target := MustParse(hex)
+			a := SolveAlpha(target, bg)
+			if a.Alpha < 0 || a.Alpha > 1 {
+				t.Fatalf("%s over %s: alpha %v", hex, bg.Hex(), a.Alpha)
+			}
+			if got := compositeHex(t, a, bg); got != target.Hex() {
+				t.Errorf("%s over %s: %s composites to %s", hex, bg.Hex(), a.Hex(), got)
+			}
+		}
+	}
+}
+
+func TestSolveAlphaEdges(t *testing.T) {
+	// the background itself needs no paint at all
+	for _, bg := range []Color{RadixLightBackground, RadixDarkBackground} {
+		a := SolveAlpha(bg, bg)
+		if a.Alpha != 0 {
+			t.Errorf("%s over itself: alpha %v, want 0", bg.Hex(), a.Alpha)
+		}
+		if got := compositeHex(t, a, bg); got != bg.Hex() {
+			t.Errorf("%s over itself composites to %s", bg.Hex(), got)
+		}
+	}
+
+	// black over white is opaque black; over #111 black is the only way
+	// down, so alpha is 1 there too
+	for _, bg := range []Color{RadixLightBackground, RadixDarkBackground} {
+		a := SolveAlpha(MustParse("#000000"), bg)
+		if a.Hex() != "#000000" {
+			t.Errorf("black over %s = %s, want opaque black", bg.Hex(), a.Hex())
+		}
+	}
+
+	// the most transparent way to get a gray over white is black at
+	// partial alpha
+	a := SolveAlpha(MustParse("#808080"), RadixLightBackground)
+	if rgb := a.RGB(); rgb != (RGB{}) || a.Alpha >= 1 {
+		t.Errorf("gray over white = %s, want translucent black", a.Hex())
+	}
+}
+
+func TestRadixScaleAlphaComposites(t *testing.T) {
+	for _, in := range []string{"#0090FF", "#E5484D", "#30A46C", "#FFE629", "#8E8C99", "#000000", "#FFFFFF"} {
+		base := MustParse(in)
+		for _, mode := range []struct {
+			dark bool
+			bg   Color
+		}{{false, RadixLightBackground}, {true, RadixDarkBackground}} {
+			steps := RadixScale(base, mode.dark, mode.bg)
+			if len(steps) != 12 {
+				t.Fatalf("%s: %d steps", in, len(steps))
+			}
+			for _, s := range steps {
+				if !s.Color.To(SpaceSRGB).InGamut() {
+					t.Errorf("%s dark=%v step %d: %v outside sRGB", in, mode.dark, s.Step, s.Color)
+				}
+				if got := compositeHex(t, s.Alpha, mode.bg); got != s.Color.Hex() {
+					t.Errorf("%s dark=%v step %d: %s over %s gives %s, want %s",
+						in, mode.dark, s.Step, s.Alpha.Hex(), mode.bg.Hex(), got, s.Color.Hex())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// -------------------------------
+// ColorChecker Classic (24 patches)
+// -------------------------------
+
+// CheckerPatch is a named reference patch, CIELAB D50.
+type CheckerPatch struct {
+	Name string
+	Lab  Lab
+}
+
+// ColorChecker24 holds X-Rite's published reference values for the
+// classic 24-patch chart (D50, 2°), row by row from dark skin to black.
+var ColorChecker24 = [24]CheckerPatch{
+	{"Dark Skin", Lab{37.986, 13.555, 14.059}},
+	{"Light Skin", Lab{65.711, 18.130, 17.810}},
+	{"Blue Sky", Lab{49.927, -4.880, -21.925}},
+	{"Foliage", Lab{43.139, -13.095, 21.905}},
+	{"Blue Flower", Lab{55.112, 8.844, -25.399}},
+	{"Bluish Green", Lab{70.719, -33.397, -0.199}},
+	{"Orange", Lab{62.661, 36.067, 57.096}},
+	{"Purplish Blue", Lab{40.020, 10.410, -45.964}},
+	{"Moderate Red", Lab{51.124, 48.239, 16.248}},
+	{"Purple", Lab{30.325, 22.976, -21.587}},
+	{"Yellow Green", Lab{72.532, -23.709, 57.255}},
+	{"Orange Yellow", Lab{71.941, 19.363, 67.857}},
+	{"Blue", Lab{28.778, 14.179, -50.297}},
+	{"Green", Lab{55.261, -38.342, 31.370}},
+	{"Red", Lab{42.101, 53.378, 28.190}},
+	{"Yellow", Lab{81.733, 4.039, 79.819}},
+	{"Magenta", Lab{51.935, 49.986, -14.574}},
+	{"Cyan", Lab{51.038, -28.631, -28.638}},
+	{"White 9.5", Lab{96.539, -0.425, 1.186}},
+	{"Neutral 8", Lab{81.257, -0.638, -0.335}},
+	{"Neutral 6.5", Lab{66.766, -0.734, -0.504}},
+	{"Neutral 5", Lab{50.867, -0.153, -0.270}},
+	{"Neutral 3.5", Lab{35.656, -0.421, -1.231}},
+	{"Black 2", Lab{20.461, -0.079, -0.973}},
+}
+
+// -------------------------------
+// Linear sRGB ↔ Lab
+// -------------------------------
+
+// LinearRGBToLab converts linear sRGB (D65) to CIELAB relative to white,
+// adapting with Bradford when white is not D65.
+func LinearRGBToLab(lin [3]float64, white XYZ) Lab {
+	v := srgbToXYZ.mulVec(lin)
+	return XYZ{X: v[0], Y: v[1], Z: v[2]}.Adapt(WhiteD65, white).ToLab(white)
+}
+
+// LabToLinearRGB is the inverse of LinearRGBToLab (unclipped).
+func LabToLinearRGB(c Lab, white XYZ) [3]float64 {
+	xyz := c.ToXYZ(white).Adapt(white, WhiteD65)
+	R, G, B := xyzToLinearRGB(xyz.X, xyz.Y, xyz.Z)
+	return [3]float64{R, G, B}
+}
+
+var srgbToXYZ = mat3{
+	{0.4124564, 0.3575761, 0.1804375},
+	{0.2126729, 0.7151522, 0.0721750},
+	{0.0193339, 0.1191920, 0.9503041},
+}
+
+// -------------------------------
+// Colour correction matrix
+// -------------------------------
+
+// FitColorMatrix returns the 3×3 matrix M minimising Σ|M·src − dst|²
+// (ordinary least squares per output channel).
+func FitColorMatrix(src, dst [][3]float64) ([3][3]float64, error) {
+	if len(src) != len(dst) {
+		return [3][3]float64{}, fmt.Errorf("sample counts differ: %d and %d", len(src), len(dst))
+	}
+	if len(src) < 3 {
+		return [3][3]float64{}, errors.New("at least 3 samples are needed")
+	}
+
+	// normal equations: (SᵀS) mᵢ = Sᵀ dᵢ for each output row i
+	var sts mat3
+	var std [3][3]float64
+	for n := range src {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				sts[j][k] += src[n][j] * src[n][k]
+				std[j][k] += src[n][j] * dst[n][k]
+			}
+		}
+	}
+
+	var M [3][3]float64
+	for i := 0; i < 3; i++ {
+		row, ok := solve3(sts, [3]float64{std[0][i], std[1][i], std[2][i]})
+		if !ok {
+			return [3][3]float64{}, errors.New("samples are degenerate")
+		}
+		M[i] = row
+	}
+	return M, nil
+}
+
+// ApplyMatrix multiplies a vector by a 3×3 matrix.
+func ApplyMatrix(m [3][3]float64, v [3]float64) [3]float64 {
+	return mat3(m).mulVec(v)
+}
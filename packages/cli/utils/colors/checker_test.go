@@ -0,0 +1,81 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// The published 7-digit sRGB matrices are not exact inverses, which
+// leaves about 1e-5 ΔE in the round trip.
+func TestCheckerLabRoundTrip(t *testing.T) {
+	for _, p := range ColorChecker24 {
+		got := LinearRGBToLab(LabToLinearRGB(p.Lab, WhiteD50), WhiteD50)
+		if DeltaE76(got, p.Lab) > 1e-4 {
+			t.Errorf("%s: %+v → %+v", p.Name, p.Lab, got)
+		}
+	}
+}
+
+// A matrix applied to the chart's linear RGB is recovered exactly.
+func TestFitColorMatrixRecoversKnownMatrix(t *testing.T) {
+	known := [3][3]float64{
+		{1.62, -0.45, -0.17},
+		{-0.21, 1.38, -0.17},
+		{0.03, -0.52, 1.49},
+	}
+	src := make([][3]float64, len(ColorChecker24))
+	dst := make([][3]float64, len(ColorChecker24))
+	for i, p := range ColorChecker24 {
+		src[i] = LabToLinearRGB(p.Lab, WhiteD50)
+		dst[i] = ApplyMatrix(known, src[i])
+	}
+
+	got, err := FitColorMatrix(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range known {
+		for j := range known[i] {
+			if math.Abs(got[i][j]-known[i][j]) > 1e-9 {
+				t.Fatalf("recovered %v, want %v", got, known)
+			}
+		}
+	}
+
+	// Residuals that cancel over the samples leave the fit unchanged.
+	for i := range dst {
+		s := 0.01
+		if i%2 == 1 {
+			s = -s
+		}
+		// pairs of samples with equal src and opposite errors
+		dst[i] = ApplyMatrix(known, src[i/2*2])
+		dst[i][0] += s
+		src[i] = src[i/2*2]
+	}
+	got, err = FitColorMatrix(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range known {
+		for j := range known[i] {
+			if math.Abs(got[i][j]-known[i][j]) > 1e-9 {
+				t.Fatalf("noisy fit %v, want %v", got, known)
+			}
+		}
+	}
+}
+
+func TestFitColorMatrixErrors(t *testing.T) {
+	three := [][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	if _, err := FitColorMatrix(three, three[:2]); err == nil {
+		t.Error("expected an error for differing sample counts")
+	}
+	if _, err := FitColorMatrix(three[:2], three[:2]); err == nil {
+		t.Error("expected an error for fewer than 3 samples")
+	}
+	grays := [][3]float64{{0.1, 0.1, 0.1}, {0.5, 0.5, 0.5}, {0.9, 0.9, 0.9}}
+	if _, err := FitColorMatrix(grays, grays); err == nil {
+		t.Error("expected an error for degenerate (collinear) samples")
+	}
+}
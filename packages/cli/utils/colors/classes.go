@@ -0,0 +1,206 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// -------------------------------
+// Class breaks
+// -------------------------------
+
+// ClassMethod is a way of splitting data into classes.
+type ClassMethod int
+
+const (
+	ClassEqual    ClassMethod = iota // equal-width intervals
+	ClassQuantile                    // the same number of values per class
+	ClassKMeans                      // 1-D k-means clusters
+	ClassJenks                       // Jenks (Fisher) natural breaks
+)
+
+var classMethodNames = []string{"equal", "quantile", "kmeans", "jenks"}
+
+func (m ClassMethod) String() string {
+	if m < ClassEqual || m > ClassJenks {
+		return "unknown"
+	}
+	return classMethodNames[m]
+}
+
+// ParseClassMethod accepts equal, quantile, kmeans and jenks (and the
+// aliases equal-interval, k-means and natural).
+func ParseClassMethod(s string) (ClassMethod, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "equal-interval", "interval":
+		return ClassEqual, nil
+	case "k-means":
+		return ClassKMeans, nil
+	case "natural", "natural-breaks":
+		return ClassJenks, nil
+	}
+	for i, name := range classMethodNames {
+		if s == name {
+			return ClassMethod(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown class method %q (equal, quantile, kmeans, jenks)", s)
+}
+
+// ClassBreaks splits values into n classes and returns the n+1
+// boundaries, from the smallest value to the largest. NaNs are ignored.
+// Jenks is exact and takes O(n·len(values)²) time.
+func ClassBreaks(values []float64, n int, method ClassMethod) ([]float64, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("classes must be at least 1, got %d", n)
+	}
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("no values to classify")
+	}
+	sort.Float64s(sorted)
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+
+	breaks := make([]float64, n+1)
+	breaks[0], breaks[n] = lo, hi
+	switch method {
+	case ClassEqual:
+		for i := 1; i < n; i++ {
+			breaks[i] = lo + (hi-lo)*float64(i)/float64(n)
+		}
+	case ClassQuantile:
+		for i := 1; i < n; i++ {
+			breaks[i] = quantile(sorted, float64(i)/float64(n))
+		}
+	case ClassKMeans:
+		copy(breaks[1:n], kmeansBreaks(sorted, n))
+	case ClassJenks:
+		copy(breaks[1:n], jenksBreaks(sorted, n))
+	default:
+		return nil, fmt.Errorf("unknown class method %d", method)
+	}
+	return breaks, nil
+}
+
+// quantile interpolates linearly between the sorted values.
+func quantile(sorted []float64, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
+
+// kmeansBreaks clusters the sorted values into k groups with Lloyd's
+// algorithm, seeded at the quantiles, and returns the lowest value of
+// every cluster after the first.
+func kmeansBreaks(sorted []float64, k int) []float64 {
+	centers := make([]float64, k)
+	for i := range centers {
+		centers[i] = quantile(sorted, (float64(i)+0.5)/float64(k))
+	}
+	// on sorted data each cluster is a run; starts[i] is where run i begins
+	starts := make([]int, k)
+	for iter := 0; iter < 100; iter++ {
+		changed := false
+		c := 0
+		for j, v := range sorted {
+			for c < k-1 && math.Abs(v-centers[c+1]) < math.Abs(v-centers[c]) {
+				c++
+				if starts[c] != j {
+					starts[c], changed = j, true
+				}
+			}
+		}
+		for c++; c < k; c++ {
+			if starts[c] != len(sorted) {
+				starts[c], changed = len(sorted), true
+			}
+		}
+		for i := range centers {
+			end := len(sorted)
+			if i < k-1 {
+				end = starts[i+1]
+			}
+			if end > starts[i] {
+				sum := 0.0
+				for _, v := range sorted[starts[i]:end] {
+					sum += v
+				}
+				centers[i] = sum / float64(end-starts[i])
+			}
+		}
+		if !changed && iter > 0 {
+			break
+		}
+	}
+	out := make([]float64, k-1)
+	for i := range out {
+		out[i] = sorted[min(starts[i+1], len(sorted)-1)]
+	}
+	return out
+}
+
+// jenksBreaks finds the k classes of the sorted values with the least
+// total within-class squared deviation (Fisher's dynamic programme) and
+// returns the lowest value of every class after the first.
+func jenksBreaks(sorted []float64, k int) []float64 {
+	n := len(sorted)
+	if k >= n {
+		out := make([]float64, k-1)
+		for i := range out {
+			out[i] = sorted[min(i+1, n-1)]
+		}
+		return out
+	}
+	// prefix sums give the squared deviation of any run in O(1)
+	sum := make([]float64, n+1)
+	sumSq := make([]float64, n+1)
+	for i, v := range sorted {
+		sum[i+1] = sum[i] + v
+		sumSq[i+1] = sumSq[i] + v*v
+	}
+	ssd := func(i, j int) float64 { // values i..j-1
+		s, m := sum[j]-sum[i], float64(j-i)
+		return sumSq[j] - sumSq[i] - s*s/m
+	}
+
+	// cost[c][j]: best cost of the first j values in c+1 classes;
+	// start[c][j]: where the last of those classes begins
+	cost := make([][]float64, k)
+	start := make([][]int, k)
+	for c := range cost {
+		cost[c] = make([]float64, n+1)
+		start[c] = make([]int, n+1)
+	}
+	for j := 1; j <= n; j++ {
+		cost[0][j] = ssd(0, j)
+	}
+	for c := 1; c < k; c++ {
+		for j := c + 1; j <= n; j++ {
+			cost[c][j] = math.Inf(1)
+			for i := c; i < j; i++ {
+				if v := cost[c-1][i] + ssd(i, j); v < cost[c][j] {
+					cost[c][j], start[c][j] = v, i
+				}
+			}
+		}
+	}
+
+	out := make([]float64, k-1)
+	j := n
+	for c := k - 1; c > 0; c-- {
+		j = start[c][j]
+		out[c-1] = sorted[j]
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package colors
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestClassBreaksEqualAndQuantile(t *testing.T) {
+	tests := []struct {
+		values []float64
+		n      int
+		method ClassMethod
+		want   []float64
+	}{
+		{[]float64{10, 0, 3, 7}, 5, ClassEqual, []float64{0, 2, 4, 6, 8, 10}},
+		{[]float64{-1, 1}, 1, ClassEqual, []float64{-1, 1}},
+		{[]float64{9, 1, 8, 2, 7, 3, 6, 4, 5}, 4, ClassQuantile, []float64{1, 3, 5, 7, 9}},
+		// quantiles interpolate between values
+		{[]float64{0, 10, 20, 30}, 2, ClassQuantile, []float64{0, 15, 30}},
+		// NaNs are ignored
+		{[]float64{math.NaN(), 4, 0, math.NaN()}, 2, ClassEqual, []float64{0, 2, 4}},
+	}
+	for _, tt := range tests {
+		got, err := ClassBreaks(tt.values, tt.n, tt.method)
+		if err != nil {
+			t.Errorf("%s %v: %v", tt.method, tt.values, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s %v into %d = %v, want %v", tt.method, tt.values, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestClassBreaksErrors(t *testing.T) {
+	if _, err := ClassBreaks([]float64{1, 2}, 0, ClassEqual); err == nil {
+		t.Error("0 classes: want an error")
+	}
+	if _, err := ClassBreaks([]float64{math.NaN()}, 2, ClassEqual); err == nil {
+		t.Error("no values: want an error")
+	}
+	if _, err := ClassBreaks([]float64{1, 2}, 2, ClassMethod(9)); err == nil {
+		t.Error("unknown method: want an error")
+	}
+}
+
+// clustered returns three well-separated clusters, shuffled.
+func clustered() []float64 {
+	return []float64{
+		101, 2, 53, 1, 100, 50, 3, 102, 51, 0, 52, 4, 99, 49, 103,
+	}
+}
+
+func TestClassBreaksClusters(t *testing.T) {
+	for _, method := range []ClassMethod{ClassKMeans, ClassJenks} {
+		got, err := ClassBreaks(clustered(), 3, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// each break is the lowest value of its cluster
+		if want := []float64{0, 49, 99, 103}; !slices.Equal(got, want) {
+			t.Errorf("%s = %v, want %v", method, got, want)
+		}
+	}
+}
+
+// classCost is the total within-class squared deviation of sorted
+// values split at the given inner breaks (each the first value of a
+// class).
+func classCost(sorted, inner []float64) float64 {
+	total, start := 0.0, 0
+	for c := 0; c <= len(inner); c++ {
+		end := len(sorted)
+		if c < len(inner) {
+			end = slices.Index(sorted, inner[c])
+		}
+		run := sorted[start:end]
+		mean := 0.0
+		for _, v := range run {
+			mean += v / float64(len(run))
+		}
+		for _, v := range run {
+			total += (v - mean) * (v - mean)
+		}
+		start = end
+	}
+	return total
+}
+
+// TestClassBreaksJenksOptimal checks Jenks against an exhaustive search
+// over every way to split the data into contiguous classes, which is
+// the definition of the optimum Fisher's algorithm finds.
+func TestClassBreaksJenksOptimal(t *testing.T) {
+	// distinct values, so that each inner break names one split
+	data := []float64{0.2, 1.1, 1.9, 3.5, 4, 4.4, 7.2, 7.9, 8.1, 12, 12.6, 15, 19.5, 20, 23.3, 30}
+	for k := 2; k <= 5; k++ {
+		got, err := ClassBreaks(data, k, ClassJenks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		best := math.Inf(1)
+		var bestInner []float64
+		var search func(from int, inner []float64)
+		search = func(from int, inner []float64) {
+			if len(inner) == k-1 {
+				if c := classCost(data, inner); c < best {
+					best, bestInner = c, slices.Clone(inner)
+				}
+				return
+			}
+			for i := from; i < len(data); i++ {
+				search(i+1, append(inner, data[i]))
+			}
+		}
+		search(1, nil)
+
+		if c := classCost(data, got[1:k]); math.Abs(c-best) > 1e-9 {
+			t.Errorf("k=%d: breaks %v cost %.6f, exhaustive %v costs %.6f", k, got, c, bestInner, best)
+		}
+		if got[0] != data[0] || got[k] != data[len(data)-1] {
+			t.Errorf("k=%d: breaks %v do not span the data", k, got)
+		}
+	}
+}
+
+// More classes than values gives one class per value.
+func TestClassBreaksJenksFewValues(t *testing.T) {
+	got, err := ClassBreaks([]float64{3, 1, 2}, 3, ClassJenks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{1, 2, 3, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseClassMethod(t *testing.T) {
+	for s, want := range map[string]ClassMethod{
+		"equal": ClassEqual, "interval": ClassEqual, "Quantile": ClassQuantile,
+		"k-means": ClassKMeans, "kmeans": ClassKMeans, " natural ": ClassJenks, "jenks": ClassJenks,
+	} {
+		if got, err := ParseClassMethod(s); err != nil || got != want {
+			t.Errorf("%q = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseClassMethod("random"); err == nil {
+		t.Error("random: want an error")
+	}
+}
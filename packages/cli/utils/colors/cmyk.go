@@ -1,8 +1,10 @@
 package colors
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"strings"
 )
 
 // -------------------------------
@@ -107,3 +109,177 @@ func (c CMYK) ToOKLCH() (L, C, H float64, err error) {
 	}
 	return L, C, H, nil
 }
+
+// -------------------------------
+// Black generation
+// -------------------------------
+type BlackGeneration int
+
+const (
+	BlackNone BlackGeneration = iota
+	BlackLight
+	BlackMedium
+	BlackHeavy
+	BlackMaximum
+)
+
+var blackGenerationNames = []string{"none", "light", "medium", "heavy", "max"}
+
+func (g BlackGeneration) String() string {
+	if g < BlackNone || g > BlackMaximum {
+		return "unknown"
+	}
+	return blackGenerationNames[g]
+}
+
+// ParseBlackGeneration accepts none, light, medium, heavy and max.
+func ParseBlackGeneration(s string) (BlackGeneration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "maximum" {
+		s = "max"
+	}
+	for i, name := range blackGenerationNames {
+		if s == name {
+			return BlackGeneration(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown black generation %q (none, light, medium, heavy, max)", s)
+}
+
+// amount of the gray component moved to K, and the gray level at which
+// black generation starts
+func (g BlackGeneration) curve() (amount, start float64) {
+	switch g {
+	case BlackLight:
+		return 0.5, 0.5
+	case BlackMedium:
+		return 0.75, 0.3
+	case BlackHeavy:
+		return 0.9, 0.1
+	case BlackMaximum:
+		return 1, 0
+	}
+	return 0, 1
+}
+
+// -------------------------------
+// CMYK separation options
+// -------------------------------
+type CMYKOptions struct {
+	GCR       BlackGeneration // black generation strength
+	UCR       bool            // only replace gray under neutral shadows
+	TAC       float64         // total area coverage limit in % (0 = none)
+	RichBlack bool            // separate pure black as a rich black
+	DotGain   float64         // midtone dot gain in % to compensate (0 = none)
+}
+
+// DefaultCMYKOptions reproduces the plain K = 1 − max(R, G, B) formula.
+var DefaultCMYKOptions = CMYKOptions{GCR: BlackMaximum}
+
+// RichBlack is the separation used for pure black when rich black is on.
+var RichBlack = CMYK{C: 60, M: 40, Y: 40, K: 100}
+
+// -------------------------------
+// RGB → CMYK with options
+// -------------------------------
+func (c RGB) ToCMYKWith(opts CMYKOptions) (CMYK, error) {
+	if !c.IsValid() {
+		return CMYK{}, errors.New("invalid RGB value")
+	}
+	if opts.TAC < 0 || opts.TAC > 400 {
+		return CMYK{}, errors.New("total area coverage must be between 0 and 400")
+	}
+	if opts.DotGain < 0 || opts.DotGain >= 50 {
+		return CMYK{}, errors.New("dot gain must be between 0 and 50")
+	}
+
+	cy := 1 - float64(c.R)/255
+	m := 1 - float64(c.G)/255
+	y := 1 - float64(c.B)/255
+
+	gray := math.Min(cy, math.Min(m, y))
+	neutral := 1 - (math.Max(cy, math.Max(m, y)) - gray)
+
+	var out CMYK
+	if opts.RichBlack && gray >= 0.995 {
+		out = RichBlack
+	} else {
+		amount, start := opts.GCR.curve()
+		if opts.UCR {
+			// under colour removal: shadows only, fading out with saturation
+			start = math.Max(start, 0.5)
+			amount *= neutral * neutral
+		}
+
+		k := 0.0
+		if gray > start {
+			k = amount * (gray - start) / (1 - start)
+		}
+
+		// remove the gray replaced by K (r = (1 − c)(1 − k))
+		sep := func(v float64) float64 {
+			if k >= 1 {
+				return 0
+			}
+			return math.Max(0, (v-k)/(1-k))
+		}
+		out = CMYK{C: sep(cy) * 100, M: sep(m) * 100, Y: sep(y) * 100, K: k * 100}
+	}
+
+	if opts.TAC > 0 {
+		out = out.LimitTAC(opts.TAC)
+	}
+	if opts.DotGain > 0 {
+		out = out.CompensateDotGain(opts.DotGain)
+	}
+	return out, nil
+}
+
+// -------------------------------
+// Total area coverage
+// -------------------------------
+func (c CMYK) TotalCoverage() float64 {
+	return c.C + c.M + c.Y + c.K
+}
+
+// LimitTAC scales C, M and Y down so the total coverage stays within
+// tac percent, keeping K.
+func (c CMYK) LimitTAC(tac float64) CMYK {
+	total := c.TotalCoverage()
+	if total <= tac {
+		return c
+	}
+	if c.K >= tac {
+		return CMYK{K: tac}
+	}
+	scale := (tac - c.K) / (c.C + c.M + c.Y)
+	return CMYK{C: c.C * scale, M: c.M * scale, Y: c.Y * scale, K: c.K}
+}
+
+// -------------------------------
+// Dot gain
+// -------------------------------
+
+// DotGain returns the printed tone of a tint given the midtone gain,
+// using the parabolic model printed = v + 4·g·v·(1 − v).
+func DotGain(tint, gain float64) float64 {
+	v := tint / 100
+	g := gain / 100
+	return (v + 4*g*v*(1-v)) * 100
+}
+
+// CompensateDotGain reduces each channel so that, after the press adds
+// `gain` percent at 50 %, the printed tones match the original values.
+func (c CMYK) CompensateDotGain(gain float64) CMYK {
+	if gain <= 0 {
+		return c
+	}
+	g := gain / 100
+	inv := func(tint float64) float64 {
+		v := tint / 100
+		a := 1 + 4*g
+		u := (a - math.Sqrt(a*a-16*g*v)) / (8 * g)
+		return math.Min(math.Max(u, 0), 1) * 100
+	}
+	return CMYK{C: inv(c.C), M: inv(c.M), Y: inv(c.Y), K: inv(c.K)}
+}
@@ -0,0 +1,156 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func cmykNear(a, b CMYK, eps float64) bool {
+	return math.Abs(a.C-b.C) <= eps && math.Abs(a.M-b.M) <= eps &&
+		math.Abs(a.Y-b.Y) <= eps && math.Abs(a.K-b.K) <= eps
+}
+
+func TestToCMYKWithDefaultIsNaive(t *testing.T) {
+	for _, tc := range []struct {
+		in   RGB
+		want CMYK
+	}{
+		{RGB{255, 255, 255}, CMYK{}},
+		{RGB{0, 0, 0}, CMYK{K: 100}},
+		{RGB{255, 0, 0}, CMYK{M: 100, Y: 100}},
+		{RGB{255, 87, 51}, CMYK{M: 100 * (1 - 87.0/255), Y: 80}},
+		{RGB{128, 128, 128}, CMYK{K: 100 * (1 - 128.0/255)}},
+	} {
+		got, err := tc.in.ToCMYKWith(DefaultCMYKOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmykNear(got, tc.want, 1e-9) {
+			t.Errorf("%+v → %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+// Every black generation setting (with and without UCR) separates the
+// same colour, so the naive inverse gives the input back.
+func TestToCMYKWithBlackGeneration(t *testing.T) {
+	colours := []RGB{{40, 40, 40}, {128, 128, 128}, {64, 0, 0}, {30, 60, 90}, {200, 180, 20}}
+	for _, c := range colours {
+		prevK := -1.0
+		for g := BlackNone; g <= BlackMaximum; g++ {
+			for _, ucr := range []bool{false, true} {
+				got, err := c.ToCMYKWith(CMYKOptions{GCR: g, UCR: ucr})
+				if err != nil {
+					t.Fatal(err)
+				}
+				r, gr, b, err := got.ToRGB()
+				if err != nil {
+					t.Fatalf("%+v %v ucr=%v: %v", c, g, ucr, err)
+				}
+				if (RGB{r, gr, b}) != c {
+					t.Errorf("%+v %v ucr=%v: %+v prints as (%d, %d, %d)", c, g, ucr, got, r, gr, b)
+				}
+				if !ucr {
+					if got.K < prevK-1e-9 {
+						t.Errorf("%+v: K drops from %.2f to %.2f at %v", c, prevK, got.K, g)
+					}
+					prevK = got.K
+				}
+			}
+		}
+	}
+
+	// none never uses black
+	if got, _ := (RGB{40, 40, 40}).ToCMYKWith(CMYKOptions{GCR: BlackNone}); got.K != 0 {
+		t.Errorf("GCR none: K = %.2f, want 0", got.K)
+	}
+}
+
+// UCR only replaces the gray under neutral shadows: a saturated dark
+// red gets less black than with plain GCR, a neutral shadow the same
+// curve from 50 % gray.
+func TestToCMYKWithUCR(t *testing.T) {
+	red := RGB{64, 0, 0}
+	gcr, _ := red.ToCMYKWith(CMYKOptions{GCR: BlackMaximum})
+	ucr, _ := red.ToCMYKWith(CMYKOptions{GCR: BlackMaximum, UCR: true})
+	if ucr.K >= gcr.K {
+		t.Errorf("UCR K %.2f should be below GCR K %.2f for a saturated colour", ucr.K, gcr.K)
+	}
+
+	light, _ := (RGB{200, 200, 200}).ToCMYKWith(CMYKOptions{GCR: BlackMaximum, UCR: true})
+	if light.K != 0 {
+		t.Errorf("UCR on a light gray: K = %.2f, want 0", light.K)
+	}
+	dark, _ := (RGB{51, 51, 51}).ToCMYKWith(CMYKOptions{GCR: BlackMaximum, UCR: true})
+	if want := 100 * (0.8 - 0.5) / 0.5; math.Abs(dark.K-want) > 1e-9 {
+		t.Errorf("UCR on 80%% gray: K = %.4f, want %.4f", dark.K, want)
+	}
+}
+
+func TestToCMYKWithTAC(t *testing.T) {
+	for _, tc := range []struct {
+		in   RGB
+		opts CMYKOptions
+	}{
+		{RGB{16, 16, 16}, CMYKOptions{GCR: BlackNone, TAC: 250}},
+		{RGB{20, 10, 40}, CMYKOptions{GCR: BlackLight, TAC: 280}},
+		{RGB{0, 0, 0}, CMYKOptions{RichBlack: true, TAC: 200}},
+		{RGB{0, 0, 0}, CMYKOptions{GCR: BlackNone, TAC: 80}},
+	} {
+		unlimited := tc.opts
+		unlimited.TAC = 0
+		before, _ := tc.in.ToCMYKWith(unlimited)
+		got, err := tc.in.ToCMYKWith(tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if before.TotalCoverage() <= tc.opts.TAC {
+			t.Fatalf("%+v: coverage %.1f is already within %v", tc.in, before.TotalCoverage(), tc.opts.TAC)
+		}
+		if math.Abs(got.TotalCoverage()-tc.opts.TAC) > 1e-9 {
+			t.Errorf("%+v: coverage %.4f, want %v", tc.in, got.TotalCoverage(), tc.opts.TAC)
+		}
+		if before.K <= tc.opts.TAC && got.K != before.K {
+			t.Errorf("%+v: K changed from %.2f to %.2f", tc.in, before.K, got.K)
+		}
+	}
+
+	// rich black within the limit is left alone
+	got, _ := (RGB{0, 0, 0}).ToCMYKWith(CMYKOptions{RichBlack: true, TAC: 300})
+	if got != RichBlack {
+		t.Errorf("rich black at TAC 300 = %+v, want %+v", got, RichBlack)
+	}
+}
+
+func TestToCMYKWithInvalidOptions(t *testing.T) {
+	for _, opts := range []CMYKOptions{{TAC: -1}, {TAC: 401}, {DotGain: -1}, {DotGain: 50}} {
+		if _, err := (RGB{10, 20, 30}).ToCMYKWith(opts); err == nil {
+			t.Errorf("%+v: expected an error", opts)
+		}
+	}
+}
+
+func TestDotGain(t *testing.T) {
+	if got := DotGain(50, 20); math.Abs(got-70) > 1e-9 {
+		t.Errorf("DotGain(50, 20) = %v, want 70", got)
+	}
+	for _, gain := range []float64{5, 15, 30} {
+		for _, tint := range []float64{0, 10, 25, 50, 75, 90, 100} {
+			comp := CMYK{C: tint, M: tint, Y: tint, K: tint}.CompensateDotGain(gain)
+			if comp.C > tint+1e-9 {
+				t.Errorf("gain %v: tint %v compensated up to %v", gain, tint, comp.C)
+			}
+			if printed := DotGain(comp.C, gain); math.Abs(printed-tint) > 1e-9 {
+				t.Errorf("gain %v: tint %v prints as %v", gain, tint, printed)
+			}
+		}
+	}
+
+	got, err := (RGB{128, 128, 128}).ToCMYKWith(CMYKOptions{GCR: BlackMaximum, DotGain: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if printed := DotGain(got.K, 20); math.Abs(printed-100*(1-128.0/255)) > 1e-9 {
+		t.Errorf("compensated K %v prints as %v", got.K, printed)
+	}
+}
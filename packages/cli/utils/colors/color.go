@@ -0,0 +1,217 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// Color (any space, with alpha)
+// -------------------------------
+
+// Color is a colour in one of the supported spaces. V holds the channel
+// values in the units documented on Space; Alpha is 0–1.
+type Color struct {
+	Space Space
+	V     [4]float64
+	Alpha float64
+}
+
+// NewColor builds an opaque colour from channel values.
+func NewColor(space Space, v ...float64) Color {
+	c := Color{Space: space, Alpha: 1}
+	copy(c.V[:], v)
+	return c
+}
+
+// FromRGB wraps an 8-bit sRGB colour.
+func FromRGB(c RGB) Color {
+	return NewColor(SpaceSRGB, float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// XYZ returns the colour as CIE XYZ (D65), ignoring alpha.
+func (c Color) XYZ() XYZ {
+	return spaceToXYZ(c.Space, c.V)
+}
+
+// To converts the colour to another space. Values are not clipped.
+func (c Color) To(space Space) Color {
+	if space == c.Space {
+		return c
+	}
+	return Color{Space: space, V: spaceFromXYZ(space, c.XYZ()), Alpha: c.Alpha}
+}
+
+// InGamut reports whether the colour fits its space's range; unbounded
+// spaces always fit. HSL and HWB are checked against sRGB.
+func (c Color) InGamut() bool {
+	const eps = 1e-6
+	switch c.Space {
+	case SpaceHSL, SpaceHWB:
+		return c.To(SpaceSRGB).InGamut()
+	case SpaceCMYK:
+		for _, v := range c.V {
+			if v < -eps || v > 100+eps {
+				return false
+			}
+		}
+		return true
+	}
+	if !c.Space.Bounded() {
+		return true
+	}
+	for _, v := range c.V[:3] {
+		if v < -eps || v > 1+eps {
+			return false
+		}
+	}
+	return true
+}
+
+// Clip clamps the channels of a bounded space to its range. HSL and
+// HWB colours are clipped in sRGB.
+func (c Color) Clip() Color {
+	switch c.Space {
+	case SpaceHSL, SpaceHWB:
+		if c.InGamut() {
+			return c
+		}
+		return c.To(SpaceSRGB).Clip().To(c.Space)
+	case SpaceCMYK:
+		for i := range c.V {
+			c.V[i] = math.Max(0, math.Min(100, c.V[i]))
+		}
+		return c
+	}
+	if c.Space.Bounded() {
+		for i := 0; i < 3; i++ {
+			c.V[i] = clamp01(c.V[i])
+		}
+	}
+	return c
+}
+
+// MapToSRGB returns the colour in sRGB, fitted to the gamut as CSS
+// Color 4 does: chroma is reduced in OKLCH, keeping lightness and hue,
+// until clipping changes the colour by less than a just-noticeable
+// difference (ΔEOK 0.02).
+func (c Color) MapToSRGB() Color {
+	s := c.To(SpaceSRGB)
+	if s.InGamut() {
+		return s.Clip()
+	}
+	o := c.To(SpaceOKLCH)
+	if o.V[0] >= 1 {
+		return Color{Space: SpaceSRGB, V: [4]float64{1, 1, 1}, Alpha: c.Alpha}
+	}
+	if o.V[0] <= 0 {
+		return Color{Space: SpaceSRGB, Alpha: c.Alpha}
+	}
+
+	const jnd, eps = 0.02, 0.0001
+	clipped := s.Clip()
+	if DeltaEOK(clipped, o) < jnd {
+		return clipped
+	}
+	lo, hi := 0.0, o.V[1]
+	inGamut := true
+	for hi-lo > eps {
+		o.V[1] = (lo + hi) / 2
+		s = o.To(SpaceSRGB)
+		if inGamut && s.InGamut() {
+			lo = o.V[1]
+			continue
+		}
+		clipped = s.Clip()
+		e := DeltaEOK(clipped, o)
+		if e < jnd {
+			if jnd-e < eps {
+				return clipped
+			}
+			inGamut = false
+			lo = o.V[1]
+		} else {
+			hi = o.V[1]
+		}
+	}
+	return clipped
+}
+
+// RGB returns the colour as clipped 8-bit sRGB.
+func (c Color) RGB() RGB {
+	s := c.To(SpaceSRGB).Clip()
+	return RGB{
+		R: int(math.Round(s.V[0] * 255)),
+		G: int(math.Round(s.V[1] * 255)),
+		B: int(math.Round(s.V[2] * 255)),
+	}
+}
+
+// Hex returns #RRGGBB, or #RRGGBBAA when the colour is translucent.
+func (c Color) Hex() string {
+	rgb := c.RGB()
+	if c.Alpha < 1 {
+		a := int(math.Round(clamp01(c.Alpha) * 255))
+		return fmt.Sprintf("#%02X%02X%02X%02X", rgb.R, rgb.G, rgb.B, a)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", rgb.R, rgb.G, rgb.B)
+}
+
+// String formats the colour as CSS (cmyk and hcl use this package's
+// own notations), e.g. "oklch(0.6279 0.2577 29.23)".
+func (c Color) String() string {
+	return c.css(num)
+}
+
+// css formats the colour with num, which renders a value to at most the
+// given number of decimals.
+func (c Color) css(num func(v float64, prec int) string) string {
+	v := c.V
+	var body string
+	switch c.Space {
+	case SpaceSRGB:
+		body = fmt.Sprintf("rgb(%s %s %s", num(v[0]*255, 2), num(v[1]*255, 2), num(v[2]*255, 2))
+	case SpaceHSL:
+		body = fmt.Sprintf("hsl(%s %s%% %s%%", num(v[0], 2), num(v[1], 2), num(v[2], 2))
+	case SpaceHWB:
+		body = fmt.Sprintf("hwb(%s %s%% %s%%", num(v[0], 2), num(v[1], 2), num(v[2], 2))
+	case SpaceLab:
+		body = fmt.Sprintf("lab(%s %s %s", num(v[0], 2), num(v[1], 2), num(v[2], 2))
+	case SpaceLCH:
+		body = fmt.Sprintf("lch(%s %s %s", num(v[0], 2), num(v[1], 2), num(v[2], 2))
+	case SpaceOklab:
+		body = fmt.Sprintf("oklab(%s %s %s", num(v[0], 4), num(v[1], 4), num(v[2], 4))
+	case SpaceOKLCH:
+		body = fmt.Sprintf("oklch(%s %s %s", num(v[0], 4), num(v[1], 4), num(v[2], 2))
+	case SpaceHCL:
+		body = fmt.Sprintf("hcl(%s %s %s", num(v[0], 2), num(v[1], 2), num(v[2], 2))
+	case SpaceCMYK:
+		body = fmt.Sprintf("cmyk(%s%% %s%% %s%% %s%%", num(v[0], 2), num(v[1], 2), num(v[2], 2), num(v[3], 2))
+	default:
+		body = fmt.Sprintf("color(%s %s %s %s", c.Space, num(v[0], 4), num(v[1], 4), num(v[2], 4))
+	}
+	if c.Alpha < 1 {
+		body += " / " + num(c.Alpha, 3)
+	}
+	return body + ")"
+}
+
+// num formats v with at most prec decimals, trimming trailing zeros.
+func num(v float64, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+// exactNum formats v with as many decimals as it takes to read it back
+// unchanged.
+func exactNum(v float64, _ int) string {
+	return num(v, -1)
+}
@@ -0,0 +1,259 @@
+package colors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// -------------------------------
+// Colormap analysis
+// -------------------------------
+
+// ColormapSample is one colour of an analysed colormap.
+type ColormapSample struct {
+	Color  Color   `json:"color" yaml:"color"`
+	L      float64 `json:"l" yaml:"l"`               // CIE L* (D50)
+	C      float64 `json:"c" yaml:"c"`               // CIE C*
+	H      float64 `json:"h" yaml:"h"`               // CIE hue, degrees
+	Gray   float64 `json:"gray" yaml:"gray"`         // Rec. 601 luma, 0–100
+	DeltaE float64 `json:"deltaE00" yaml:"deltaE00"` // ΔE00 from the previous sample
+}
+
+// ColormapStats summarizes the ΔE00 steps between adjacent samples.
+type ColormapStats struct {
+	Mean float64 `json:"mean" yaml:"mean"`
+	Min  float64 `json:"min" yaml:"min"`
+	Max  float64 `json:"max" yaml:"max"`
+	CV   float64 `json:"cv" yaml:"cv"` // coefficient of variation; 0 is perfectly uniform
+}
+
+// ColormapTrend describes how a quantity runs along the map.
+type ColormapTrend struct {
+	Direction int     `json:"direction" yaml:"direction"` // 1 rising, -1 falling, 0 flat
+	Reversals int     `json:"reversals" yaml:"reversals"` // steps against the direction
+	Monotonic bool    `json:"monotonic" yaml:"monotonic"`
+	Range     float64 `json:"range" yaml:"range"` // largest minus smallest value
+}
+
+// ColormapCVD is how the map holds up under a colour vision deficiency.
+type ColormapCVD struct {
+	CVD       CVD           `json:"cvd" yaml:"cvd"`
+	Retained  float64       `json:"retained" yaml:"retained"` // ΔE00 path length as a share of normal vision's
+	MinStep   float64       `json:"minStep" yaml:"minStep"`   // smallest adjacent ΔE00
+	Lightness ColormapTrend `json:"lightness" yaml:"lightness"`
+}
+
+// ColormapReport is the result of AnalyzeColormap.
+type ColormapReport struct {
+	Samples    []ColormapSample `json:"samples" yaml:"samples"`
+	Uniformity ColormapStats    `json:"uniformity" yaml:"uniformity"`
+	Lightness  ColormapTrend    `json:"lightness" yaml:"lightness"`
+	Gray       ColormapTrend    `json:"gray" yaml:"gray"`
+	CVD        []ColormapCVD    `json:"cvd" yaml:"cvd"`
+}
+
+// trendTolerance is the change (L* or luma units) below which a step
+// counts as neither rising nor falling.
+const trendTolerance = 0.5
+
+// AnalyzeColormap measures a colormap given as its samples in order:
+// perceptual uniformity (CIEDE2000 between adjacent samples), whether
+// CIE lightness runs one way, how much of the map's ΔE00 survives
+// protan, deutan and tritan simulation, and whether its gray-scale
+// (Rec. 601 luma, as most printers and copiers convert) stays ordered.
+func AnalyzeColormap(cs []Color) (ColormapReport, error) {
+	if len(cs) < 2 {
+		return ColormapReport{}, fmt.Errorf("a colormap needs at least 2 colors, got %d", len(cs))
+	}
+	var rep ColormapReport
+	labs := make([]Lab, len(cs))
+	lightness := make([]float64, len(cs))
+	gray := make([]float64, len(cs))
+	for i, c := range cs {
+		lab := c.To(SpaceLab).V
+		lch := c.To(SpaceLCH).V
+		srgb := c.To(SpaceSRGB).Clip().V
+		labs[i] = Lab{L: lab[0], A: lab[1], B: lab[2]}
+		lightness[i] = lab[0]
+		gray[i] = 100 * (0.299*srgb[0] + 0.587*srgb[1] + 0.114*srgb[2])
+		s := ColormapSample{Color: c, L: lch[0], C: lch[1], H: lch[2], Gray: gray[i]}
+		if i > 0 {
+			s.DeltaE = DeltaE2000(labs[i-1], labs[i])
+		}
+		rep.Samples = append(rep.Samples, s)
+	}
+
+	steps := make([]float64, len(cs)-1)
+	for i := range steps {
+		steps[i] = rep.Samples[i+1].DeltaE
+	}
+	rep.Uniformity = stepStats(steps)
+	rep.Lightness = trend(lightness)
+	rep.Gray = trend(gray)
+
+	normal := rep.Uniformity.Mean * float64(len(steps))
+	for _, d := range []CVD{CVDProtan, CVDDeutan, CVDTritan} {
+		sim := make([]Lab, len(cs))
+		simL := make([]float64, len(cs))
+		for i, c := range cs {
+			sim[i] = cvdLab(c, d)
+			simL[i] = sim[i].L
+		}
+		length, minStep := 0.0, math.Inf(1)
+		for i := 1; i < len(sim); i++ {
+			step := DeltaE2000(sim[i-1], sim[i])
+			length += step
+			minStep = math.Min(minStep, step)
+		}
+		r := ColormapCVD{CVD: d, MinStep: minStep, Lightness: trend(simL)}
+		if normal > 0 {
+			r.Retained = length / normal
+		}
+		rep.CVD = append(rep.CVD, r)
+	}
+	return rep, nil
+}
+
+func stepStats(steps []float64) ColormapStats {
+	s := ColormapStats{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, v := range steps {
+		s.Mean += v
+		s.Min = math.Min(s.Min, v)
+		s.Max = math.Max(s.Max, v)
+	}
+	s.Mean /= float64(len(steps))
+	if s.Mean > 0 {
+		variance := 0.0
+		for _, v := range steps {
+			variance += (v - s.Mean) * (v - s.Mean)
+		}
+		s.CV = math.Sqrt(variance/float64(len(steps))) / s.Mean
+	}
+	return s
+}
+
+func trend(values []float64) ColormapTrend {
+	var t ColormapTrend
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	t.Range = hi - lo
+	if d := values[len(values)-1] - values[0]; d > trendTolerance {
+		t.Direction = 1
+	} else if d < -trendTolerance {
+		t.Direction = -1
+	}
+	for i := 1; i < len(values); i++ {
+		d := values[i] - values[i-1]
+		if (t.Direction >= 0 && d < -trendTolerance) || (t.Direction <= 0 && d > trendTolerance) {
+			t.Reversals++
+		}
+	}
+	t.Monotonic = t.Direction != 0 && t.Reversals == 0
+	return t
+}
+
+// -------------------------------
+// Colormap diagnostic chart (SVG)
+// -------------------------------
+
+// WriteSVG draws the diagnostic chart: the map as seen with normal
+// vision, in gray-scale and under each simulated deficiency, above a
+// plot of CIE L*, C* and hue along it.
+func (r ColormapReport) WriteSVG(w io.Writer) error {
+	const (
+		width, left, right = 720.0, 90.0, 20.0
+		stripH, gap        = 28.0, 6.0
+		plotH              = 220.0
+		maxChroma          = 150.0
+	)
+	plotW := width - left - right
+	n := len(r.Samples)
+	x := func(i int) float64 {
+		if n == 1 {
+			return left
+		}
+		return left + plotW*float64(i)/float64(n-1)
+	}
+
+	type strip struct {
+		label string
+		color func(ColormapSample) Color
+	}
+	strips := []strip{
+		{"normal", func(s ColormapSample) Color { return s.Color }},
+		{"gray", func(s ColormapSample) Color { g := s.Gray / 100; return NewColor(SpaceSRGB, g, g, g) }},
+	}
+	for _, c := range r.CVD {
+		d := c.CVD
+		strips = append(strips, strip{d.String(), func(s ColormapSample) Color { return SimulateCVD(s.Color, d, 1) }})
+	}
+	plotTop := 20 + float64(len(strips))*(stripH+gap) + 20
+	height := plotTop + plotH + 50
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="sans-serif" font-size="12">`+"\n",
+		num(width, 1), num(height, 1), num(width, 1), num(height, 1))
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="#FFFFFF"/>`+"\n")
+
+	// strips: one rect per sample, each reaching the next sample's start
+	cell := plotW / float64(n)
+	for si, s := range strips {
+		y := 20 + float64(si)*(stripH+gap)
+		fmt.Fprintf(b, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n",
+			num(left-8, 1), num(y+stripH/2, 1), s.label)
+		for i, sample := range r.Samples {
+			fmt.Fprintf(b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+				num(left+cell*float64(i), 2), num(y, 1), num(cell+0.5, 2), num(stripH, 1), s.color(sample).MapToSRGB().Hex())
+		}
+	}
+
+	// plot frame and gridlines at L* 0, 25, 50, 75, 100
+	fmt.Fprintf(b, `<rect x="%s" y="%s" width="%s" height="%s" fill="none" stroke="#999999"/>`+"\n",
+		num(left, 1), num(plotTop, 1), num(plotW, 1), num(plotH, 1))
+	for _, v := range []float64{0, 25, 50, 75, 100} {
+		y := plotTop + plotH*(1-v/100)
+		fmt.Fprintf(b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="#E5E5E5"/>`+"\n", num(left, 1), num(y, 1), num(left+plotW, 1), num(y, 1))
+		fmt.Fprintf(b, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", num(left-8, 1), num(y, 1), num(v, 0))
+	}
+
+	// series scaled to 0–100: L* as is, C* over 0–150, hue over 0–360
+	series := []struct {
+		label, color string
+		value        func(ColormapSample) float64
+		line         bool
+	}{
+		{"L*", "#222222", func(s ColormapSample) float64 { return s.L }, true},
+		{"C* (0–150)", "#D62728", func(s ColormapSample) float64 { return math.Min(s.C, maxChroma) / maxChroma * 100 }, true},
+		{"hue (0–360°)", "#1F77B4", func(s ColormapSample) float64 { return s.H / 360 * 100 }, false},
+	}
+	for si, s := range series {
+		y := func(v float64) float64 { return plotTop + plotH*(1-math.Max(0, math.Min(100, v))/100) }
+		if s.line {
+			pts := make([]string, n)
+			for i, sample := range r.Samples {
+				pts[i] = num(x(i), 2) + "," + num(y(s.value(sample)), 2)
+			}
+			fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(pts, " "), s.color)
+		} else {
+			// hue wraps, so draw it as dots
+			for i, sample := range r.Samples {
+				if sample.C < 1 {
+					continue // hue is meaningless for near-neutral colours
+				}
+				fmt.Fprintf(b, `<circle cx="%s" cy="%s" r="1.5" fill="%s"/>`+"\n", num(x(i), 2), num(y(s.value(sample)), 2), s.color)
+			}
+		}
+		lx := left + float64(si)*150
+		ly := plotTop + plotH + 30
+		fmt.Fprintf(b, `<rect x="%s" y="%s" width="14" height="4" fill="%s"/>`+"\n", num(lx, 1), num(ly-2, 1), s.color)
+		fmt.Fprintf(b, `<text x="%s" y="%s" dominant-baseline="middle">%s</text>`+"\n", num(lx+20, 1), num(ly, 1), s.label)
+	}
+
+	fmt.Fprintln(b, `</svg>`)
+	return b.Flush()
+}
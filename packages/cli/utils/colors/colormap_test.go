@@ -0,0 +1,93 @@
+package colors
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// viridis at 11 evenly spaced points (matplotlib)
+var viridis = []string{
+	"#440154", "#482475", "#414487", "#355F8D", "#2A788E", "#21918C",
+	"#22A884", "#44BF70", "#7AD151", "#BDDF26", "#FDE725",
+}
+
+// jet's control colours (matplotlib), the classic rainbow map
+var jet = []string{
+	"#00007F", "#0000FF", "#007FFF", "#00FFFF", "#7FFF7F",
+	"#FFFF00", "#FF7F00", "#FF0000", "#7F0000",
+}
+
+func parseAll(hexes []string) []Color {
+	out := make([]Color, len(hexes))
+	for i, h := range hexes {
+		out[i] = MustParse(h)
+	}
+	return out
+}
+
+func TestAnalyzeColormapViridis(t *testing.T) {
+	rep, err := AnalyzeColormap(parseAll(viridis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rep.Lightness.Monotonic || rep.Lightness.Direction != 1 {
+		t.Errorf("lightness = %+v, want monotonic and rising", rep.Lightness)
+	}
+	if !rep.Gray.Monotonic || rep.Gray.Range < 30 {
+		t.Errorf("gray = %+v, want monotonic over a printable range", rep.Gray)
+	}
+	if rep.Uniformity.CV > 0.25 {
+		t.Errorf("uniformity CV = %.3f, want a near-uniform map", rep.Uniformity.CV)
+	}
+	for _, c := range rep.CVD {
+		if !c.Lightness.Monotonic || c.Retained < 0.5 {
+			t.Errorf("%s: %+v, want monotonic lightness keeping half the ΔE00", c.CVD, c)
+		}
+	}
+	var svg bytes.Buffer
+	if err := rep.WriteSVG(&svg); err != nil || !strings.HasSuffix(svg.String(), "</svg>\n") {
+		t.Errorf("WriteSVG: %v", err)
+	}
+}
+
+func TestAnalyzeColormapJet(t *testing.T) {
+	rep, err := AnalyzeColormap(parseAll(jet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Lightness.Monotonic || rep.Lightness.Reversals == 0 {
+		t.Errorf("lightness = %+v, want reversals", rep.Lightness)
+	}
+	if rep.Gray.Monotonic {
+		t.Errorf("gray = %+v, want it out of order", rep.Gray)
+	}
+}
+
+func TestAnalyzeColormapErrors(t *testing.T) {
+	for _, cs := range [][]Color{nil, parseAll([]string{"#440154"})} {
+		if _, err := AnalyzeColormap(cs); err == nil {
+			t.Errorf("%d colors: want an error", len(cs))
+		}
+	}
+}
+
+func TestTrend(t *testing.T) {
+	for _, tc := range []struct {
+		values    []float64
+		direction int
+		reversals int
+		monotonic bool
+	}{
+		{[]float64{1, 2, 3}, 1, 0, true},
+		{[]float64{3, 2.8, 1}, -1, 0, true}, // 0.2 is within the tolerance
+		{[]float64{1, 5, 2, 6}, 1, 1, false},
+		{[]float64{1, 5, 1}, 0, 2, false},
+		{[]float64{2, 2.1, 2}, 0, 0, false},
+	} {
+		got := trend(tc.values)
+		if got.Direction != tc.direction || got.Reversals != tc.reversals || got.Monotonic != tc.monotonic {
+			t.Errorf("trend(%v) = %+v", tc.values, got)
+		}
+	}
+}
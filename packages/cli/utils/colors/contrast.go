@@ -0,0 +1,191 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// WCAG 2 contrast
+// -------------------------------
+
+// RelativeLuminance is the WCAG relative luminance of the colour,
+// clipped to sRGB (0 black, 1 white).
+func RelativeLuminance(c Color) float64 {
+	s := c.To(SpaceSRGB).Clip()
+	return 0.2126*decodeSRGB(s.V[0]) + 0.7152*decodeSRGB(s.V[1]) + 0.0722*decodeSRGB(s.V[2])
+}
+
+// ContrastRatio is the WCAG 2 contrast ratio of two colours, 1–21.
+func ContrastRatio(a, b Color) float64 {
+	la, lb := RelativeLuminance(a), RelativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// -------------------------------
+// APCA contrast (APCA-W3 0.0.98G)
+// -------------------------------
+
+// APCAContrast returns the lightness contrast Lc of text over a
+// background: positive for dark text on light, negative for light text
+// on dark, roughly ±108 at most.
+func APCAContrast(text, background Color) float64 {
+	const (
+		blkThrs, blkClmp    = 0.022, 1.414
+		normBG, normTXT     = 0.56, 0.57
+		revBG, revTXT       = 0.65, 0.62
+		scale, offset, clip = 1.14, 0.027, 0.1
+		deltaYmin           = 0.0005
+	)
+	y := func(c Color) float64 {
+		s := c.To(SpaceSRGB).Clip()
+		v := 0.2126729*math.Pow(s.V[0], 2.4) + 0.7151522*math.Pow(s.V[1], 2.4) + 0.0721750*math.Pow(s.V[2], 2.4)
+		if v < blkThrs {
+			v += math.Pow(blkThrs-v, blkClmp)
+		}
+		return v
+	}
+	yt, yb := y(text), y(background)
+	if math.Abs(yb-yt) < deltaYmin {
+		return 0
+	}
+	if yb > yt {
+		sapc := (math.Pow(yb, normBG) - math.Pow(yt, normTXT)) * scale
+		if sapc < clip {
+			return 0
+		}
+		return (sapc - offset) * 100
+	}
+	sapc := (math.Pow(yb, revBG) - math.Pow(yt, revTXT)) * scale
+	if sapc > -clip {
+		return 0
+	}
+	return (sapc + offset) * 100
+}
+
+// -------------------------------
+// Contrast targets
+// -------------------------------
+
+// ContrastTarget is a minimum contrast under WCAG 2 (ratio) or APCA
+// (absolute Lc).
+type ContrastTarget struct {
+	APCA bool
+	Min  float64
+}
+
+// ParseContrastTarget accepts aa (4.5:1), aaa (7:1), aa-large (3:1),
+// wcag:<ratio>, and apca:<Lc> or apca (Lc 75, body text).
+func ParseContrastTarget(s string) (ContrastTarget, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "aa", "wcag-aa":
+		return ContrastTarget{Min: 4.5}, nil
+	case "aaa", "wcag-aaa":
+		return ContrastTarget{Min: 7}, nil
+	case "aa-large", "wcag-aa-large":
+		return ContrastTarget{Min: 3}, nil
+	case "apca":
+		return ContrastTarget{APCA: true, Min: 75}, nil
+	}
+	if method, v, ok := strings.Cut(s, ":"); ok && (method == "wcag" || method == "apca") {
+		limit, err := strconv.ParseFloat(strings.TrimSuffix(v, ":1"), 64)
+		if err == nil && limit > 0 {
+			return ContrastTarget{APCA: method == "apca", Min: limit}, nil
+		}
+	}
+	return ContrastTarget{}, fmt.Errorf("unknown contrast level %q (aa, aaa, aa-large, wcag:<ratio>, apca, apca:<Lc>)", s)
+}
+
+func (t ContrastTarget) String() string {
+	if t.APCA {
+		return "APCA Lc " + num(t.Min, 1)
+	}
+	return "WCAG " + num(t.Min, 2) + ":1"
+}
+
+// Contrast measures fg over bg: the WCAG ratio, or APCA's absolute Lc.
+func (t ContrastTarget) Contrast(fg, bg Color) float64 {
+	if t.APCA {
+		return math.Abs(APCAContrast(fg, bg))
+	}
+	return ContrastRatio(fg, bg)
+}
+
+// Passes reports whether fg over every bg meets the target.
+func (t ContrastTarget) Passes(fg Color, bgs ...Color) bool {
+	for _, bg := range bgs {
+		if t.Contrast(fg, bg) < t.Min {
+			return false
+		}
+	}
+	return true
+}
+
+// EnsureContrast returns fg, or the colour nearest it in OKLCH
+// lightness (hue and chroma kept where sRGB allows) that meets the
+// target over every bg. It reports false if even black or white fails.
+//
+// Colours are judged as the 8-bit sRGB that Hex writes, so the result
+// is quantized and still passes once emitted.
+func EnsureContrast(fg Color, t ContrastTarget, bgs ...Color) (Color, bool) {
+	bgs = append([]Color(nil), bgs...)
+	for i, bg := range bgs {
+		bgs[i] = quantize8(bg)
+	}
+	return seekLightness(fg, func(c Color) bool { return t.Passes(c, bgs...) }, 0, 1)
+}
+
+// seekLightness returns c, or the quantized colour nearest it in OKLCH
+// lightness towards one of ends (0 black, 1 white) for which passes
+// holds. It reports false if no end passes.
+func seekLightness(c Color, passes func(Color) bool, ends ...float64) (Color, bool) {
+	base := c.MapToSRGB().To(SpaceOKLCH)
+	f := quantize8(base)
+	if passes(f) {
+		return f, true
+	}
+	at := func(l float64) Color {
+		c := base
+		c.V[0] = l
+		return quantize8(c.MapToSRGB())
+	}
+
+	var best Color
+	found := false
+	for _, end := range ends {
+		if !passes(at(end)) {
+			continue
+		}
+		// bisect between the failing start and the passing end
+		lo, hi := f.V[0], end
+		for i := 0; i < 30; i++ {
+			mid := (lo + hi) / 2
+			if passes(at(mid)) {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		if c := at(hi); !found || math.Abs(c.V[0]-f.V[0]) < math.Abs(best.V[0]-f.V[0]) {
+			best, found = c, true
+		}
+	}
+	if !found {
+		return f, false
+	}
+	return best, true
+}
+
+// quantize8 returns c as the 8-bit sRGB colour Hex writes, in OKLCH.
+func quantize8(c Color) Color {
+	rgb := c.RGB()
+	q := NewColor(SpaceSRGB, float64(rgb.R)/255, float64(rgb.G)/255, float64(rgb.B)/255).To(SpaceOKLCH)
+	q.Alpha = c.Alpha
+	return q
+}
@@ -0,0 +1,66 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// The Machado matrices keep white white and every gray gray, at every
+// severity.
+func TestSimulateCVDKeepsNeutrals(t *testing.T) {
+	for _, d := range []CVD{CVDProtan, CVDDeutan, CVDTritan} {
+		for _, severity := range []float64{0.3, 0.7, 1} {
+			for _, g := range []float64{0, 0.05, 0.2, 0.5, 0.8, 1} {
+				gray := NewColor(SpaceLinearSRGB, g, g, g)
+				got := SimulateCVD(gray, d, severity)
+				for i := 0; i < 3; i++ {
+					if math.Abs(got.V[i]-g) > 2e-6 {
+						t.Errorf("%s at %.1f: gray %.2f becomes %v", d, severity, g, got.V)
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSimulateCVD(t *testing.T) {
+	red := MustParse("red")
+	red.Alpha = 0.5
+
+	// normal vision and zero severity leave the colour alone
+	for _, got := range []Color{SimulateCVD(red, CVDNone, 1), SimulateCVD(red, CVDProtan, 0)} {
+		if got.Space != SpaceLinearSRGB || math.Abs(got.V[0]-1) > 1e-12 || got.V[1] != 0 || got.V[2] != 0 || got.Alpha != 0.5 {
+			t.Errorf("got %v, want linear red with alpha 0.5", got)
+		}
+	}
+
+	// a dichromat sees red as the matrix's first column, clipped
+	for _, d := range []CVD{CVDProtan, CVDDeutan, CVDTritan} {
+		got := SimulateCVD(red, d, 1)
+		for i := 0; i < 3; i++ {
+			if want := clamp01(cvdMatrices[d][i][0]); math.Abs(got.V[i]-want) > 1e-12 {
+				t.Errorf("%s: red channel %d = %v, want %v", d, i, got.V[i], want)
+			}
+		}
+		// partial severity lies between normal and dichromat vision
+		half := SimulateCVD(red, d, 0.5)
+		if want := clamp01((1 + cvdMatrices[d][0][0]) / 2); math.Abs(half.V[0]-want) > 1e-12 {
+			t.Errorf("%s at 0.5: red = %v, want %v", d, half.V[0], want)
+		}
+	}
+}
+
+func TestParseCVD(t *testing.T) {
+	for s, want := range map[string]CVD{
+		"none": CVDNone, "Normal": CVDNone, "protanopia": CVDProtan, "deuteranomaly": CVDDeutan,
+		" tritan ": CVDTritan,
+	} {
+		if got, err := ParseCVD(s); err != nil || got != want {
+			t.Errorf("%q = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseCVD("achromat"); err == nil {
+		t.Error("achromat: want an error")
+	}
+}
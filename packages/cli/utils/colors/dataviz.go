@@ -0,0 +1,295 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// -------------------------------
+// Data-visualization palettes
+// -------------------------------
+
+// DatavizOptions configures the chart palette generators. Lightness
+// and chroma are OKLCH; a zero range takes the generator's default.
+type DatavizOptions struct {
+	Count     int
+	Lightness [2]float64 // lowest and highest L
+	Chroma    [2]float64 // lowest and highest C
+	// CVD lists the deficiencies under which categorical colours must
+	// also stay apart, besides normal vision.
+	CVD []CVD
+	// Base is kept as the first categorical colour, and gives the hue
+	// and peak chroma of a sequential palette and of a diverging
+	// palette's low end.
+	Base *Color
+	// Opposite is a diverging palette's high end (default: Base's
+	// complementary hue).
+	Opposite *Color
+}
+
+// default ranges per generator
+var (
+	categoricalLightness = [2]float64{0.5, 0.85}
+	categoricalChroma    = [2]float64{0.08, 0.2}
+	sequentialLightness  = [2]float64{0.3, 0.97}
+	sequentialChroma     = [2]float64{0.02, 0.16}
+)
+
+// withDefaults fills in zero ranges and checks the options.
+func (opt DatavizOptions) withDefaults(l, c [2]float64) (DatavizOptions, error) {
+	if opt.Count < 1 {
+		return opt, fmt.Errorf("count must be at least 1, got %d", opt.Count)
+	}
+	if opt.Lightness == [2]float64{} {
+		opt.Lightness = l
+	}
+	if opt.Chroma == [2]float64{} {
+		opt.Chroma = c
+	}
+	if opt.Lightness[0] > opt.Lightness[1] || opt.Lightness[0] < 0 || opt.Lightness[1] > 1 {
+		return opt, fmt.Errorf("lightness range %v is not within 0–1", opt.Lightness)
+	}
+	if opt.Chroma[0] > opt.Chroma[1] || opt.Chroma[0] < 0 {
+		return opt, fmt.Errorf("chroma range %v is invalid", opt.Chroma)
+	}
+	return opt, nil
+}
+
+// MinSeparation is the smallest CIEDE2000 difference between any two of
+// the colours as seen with the deficiency (CVDNone for normal vision).
+func MinSeparation(cs []Color, d CVD) float64 {
+	labs := make([]Lab, len(cs))
+	for i, c := range cs {
+		labs[i] = cvdLab(c, d)
+	}
+	best := math.Inf(1)
+	for i := range labs {
+		for j := i + 1; j < len(labs); j++ {
+			best = math.Min(best, DeltaE2000(labs[i], labs[j]))
+		}
+	}
+	return best
+}
+
+func cvdLab(c Color, d CVD) Lab {
+	v := SimulateCVD(c, d, 1).To(SpaceLab).V
+	return Lab{L: v[0], A: v[1], B: v[2]}
+}
+
+// CategoricalPalette picks Count colours within the lightness and
+// chroma ranges that maximize the smallest CIEDE2000 difference between
+// any two, under normal vision and every opt.CVD. Candidates are an
+// sRGB-gamut OKLCH grid; colours are chosen greedily (farthest first)
+// and then swapped while that raises the smallest difference, so the
+// result is deterministic and ordered most distinct first.
+func CategoricalPalette(opt DatavizOptions) ([]Color, error) {
+	return categoricalPalette(opt, 20)
+}
+
+// categoricalPalette is CategoricalPalette with at most the given
+// number of swap passes; 0 returns the greedy choice.
+func categoricalPalette(opt DatavizOptions, passes int) ([]Color, error) {
+	opt, err := opt.withDefaults(categoricalLightness, categoricalChroma)
+	if err != nil {
+		return nil, err
+	}
+	visions := append([]CVD{CVDNone}, opt.CVD...)
+
+	type candidate struct {
+		color Color
+		labs  []Lab // per vision
+	}
+	newCandidate := func(c Color) candidate {
+		cand := candidate{color: c, labs: make([]Lab, len(visions))}
+		for i, v := range visions {
+			cand.labs[i] = cvdLab(c, v)
+		}
+		return cand
+	}
+	var pool []candidate
+	const lSteps, cSteps, hStep = 7, 4, 10.0
+	for li := 0; li < lSteps; li++ {
+		l := opt.Lightness[0] + (opt.Lightness[1]-opt.Lightness[0])*float64(li)/(lSteps-1)
+		for ci := 0; ci < cSteps; ci++ {
+			ch := opt.Chroma[0] + (opt.Chroma[1]-opt.Chroma[0])*float64(ci)/(cSteps-1)
+			for h := 0.0; h < 360; h += hStep {
+				c := NewColor(SpaceOKLCH, l, ch, h)
+				if c.To(SpaceSRGB).InGamut() {
+					pool = append(pool, newCandidate(c))
+				}
+			}
+		}
+	}
+	if len(pool) < opt.Count {
+		return nil, fmt.Errorf("only %d candidate colors fit the lightness and chroma ranges in sRGB", len(pool))
+	}
+
+	// distance is the smallest difference between a and b over the visions
+	distance := func(a, b candidate) float64 {
+		d := math.Inf(1)
+		for i := range visions {
+			d = math.Min(d, DeltaE2000(a.labs[i], b.labs[i]))
+		}
+		return d
+	}
+	// nearest is a's smallest distance to the chosen colours, skipping one
+	nearest := func(a candidate, chosen []candidate, skip int) float64 {
+		d := math.Inf(1)
+		for i, c := range chosen {
+			if i != skip {
+				d = math.Min(d, distance(a, c))
+			}
+		}
+		return d
+	}
+
+	chosen := make([]candidate, 0, opt.Count)
+	fixed := 0
+	if opt.Base != nil {
+		chosen = append(chosen, newCandidate(opt.Base.MapToSRGB().To(SpaceOKLCH)))
+		fixed = 1
+	} else {
+		first := 0
+		for i, c := range pool {
+			if c.color.V[1] > pool[first].color.V[1] {
+				first = i
+			}
+		}
+		chosen = append(chosen, pool[first])
+	}
+	for len(chosen) < opt.Count {
+		best, bestD := 0, -1.0
+		for i, c := range pool {
+			if d := nearest(c, chosen, -1); d > bestD {
+				best, bestD = i, d
+			}
+		}
+		chosen = append(chosen, pool[best])
+	}
+
+	// swap colours for candidates while the smallest difference grows
+	score := func() float64 {
+		d := math.Inf(1)
+		for i := range chosen {
+			d = math.Min(d, nearest(chosen[i], chosen[i+1:], -1))
+		}
+		return d
+	}
+	current := score()
+	for pass := 0; pass < passes; pass++ {
+		improved := false
+		for k := fixed; k < len(chosen); k++ {
+			for _, c := range pool {
+				if nearest(c, chosen, k) <= current {
+					continue
+				}
+				old := chosen[k]
+				chosen[k] = c
+				if s := score(); s > current {
+					current, improved = s, true
+				} else {
+					chosen[k] = old
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	out := make([]Color, len(chosen))
+	for i, c := range chosen {
+		out[i] = c.color
+	}
+	return out, nil
+}
+
+// SequentialPalette runs Count colours from light to dark at the base
+// hue (default blue): lightness falls evenly across the range, and
+// chroma rises from the low end of its range to a peak past the middle.
+// Colours outside sRGB lose chroma only, keeping lightness and hue.
+func SequentialPalette(opt DatavizOptions) ([]Color, error) {
+	hue, peak := 250.0, 0.0
+	if opt.Base != nil {
+		b := opt.Base.MapToSRGB().To(SpaceOKLCH)
+		hue, peak = b.V[2], b.V[1]
+	}
+	opt, err := opt.withDefaults(sequentialLightness, sequentialChroma)
+	if err != nil {
+		return nil, err
+	}
+	if peak > 0 {
+		peak = math.Max(opt.Chroma[0], math.Min(opt.Chroma[1], peak))
+	} else {
+		peak = opt.Chroma[1]
+	}
+
+	out := make([]Color, opt.Count)
+	for i := range out {
+		t := 0.5
+		if opt.Count > 1 {
+			t = float64(i) / float64(opt.Count-1)
+		}
+		l := opt.Lightness[1] - (opt.Lightness[1]-opt.Lightness[0])*t
+		c := opt.Chroma[0] + (peak-opt.Chroma[0])*math.Sin(math.Pi*math.Min(1, t/1.2))
+		out[i] = fitChroma(l, c, hue)
+	}
+	return out, nil
+}
+
+// DivergingPalette runs Count colours from a dark low end at Base's hue
+// through a light, near-neutral middle to a dark high end at Opposite's
+// hue. Lightness changes evenly on each side and is symmetric about the
+// middle, which an odd Count includes. Colours outside sRGB lose chroma
+// only, keeping lightness and hue.
+func DivergingPalette(opt DatavizOptions) ([]Color, error) {
+	low, high := 250.0, 30.0
+	if opt.Base != nil {
+		low = opt.Base.MapToSRGB().To(SpaceOKLCH).V[2]
+		high = normalizeHue(low + 180)
+	}
+	if opt.Opposite != nil {
+		high = opt.Opposite.MapToSRGB().To(SpaceOKLCH).V[2]
+	}
+	opt, err := opt.withDefaults(sequentialLightness, sequentialChroma)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Count < 2 {
+		return nil, fmt.Errorf("a diverging palette needs at least 2 colors, got %d", opt.Count)
+	}
+
+	out := make([]Color, opt.Count)
+	for i := range out {
+		u := 2*float64(i)/float64(opt.Count-1) - 1 // -1 low end, 0 middle, 1 high end
+		hue := low
+		if u > 0 {
+			hue = high
+		}
+		d := math.Abs(u)
+		l := opt.Lightness[1] - (opt.Lightness[1]-opt.Lightness[0])*d
+		c := opt.Chroma[0] + (opt.Chroma[1]-opt.Chroma[0])*math.Sin(math.Pi/2*d)
+		out[i] = fitChroma(l, c, hue)
+	}
+	return out, nil
+}
+
+// fitChroma returns the OKLCH colour at lightness l and hue h with the
+// most chroma up to c that fits sRGB. Unlike MapToSRGB it only lowers
+// chroma, so lightness and hue stay as asked.
+func fitChroma(l, c, h float64) Color {
+	fits := func(c float64) bool { return NewColor(SpaceOKLCH, l, c, h).To(SpaceSRGB).InGamut() }
+	if !fits(c) {
+		lo, hi := 0.0, c
+		for i := 0; i < 30; i++ {
+			mid := (lo + hi) / 2
+			if fits(mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		c = lo
+	}
+	return NewColor(SpaceOKLCH, l, c, h).To(SpaceSRGB).Clip().To(SpaceOKLCH)
+}
@@ -0,0 +1,136 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// separation is the smallest MinSeparation over normal vision and the
+// deficiencies, the score CategoricalPalette maximizes.
+func separation(cs []Color, cvds []CVD) float64 {
+	d := MinSeparation(cs, CVDNone)
+	for _, v := range cvds {
+		d = math.Min(d, MinSeparation(cs, v))
+	}
+	return d
+}
+
+func TestCategoricalPalette(t *testing.T) {
+	base := MustParse("#1F77B4")
+	for _, opt := range []DatavizOptions{
+		{Count: 6},
+		{Count: 8, CVD: []CVD{CVDDeutan}},
+		{Count: 5, CVD: []CVD{CVDProtan, CVDDeutan, CVDTritan}},
+		{Count: 6, Base: &base},
+	} {
+		got, err := CategoricalPalette(opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != opt.Count {
+			t.Fatalf("%+v: %d colors", opt, len(got))
+		}
+		greedy, _ := categoricalPalette(opt, 0)
+		if s, g := separation(got, opt.CVD), separation(greedy, opt.CVD); s < g {
+			t.Errorf("%+v: separation %.3f is below the greedy seed's %.3f", opt, s, g)
+		}
+		for i, c := range got {
+			if !c.To(SpaceSRGB).InGamut() {
+				t.Errorf("%+v: color %d %s is out of sRGB", opt, i, c)
+			}
+			if i == 0 && opt.Base != nil {
+				if d := DeltaEOK(c, base); d > 1e-6 {
+					t.Errorf("%+v: first color %s is not the base", opt, c.Hex())
+				}
+				continue
+			}
+			l, ch := c.V[0], c.V[1]
+			if l < categoricalLightness[0]-1e-9 || l > categoricalLightness[1]+1e-9 ||
+				ch < categoricalChroma[0]-1e-9 || ch > categoricalChroma[1]+1e-9 {
+				t.Errorf("%+v: color %d %s is outside the ranges", opt, i, c)
+			}
+		}
+		again, _ := CategoricalPalette(opt)
+		for i := range got {
+			if got[i] != again[i] {
+				t.Fatalf("%+v: not deterministic", opt)
+			}
+		}
+	}
+}
+
+// Sequential lightness falls evenly from the light end to the dark end.
+func TestSequentialPalette(t *testing.T) {
+	base := MustParse("#2E7D32")
+	for _, opt := range []DatavizOptions{{Count: 9}, {Count: 5, Base: &base}, {Count: 7, Lightness: [2]float64{0.4, 0.9}}} {
+		got, err := SequentialPalette(opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lr := opt.Lightness
+		if lr == [2]float64{} {
+			lr = sequentialLightness
+		}
+		step := (lr[1] - lr[0]) / float64(opt.Count-1)
+		for i, c := range got {
+			want := lr[1] - step*float64(i)
+			if math.Abs(c.V[0]-want) > 1e-5 {
+				t.Errorf("%+v: step %d has L %.4f, want %.4f", opt, i, c.V[0], want)
+			}
+			if i > 0 && c.V[0] >= got[i-1].V[0] {
+				t.Errorf("%+v: lightness rises at step %d", opt, i)
+			}
+		}
+	}
+}
+
+// Diverging lightness is symmetric about the middle and falls evenly
+// towards both ends.
+func TestDivergingPalette(t *testing.T) {
+	base, opposite := MustParse("#2166AC"), MustParse("#B2182B")
+	for _, opt := range []DatavizOptions{{Count: 9}, {Count: 8}, {Count: 11, Base: &base, Opposite: &opposite}, {Count: 2}} {
+		got, err := DivergingPalette(opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := len(got)
+		for i := 0; i < n/2; i++ {
+			lo, hi := got[i].V[0], got[n-1-i].V[0]
+			if math.Abs(lo-hi) > 1e-5 {
+				t.Errorf("count %d: L %.4f at %d but %.4f at %d", n, lo, i, hi, n-1-i)
+			}
+			if i > 0 && lo <= got[i-1].V[0] {
+				t.Errorf("count %d: lightness does not rise towards the middle at %d", n, i)
+			}
+		}
+		if n%2 == 1 {
+			if mid := got[n/2].V[0]; math.Abs(mid-sequentialLightness[1]) > 1e-5 {
+				t.Errorf("count %d: middle L %.4f, want %.2f", n, mid, sequentialLightness[1])
+			}
+		}
+		if opt.Base != nil {
+			if d := hueDiff(got[0].V[2], base.To(SpaceOKLCH).V[2]); d > 0.01 {
+				t.Errorf("low end hue is %.1f° off the base", d)
+			}
+			if d := hueDiff(got[n-1].V[2], opposite.To(SpaceOKLCH).V[2]); d > 0.01 {
+				t.Errorf("high end hue is %.1f° off the opposite", d)
+			}
+		}
+	}
+	if _, err := DivergingPalette(DatavizOptions{Count: 1}); err == nil {
+		t.Error("count 1: want an error")
+	}
+}
+
+func TestDatavizOptionErrors(t *testing.T) {
+	for _, opt := range []DatavizOptions{
+		{Count: 0},
+		{Count: 3, Lightness: [2]float64{0.9, 0.2}},
+		{Count: 3, Lightness: [2]float64{0.2, 1.2}},
+		{Count: 3, Chroma: [2]float64{-0.1, 0.1}},
+	} {
+		if _, err := SequentialPalette(opt); err == nil {
+			t.Errorf("%+v: want an error", opt)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package colors
+
+import "math"
+
+// -------------------------------
+// ΔE*ab (CIE 1976)
+// -------------------------------
+func DeltaE76(a, b Lab) float64 {
+	dL, dA, dB := a.L-b.L, a.A-b.A, a.B-b.B
+	return math.Sqrt(dL*dL + dA*dA + dB*dB)
+}
+
+// -------------------------------
+// ΔEOK (Euclidean distance in Oklab)
+// -------------------------------
+func DeltaEOK(a, b Color) float64 {
+	p, q := a.To(SpaceOklab).V, b.To(SpaceOklab).V
+	dL, dA, dB := p[0]-q[0], p[1]-q[1], p[2]-q[2]
+	return math.Sqrt(dL*dL + dA*dA + dB*dB)
+}
+
+// -------------------------------
+// ΔE00 (CIEDE2000)
+// -------------------------------
+func DeltaE2000(a, b Lab) float64 {
+	const kL, kC, kH = 1.0, 1.0, 1.0
+	rad := math.Pi / 180
+
+	C1 := math.Hypot(a.A, a.B)
+	C2 := math.Hypot(b.A, b.B)
+	Cbar := (C1 + C2) / 2
+	Cbar7 := math.Pow(Cbar, 7)
+	G := 0.5 * (1 - math.Sqrt(Cbar7/(Cbar7+math.Pow(25, 7))))
+
+	a1 := (1 + G) * a.A
+	a2 := (1 + G) * b.A
+	C1p := math.Hypot(a1, a.B)
+	C2p := math.Hypot(a2, b.B)
+
+	hue := func(x, y float64) float64 {
+		if x == 0 && y == 0 {
+			return 0
+		}
+		h := math.Atan2(y, x) / rad
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+	h1p := hue(a1, a.B)
+	h2p := hue(a2, b.B)
+
+	// Hues exactly opposite each other land on the 180° boundary, where
+	// atan2 rounding would otherwise pick the branch at random.
+	const hueEps = 1e-9
+	within180 := math.Abs(h2p-h1p) <= 180+hueEps
+
+	dLp := b.L - a.L
+	dCp := C2p - C1p
+
+	var dhp float64
+	switch {
+	case C1p*C2p == 0:
+		dhp = 0
+	case within180:
+		dhp = h2p - h1p
+	case h2p-h1p > 180:
+		dhp = h2p - h1p - 360
+	default:
+		dhp = h2p - h1p + 360
+	}
+	dHp := 2 * math.Sqrt(C1p*C2p) * math.Sin(dhp/2*rad)
+
+	Lbarp := (a.L + b.L) / 2
+	Cbarp := (C1p + C2p) / 2
+
+	var hbarp float64
+	switch {
+	case C1p*C2p == 0:
+		hbarp = h1p + h2p
+	case within180:
+		hbarp = (h1p + h2p) / 2
+	case h1p+h2p < 360:
+		hbarp = (h1p + h2p + 360) / 2
+	default:
+		hbarp = (h1p + h2p - 360) / 2
+	}
+
+	T := 1 - 0.17*math.Cos((hbarp-30)*rad) +
+		0.24*math.Cos(2*hbarp*rad) +
+		0.32*math.Cos((3*hbarp+6)*rad) -
+		0.20*math.Cos((4*hbarp-63)*rad)
+
+	dTheta := 30 * math.Exp(-math.Pow((hbarp-275)/25, 2))
+	Cbarp7 := math.Pow(Cbarp, 7)
+	RC := 2 * math.Sqrt(Cbarp7/(Cbarp7+math.Pow(25, 7)))
+	L50 := (Lbarp - 50) * (Lbarp - 50)
+	SL := 1 + 0.015*L50/math.Sqrt(20+L50)
+	SC := 1 + 0.045*Cbarp
+	SH := 1 + 0.015*Cbarp*T
+	RT := -math.Sin(2*dTheta*rad) * RC
+
+	l := dLp / (kL * SL)
+	c := dCp / (kC * SC)
+	h := dHp / (kH * SH)
+	return math.Sqrt(l*l + c*c + h*h + RT*c*h)
+}
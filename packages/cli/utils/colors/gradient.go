@@ -0,0 +1,195 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// Easing (CSS cubic-bezier)
+// -------------------------------
+
+// Easing is a CSS cubic-bezier() timing function from (0,0) to (1,1).
+type Easing struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// The CSS named timing functions.
+var (
+	EaseLinear    = Easing{0, 0, 1, 1}
+	Ease          = Easing{0.25, 0.1, 0.25, 1}
+	EaseIn        = Easing{0.42, 0, 1, 1}
+	EaseOut       = Easing{0, 0, 0.58, 1}
+	EaseInOut     = Easing{0.42, 0, 0.58, 1}
+	easingsByName = map[string]Easing{
+		"linear":      EaseLinear,
+		"ease":        Ease,
+		"ease-in":     EaseIn,
+		"ease-out":    EaseOut,
+		"ease-in-out": EaseInOut,
+	}
+)
+
+// ParseEasing accepts linear, ease, ease-in, ease-out, ease-in-out and
+// cubic-bezier(x1, y1, x2, y2) with x1 and x2 in 0–1.
+func ParseEasing(s string) (Easing, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if e, ok := easingsByName[s]; ok {
+		return e, nil
+	}
+	if body, ok := strings.CutPrefix(s, "cubic-bezier("); ok && strings.HasSuffix(body, ")") {
+		parts := strings.FieldsFunc(strings.TrimSuffix(body, ")"), func(r rune) bool { return r == ',' || r == ' ' })
+		if len(parts) == 4 {
+			var v [4]float64
+			var err error
+			for i, p := range parts {
+				if v[i], err = strconv.ParseFloat(p, 64); err != nil {
+					break
+				}
+			}
+			if err == nil && v[0] >= 0 && v[0] <= 1 && v[2] >= 0 && v[2] <= 1 {
+				return Easing{v[0], v[1], v[2], v[3]}, nil
+			}
+		}
+	}
+	return Easing{}, fmt.Errorf("unknown easing %q (linear, ease, ease-in, ease-out, ease-in-out, cubic-bezier(x1, y1, x2, y2))", s)
+}
+
+func (e Easing) String() string {
+	for _, name := range []string{"linear", "ease", "ease-in", "ease-out", "ease-in-out"} {
+		if easingsByName[name] == e {
+			return name
+		}
+	}
+	return fmt.Sprintf("cubic-bezier(%s, %s, %s, %s)", num(e.X1, 4), num(e.Y1, 4), num(e.X2, 4), num(e.Y2, 4))
+}
+
+// Linear reports whether the easing leaves progress unchanged.
+func (e Easing) Linear() bool {
+	return e.X1 == e.Y1 && e.X2 == e.Y2
+}
+
+// Ease maps progress x (0–1) to eased progress.
+func (e Easing) Ease(x float64) float64 {
+	if e.Linear() || x <= 0 || x >= 1 {
+		return x
+	}
+	bezier := func(p1, p2, t float64) float64 {
+		u := 1 - t
+		return 3*u*u*t*p1 + 3*u*t*t*p2 + t*t*t
+	}
+	// solve x(t) = x by bisection; x(t) is monotonic for x1, x2 in 0–1
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 40; i++ {
+		mid := (lo + hi) / 2
+		if bezier(e.X1, e.X2, mid) < x {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return bezier(e.Y1, e.Y2, (lo+hi)/2)
+}
+
+// -------------------------------
+// Multi-stop gradients
+// -------------------------------
+
+// GradientStop is a colour at a position along a gradient.
+type GradientStop struct {
+	Color    Color
+	Position float64 // 0–1, or NaN to place it as CSS does
+}
+
+// GradientOptions configures Gradient.
+type GradientOptions struct {
+	Space   Space     // interpolation space
+	Hue     HueMethod // hue interpolation in polar spaces
+	Easings []Easing  // one per segment, or one for all (default linear)
+}
+
+// ResolveStops fills in missing positions as CSS gradients do: the
+// first and last stops default to 0 and 1, a position smaller than an
+// earlier one is raised to it, and runs of unplaced stops are spread
+// evenly between their placed neighbours.
+func ResolveStops(stops []GradientStop) []GradientStop {
+	out := append([]GradientStop(nil), stops...)
+	if len(out) == 0 {
+		return out
+	}
+	if math.IsNaN(out[0].Position) {
+		out[0].Position = 0
+	}
+	if last := len(out) - 1; math.IsNaN(out[last].Position) {
+		out[last].Position = math.Max(1, out[0].Position)
+	}
+	highest := out[0].Position
+	for i := range out {
+		if !math.IsNaN(out[i].Position) {
+			out[i].Position = math.Max(out[i].Position, highest)
+			highest = out[i].Position
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		if !math.IsNaN(out[i].Position) {
+			continue
+		}
+		j := i
+		for math.IsNaN(out[j].Position) {
+			j++
+		}
+		from, to := out[i-1].Position, out[j].Position
+		for k := i; k < j; k++ {
+			out[k].Position = from + (to-from)*float64(k-i+1)/float64(j-i+1)
+		}
+		i = j
+	}
+	return out
+}
+
+// Gradient samples n evenly spaced colours from the first stop's
+// position to the last's. Each segment is interpolated with Mix in
+// opt.Space after its easing; at a hard stop (two stops at one
+// position) the later colour wins. Results are in opt.Space and not
+// gamut mapped.
+func Gradient(stops []GradientStop, n int, opt GradientOptions) ([]Color, error) {
+	if len(stops) < 2 {
+		return nil, fmt.Errorf("a gradient needs at least 2 colors, got %d", len(stops))
+	}
+	if n < 2 {
+		return nil, fmt.Errorf("steps must be at least 2, got %d", n)
+	}
+	segments := len(stops) - 1
+	if len(opt.Easings) > 1 && len(opt.Easings) != segments {
+		return nil, fmt.Errorf("got %d easings for %d segments", len(opt.Easings), segments)
+	}
+	easing := func(i int) Easing {
+		switch len(opt.Easings) {
+		case 0:
+			return EaseLinear
+		case 1:
+			return opt.Easings[0]
+		}
+		return opt.Easings[i]
+	}
+
+	stops = ResolveStops(stops)
+	start, end := stops[0].Position, stops[segments].Position
+	out := make([]Color, n)
+	for i := range out {
+		p := start + (end-start)*float64(i)/float64(n-1)
+		seg := 0
+		for seg < segments-1 && p >= stops[seg+1].Position {
+			seg++
+		}
+		a, b := stops[seg], stops[seg+1]
+		t := 1.0
+		if width := b.Position - a.Position; width > 0 {
+			t = math.Max(0, math.Min(1, (p-a.Position)/width))
+		}
+		out[i] = Mix(a.Color, b.Color, easing(seg).Ease(t), opt.Space, opt.Hue)
+	}
+	return out, nil
+}
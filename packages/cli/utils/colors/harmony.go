@@ -0,0 +1,219 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// -------------------------------
+// Harmonies
+// -------------------------------
+type Harmony int
+
+const (
+	HarmonyComplementary Harmony = iota
+	HarmonyAnalogous
+	HarmonyTriadic
+	HarmonySplitComplementary
+	HarmonyTetradic
+	HarmonySquare
+	HarmonyMonochromatic
+	HarmonyCompound
+	HarmonyCustom
+)
+
+var harmonyNames = []string{
+	"complementary", "analogous", "triadic", "split-complementary",
+	"tetradic", "square", "monochromatic", "compound", "custom",
+}
+
+func (h Harmony) String() string {
+	if h < HarmonyComplementary || h > HarmonyCustom {
+		return "unknown"
+	}
+	return harmonyNames[h]
+}
+
+// ParseHarmony accepts the names above, plus split and rectangle.
+func ParseHarmony(s string) (Harmony, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "split":
+		return HarmonySplitComplementary, nil
+	case "rectangle":
+		return HarmonyTetradic, nil
+	}
+	for i, name := range harmonyNames {
+		if s == name {
+			return Harmony(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown harmony %q (%s)", s, strings.Join(harmonyNames, ", "))
+}
+
+// HarmonyOptions configures Harmonize.
+type HarmonyOptions struct {
+	Space  Space     // space the hue is rotated in: OKLCH, HCL or HSL
+	Count  int       // number of colours; 0 = the harmony's own size
+	Angle  float64   // spread in degrees for analogous, split, tetradic and compound; 0 = default
+	Angles []float64 // hue offsets from the base for HarmonyCustom
+}
+
+// default spread per harmony
+var harmonyAngles = map[Harmony]float64{
+	HarmonyAnalogous:          30,
+	HarmonySplitComplementary: 30,
+	HarmonyTetradic:           60,
+	HarmonyCompound:           30,
+}
+
+// HueOffsets returns the harmony's hue offsets from the base colour, in
+// degrees; the first is always 0. Monochromatic has the single offset 0.
+func (h Harmony) HueOffsets(angle float64, custom []float64) ([]float64, error) {
+	if angle == 0 {
+		angle = harmonyAngles[h]
+	}
+	switch h {
+	case HarmonyComplementary:
+		return []float64{0, 180}, nil
+	case HarmonyAnalogous:
+		return []float64{0, -angle, angle}, nil
+	case HarmonyTriadic:
+		return []float64{0, 120, 240}, nil
+	case HarmonySplitComplementary:
+		return []float64{0, 180 - angle, 180 + angle}, nil
+	case HarmonyTetradic:
+		return []float64{0, angle, 180, 180 + angle}, nil
+	case HarmonySquare:
+		return []float64{0, 90, 180, 270}, nil
+	case HarmonyMonochromatic:
+		return []float64{0}, nil
+	case HarmonyCompound:
+		return []float64{0, angle, 180, 180 - angle}, nil
+	case HarmonyCustom:
+		if len(custom) == 0 {
+			return nil, fmt.Errorf("custom harmony needs hue offsets")
+		}
+		return append([]float64{0}, custom...), nil
+	}
+	return nil, fmt.Errorf("unknown harmony %d", int(h))
+}
+
+// Harmonize builds a palette around base by rotating its hue in
+// opt.Space, keeping lightness and chroma (saturation in HSL) so that in
+// OKLCH and HCL every colour has the same perceived lightness. The base
+// comes first.
+//
+// With a Count above the harmony's size, analogous spreads further
+// around the wheel, monochromatic steps through lightness, and the
+// others add lighter and darker variants of their hues in turn. Colours
+// are mapped into sRGB with MapToSRGB and returned in opt.Space. A
+// space without a hue channel is an error.
+func Harmonize(base Color, h Harmony, opt HarmonyOptions) ([]Color, error) {
+	space := opt.Space
+	hue := space.HueChannel()
+	light, lmax := lightnessChannel(space)
+	if hue < 0 || light < 0 {
+		return nil, fmt.Errorf("cannot rotate hue in %s (oklch, hcl, hsl)", space)
+	}
+
+	offsets, err := h.HueOffsets(opt.Angle, opt.Angles)
+	if err != nil {
+		return nil, err
+	}
+	count := opt.Count
+	if count <= 0 {
+		count = len(offsets)
+		if h == HarmonyMonochromatic {
+			count = 5
+		}
+	}
+
+	c := base.To(space)
+	out := make([]Color, 0, count)
+	add := func(dh, l float64) {
+		v := c
+		v.V[hue] = normalizeHue(v.V[hue] + dh)
+		v.V[light] = l
+		out = append(out, v.MapToSRGB().To(space))
+	}
+
+	switch h {
+	case HarmonyMonochromatic:
+		for _, l := range lightnessSteps(c.V[light]/lmax, count) {
+			add(0, l*lmax)
+		}
+	case HarmonyAnalogous:
+		step := opt.Angle
+		if step == 0 {
+			step = harmonyAngles[h]
+		}
+		// 0, -a, +a, -2a, +2a, …
+		for i := 0; i < count; i++ {
+			k := float64((i + 1) / 2)
+			if i%2 == 1 {
+				k = -k
+			}
+			add(k*step, c.V[light])
+		}
+	default:
+		for i := 0; i < count; i++ {
+			n := len(offsets)
+			l := c.V[light]
+			if i >= n {
+				// lighter then darker variants, further each round
+				round := (i - n) / n
+				d := 0.12 * float64(round/2+1)
+				if round%2 == 1 {
+					d = -d
+				}
+				l = math.Max(0.05, math.Min(0.98, l/lmax+d)) * lmax
+			}
+			add(offsets[i%n], l)
+		}
+	}
+	return out, nil
+}
+
+// lightnessChannel returns the index and full-scale value of the
+// lightness channel of a hue-based space.
+func lightnessChannel(s Space) (int, float64) {
+	switch s {
+	case SpaceOKLCH:
+		return 0, 1
+	case SpaceLCH:
+		return 0, 100
+	case SpaceHSL, SpaceHCL:
+		return 2, 100
+	}
+	return -1, 0
+}
+
+// lightnessSteps spreads n lightness values (0–1) evenly from dark to
+// light, moving the nearest one onto the base lightness.
+func lightnessSteps(base float64, n int) []float64 {
+	const lo, hi = 0.2, 0.95
+	steps := make([]float64, n)
+	if n == 1 {
+		steps[0] = base
+		return steps
+	}
+	nearest := 0
+	for i := range steps {
+		steps[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+		if math.Abs(steps[i]-base) < math.Abs(steps[nearest]-base) {
+			nearest = i
+		}
+	}
+	steps[nearest] = base
+	return steps
+}
+
+func normalizeHue(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
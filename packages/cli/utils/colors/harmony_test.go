@@ -0,0 +1,117 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func hueDiff(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	return math.Min(d, 360-d)
+}
+
+func TestHarmonize(t *testing.T) {
+	// low-chroma bases stay inside sRGB at every hue, so gamut mapping
+	// never moves them
+	bases := map[Space]Color{
+		SpaceOKLCH: NewColor(SpaceOKLCH, 0.7, 0.05, 30),
+		SpaceHCL:   NewColor(SpaceHCL, 30, 20, 60),
+		SpaceHSL:   NewColor(SpaceHSL, 30, 50, 50),
+	}
+	for _, tc := range []struct {
+		h       Harmony
+		opt     HarmonyOptions
+		offsets []float64
+		shift   []float64 // lightness change of variants past the harmony's size, 0–1
+	}{
+		{HarmonyComplementary, HarmonyOptions{}, []float64{0, 180}, nil},
+		{HarmonyAnalogous, HarmonyOptions{}, []float64{0, -30, 30}, nil},
+		{HarmonyAnalogous, HarmonyOptions{Count: 5, Angle: 20}, []float64{0, -20, 20, -40, 40}, nil},
+		{HarmonyTriadic, HarmonyOptions{}, []float64{0, 120, 240}, nil},
+		{HarmonyTriadic, HarmonyOptions{Count: 9}, []float64{0, 120, 240, 0, 120, 240, 0, 120, 240},
+			[]float64{0, 0, 0, 0.12, 0.12, 0.12, -0.12, -0.12, -0.12}},
+		{HarmonySplitComplementary, HarmonyOptions{}, []float64{0, 150, 210}, nil},
+		{HarmonySplitComplementary, HarmonyOptions{Angle: 20}, []float64{0, 160, 200}, nil},
+		{HarmonyTetradic, HarmonyOptions{}, []float64{0, 60, 180, 240}, nil},
+		{HarmonySquare, HarmonyOptions{}, []float64{0, 90, 180, 270}, nil},
+		{HarmonySquare, HarmonyOptions{Count: 6}, []float64{0, 90, 180, 270, 0, 90},
+			[]float64{0, 0, 0, 0, 0.12, 0.12}},
+		{HarmonyCompound, HarmonyOptions{}, []float64{0, 30, 180, 150}, nil},
+		{HarmonyCustom, HarmonyOptions{Angles: []float64{45, -45}}, []float64{0, 45, -45}, nil},
+	} {
+		for space, base := range bases {
+			opt := tc.opt
+			opt.Space = space
+			got, err := Harmonize(base, tc.h, opt)
+			if err != nil {
+				t.Fatalf("%v in %v: %v", tc.h, space, err)
+			}
+			if len(got) != len(tc.offsets) {
+				t.Fatalf("%v in %v: %d colours, want %d", tc.h, space, len(got), len(tc.offsets))
+			}
+
+			hue := space.HueChannel()
+			light, lmax := lightnessChannel(space)
+			for i, c := range got {
+				if c.Space != space {
+					t.Errorf("%v in %v: colour %d is in %v", tc.h, space, i, c.Space)
+				}
+				if d := hueDiff(c.V[hue], base.V[hue]+tc.offsets[i]); d > 0.01 {
+					t.Errorf("%v in %v: colour %d hue %.3f, want offset %v", tc.h, space, i, c.V[hue], tc.offsets[i])
+				}
+				want := base.V[light]
+				if tc.shift != nil {
+					want += tc.shift[i] * lmax
+				}
+				if math.Abs(c.V[light]-want) > 1e-3*lmax {
+					t.Errorf("%v in %v: colour %d lightness %.4f, want %.4f", tc.h, space, i, c.V[light], want)
+				}
+			}
+		}
+	}
+}
+
+func TestHarmonizeMonochromatic(t *testing.T) {
+	base := NewColor(SpaceOKLCH, 0.6, 0.02, 250)
+	for _, count := range []int{0, 3, 7} {
+		got, err := Harmonize(base, HarmonyMonochromatic, HarmonyOptions{Space: SpaceOKLCH, Count: count})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := count
+		if want == 0 {
+			want = 5
+		}
+		if len(got) != want {
+			t.Fatalf("count %d: %d colours", count, len(got))
+		}
+		hasBase := false
+		for i, c := range got {
+			if hueDiff(c.V[2], 250) > 0.01 {
+				t.Errorf("count %d: colour %d hue %.2f", count, i, c.V[2])
+			}
+			if i > 0 && c.V[0] <= got[i-1].V[0] {
+				t.Errorf("count %d: lightness not increasing at %d", count, i)
+			}
+			hasBase = hasBase || math.Abs(c.V[0]-0.6) < 1e-6
+		}
+		if !hasBase {
+			t.Errorf("count %d: base lightness missing", count)
+		}
+	}
+}
+
+func TestHarmonizeErrors(t *testing.T) {
+	base := MustParse("#3366cc")
+	for _, space := range []Space{SpaceSRGB, SpaceLab, SpaceOklab, SpaceXYZD65, SpaceCMYK, SpaceHWB} {
+		if _, err := Harmonize(base, HarmonyTriadic, HarmonyOptions{Space: space}); err == nil {
+			t.Errorf("%v: expected an error for a space without hue and lightness channels", space)
+		}
+	}
+	if _, err := Harmonize(base, HarmonyCustom, HarmonyOptions{Space: SpaceOKLCH}); err == nil {
+		t.Error("custom without angles: expected an error")
+	}
+	if _, err := Harmonize(base, Harmony(99), HarmonyOptions{Space: SpaceOKLCH}); err == nil {
+		t.Error("unknown harmony: expected an error")
+	}
+}
@@ -24,7 +24,7 @@ func (c HCL) IsValid() bool {
 }
 
 // -------------------------------
-// HCL → Lab
+// HCL → Lab (both D65)
 // -------------------------------
 func (c HCL) ToLab() (l, a, b float64) {
 	hRad := c.H * (math.Pi / 180)
@@ -69,28 +69,6 @@ func xyzToLinearRGB(X, Y, Z float64) (R, G, B float64) {
 	return
 }
 
-// -------------------------------
-// Linear RGB → sRGB 0–255
-// -------------------------------
-func linearToRGB(R, G, B float64) (r, g, b int) {
-	toSRGB := func(u float64) float64 {
-		if u <= 0 {
-			return 0
-		}
-		if u >= 1 {
-			return 1
-		}
-		if u <= 0.0031308 {
-			return 12.92 * u
-		}
-		return 1.055*math.Pow(u, 1/2.4) - 0.055
-	}
-	r = int(math.Round(toSRGB(R) * 255))
-	g = int(math.Round(toSRGB(G) * 255))
-	b = int(math.Round(toSRGB(B) * 255))
-	return
-}
-
 // -------------------------------
 // HCL → RGB
 // -------------------------------
@@ -101,7 +79,7 @@ func (c HCL) ToRGB() (int, int, int, error) {
 	l, a, b := c.ToLab()
 	X, Y, Z := labToXYZ(l, a, b)
 	R, G, B := xyzToLinearRGB(X, Y, Z)
-	r, g, bInt := linearToRGB(R, G, B)
+	r, g, bInt := linearToRGB8(R, G, B)
 	return r, g, bInt, nil
 }
 
@@ -5,7 +5,6 @@ import (
 	"math"
 	"math/rand/v2"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
@@ -14,27 +13,41 @@ type Hex string
 // ToRGB converts the hex color to RGB
 func (h Hex) ToRGB() (r, g, b int, err error) {
 	hex := strings.TrimPrefix(string(h), "#")
-	if len(hex) == 3 {
-		hex = fmt.Sprintf("%c%c%c%c%c%c", hex[0], hex[0], hex[1], hex[1], hex[2], hex[2])
-	}
-	if len(hex) != 6 {
+	var v [6]int
+	switch len(hex) {
+	case 3:
+		for i := 0; i < 3; i++ {
+			n, ok := hexDigit(hex[i])
+			if !ok {
+				return 0, 0, 0, fmt.Errorf("invalid hex digit %q", hex[i])
+			}
+			v[2*i], v[2*i+1] = n, n
+		}
+	case 6:
+		for i := 0; i < 6; i++ {
+			n, ok := hexDigit(hex[i])
+			if !ok {
+				return 0, 0, 0, fmt.Errorf("invalid hex digit %q", hex[i])
+			}
+			v[i] = n
+		}
+	default:
 		return 0, 0, 0, fmt.Errorf("invalid hex length")
 	}
+	return v[0]<<4 | v[1], v[2]<<4 | v[3], v[4]<<4 | v[5], nil
+}
 
-	r64, err := strconv.ParseInt(hex[0:2], 16, 0)
-	if err != nil {
-		return 0, 0, 0, err
-	}
-	g64, err := strconv.ParseInt(hex[2:4], 16, 0)
-	if err != nil {
-		return 0, 0, 0, err
-	}
-	b64, err := strconv.ParseInt(hex[4:6], 16, 0)
-	if err != nil {
-		return 0, 0, 0, err
-	}
-
-	return int(r64), int(g64), int(b64), nil
+// hexDigit decodes one hexadecimal digit without allocating.
+func hexDigit(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'a' && c <= 'f':
+		return int(c-'a') + 10, true
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10, true
+	}
+	return 0, false
 }
 
 // ToHSL converts a Hex color to HSL values
@@ -89,17 +102,7 @@ func (h Hex) ToOKLCH() (L, C, H float64, err error) {
 	}
 
 	// sRGB → linear RGB
-	toLinear := func(c float64) float64 {
-		c = c / 255
-		if c <= 0.04045 {
-			return c / 12.92
-		}
-		return math.Pow((c+0.055)/1.055, 2.4)
-	}
-
-	r := toLinear(float64(rInt))
-	g := toLinear(float64(gInt))
-	b := toLinear(float64(bInt))
+	r, g, b := rgb8ToLinear(rInt, gInt, bInt)
 
 	// Linear RGB → LMS (Oklab)
 	l_ := 0.4122214708*r + 0.5363325363*g + 0.0514459929*b
@@ -128,21 +131,8 @@ func (h Hex) ToHCL() (H, C, L float64, err error) {
 		return 0, 0, 0, err
 	}
 
-	// Convert RGB to [0,1]
-	R := float64(rInt) / 255
-	G := float64(gInt) / 255
-	B := float64(bInt) / 255
-
 	// sRGB → Linear RGB
-	toLinear := func(u float64) float64 {
-		if u <= 0.04045 {
-			return u / 12.92
-		}
-		return math.Pow((u+0.055)/1.055, 2.4)
-	}
-	R = toLinear(R)
-	G = toLinear(G)
-	B = toLinear(B)
+	R, G, B := rgb8ToLinear(rInt, gInt, bInt)
 
 	// Linear RGB → XYZ
 	X := 0.4124564*R + 0.3575761*G + 0.1804375*B
@@ -99,17 +99,7 @@ func HSLToOKLCH(h, s, l float64) (L_ok, C_ok, H_ok float64) {
 	r, g, b := HSLToRGB(h, s, l)
 
 	// --- Step 2: sRGB → linear RGB
-	toLinear := func(c float64) float64 {
-		c = c / 255
-		if c <= 0.04045 {
-			return c / 12.92
-		}
-		return math.Pow((c+0.055)/1.055, 2.4)
-	}
-
-	R := toLinear(float64(r))
-	G := toLinear(float64(g))
-	B := toLinear(float64(b))
+	R, G, B := rgb8ToLinear(r, g, b)
 
 	// --- Step 3: linear RGB → LMS (Oklab)
 	l_ := 0.4122214708*R + 0.5363325363*G + 0.0514459929*B
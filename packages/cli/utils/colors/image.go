@@ -0,0 +1,212 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+)
+
+// -------------------------------
+// image/color interop
+// -------------------------------
+
+// Every colour type implements color.Color. Values outside sRGB are
+// clipped, and a Hex or Munsell value that does not parse is
+// transparent black. XYZ is taken as D65-relative and Lab as CIELAB D50,
+// as in CSS; HCL.Lab and Lab.ToHCL adapt between the two.
+
+// RGBA implements color.Color: alpha-premultiplied 16-bit sRGB.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	n := c.NRGBA64()
+	a = uint32(n.A)
+	return uint32(n.R) * a / 0xffff, uint32(n.G) * a / 0xffff, uint32(n.B) * a / 0xffff, a
+}
+
+// NRGBA64 returns the colour as clipped, non-premultiplied 16-bit sRGB.
+func (c Color) NRGBA64() color.NRGBA64 {
+	s := c.To(SpaceSRGB).Clip()
+	return color.NRGBA64{R: quantize16(s.V[0]), G: quantize16(s.V[1]), B: quantize16(s.V[2]), A: quantize16(c.Alpha)}
+}
+
+func quantize16(v float64) uint16 {
+	if !(v > 0) {
+		return 0
+	}
+	return uint16(math.Round(math.Min(v, 1) * 0xffff))
+}
+
+// FromNRGBA64 converts a 16-bit sRGB colour without losing precision.
+func FromNRGBA64(c color.NRGBA64) Color {
+	return Color{
+		Space: SpaceSRGB,
+		V:     [4]float64{float64(c.R) / 0xffff, float64(c.G) / 0xffff, float64(c.B) / 0xffff},
+		Alpha: float64(c.A) / 0xffff,
+	}
+}
+
+// FromColor converts any color.Color to an sRGB Color, keeping its full
+// precision: this package's types convert exactly, NRGBA64 and NRGBA
+// directly, and anything else through its premultiplied RGBA.
+func FromColor(c color.Color) Color {
+	switch t := c.(type) {
+	case Color:
+		return t
+	case interface{ toColor() Color }:
+		return t.toColor()
+	case color.NRGBA64:
+		return FromNRGBA64(t)
+	case color.NRGBA:
+		return Color{
+			Space: SpaceSRGB,
+			V:     [4]float64{float64(t.R) / 255, float64(t.G) / 255, float64(t.B) / 255},
+			Alpha: float64(t.A) / 255,
+		}
+	}
+
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return Color{Space: SpaceSRGB}
+	}
+	fa := float64(a)
+	return Color{
+		Space: SpaceSRGB,
+		V:     [4]float64{float64(r) / fa, float64(g) / fa, float64(b) / fa},
+		Alpha: fa / 0xffff,
+	}
+}
+
+func (c RGB) toColor() Color          { return FromRGB(c) }
+func (c HSL) toColor() Color          { return NewColor(SpaceHSL, c.H, c.S*100, c.L*100) }
+func (c HCL) toColor() Color          { return NewColor(SpaceHCL, c.H, c.C, c.L) }
+func (c OKLCH) toColor() Color        { return NewColor(SpaceOKLCH, c.L, c.C, c.H) }
+func (c CMYK) toColor() Color         { return NewColor(SpaceCMYK, c.C, c.M, c.Y, c.K) }
+func (c XYZ) toColor() Color          { return NewColor(SpaceXYZD65, c.X, c.Y, c.Z) }
+func (c XYY) toColor() Color          { return c.ToXYZ().toColor() }
+func (c Lab) toColor() Color          { return NewColor(SpaceLab, c.L, c.A, c.B) }
+func (p CheckerPatch) toColor() Color { return p.Lab.toColor() }
+
+func (h Hex) toColor() Color {
+	c, err := Parse(string(h))
+	if err != nil {
+		return Color{Space: SpaceSRGB}
+	}
+	return c
+}
+
+func (c Munsell) toColor() Color {
+	xyz, err := c.ToXYZ()
+	if err != nil {
+		return Color{Space: SpaceSRGB}
+	}
+	return xyz.toColor()
+}
+
+func (c RGB) RGBA() (r, g, b, a uint32)          { return c.toColor().RGBA() }
+func (h Hex) RGBA() (r, g, b, a uint32)          { return h.toColor().RGBA() }
+func (c HSL) RGBA() (r, g, b, a uint32)          { return c.toColor().RGBA() }
+func (c HCL) RGBA() (r, g, b, a uint32)          { return c.toColor().RGBA() }
+func (c OKLCH) RGBA() (r, g, b, a uint32)        { return c.toColor().RGBA() }
+func (c CMYK) RGBA() (r, g, b, a uint32)         { return c.toColor().RGBA() }
+func (c XYZ) RGBA() (r, g, b, a uint32)          { return c.toColor().RGBA() }
+func (c XYY) RGBA() (r, g, b, a uint32)          { return c.toColor().RGBA() }
+func (c Lab) RGBA() (r, g, b, a uint32)          { return c.toColor().RGBA() }
+func (c Munsell) RGBA() (r, g, b, a uint32)      { return c.toColor().RGBA() }
+func (p CheckerPatch) RGBA() (r, g, b, a uint32) { return p.toColor().RGBA() }
+
+// -------------------------------
+// Models
+// -------------------------------
+
+// Models convert any color.Color to the named type; the opaque types
+// drop alpha.
+var (
+	RGBModel   color.Model = color.ModelFunc(func(c color.Color) color.Color { return FromColor(c).RGB() })
+	HexModel   color.Model = color.ModelFunc(func(c color.Color) color.Color { return Hex(FromColor(c).Hex()) })
+	HSLModel   color.Model = color.ModelFunc(hslModel)
+	HCLModel   color.Model = color.ModelFunc(hclModel)
+	OKLCHModel color.Model = color.ModelFunc(oklchModel)
+	CMYKModel  color.Model = color.ModelFunc(cmykModel)
+	XYZModel   color.Model = color.ModelFunc(xyzModel)
+	LabModel   color.Model = color.ModelFunc(labModel)
+)
+
+func hslModel(c color.Color) color.Color {
+	v := FromColor(c).To(SpaceHSL).V
+	return HSL{H: v[0], S: v[1] / 100, L: v[2] / 100}
+}
+
+func hclModel(c color.Color) color.Color {
+	v := FromColor(c).To(SpaceHCL).V
+	return HCL{H: v[0], C: v[1], L: v[2]}
+}
+
+func oklchModel(c color.Color) color.Color {
+	v := FromColor(c).To(SpaceOKLCH).V
+	return OKLCH{L: v[0], C: v[1], H: v[2]}
+}
+
+func cmykModel(c color.Color) color.Color {
+	v := FromColor(c).To(SpaceCMYK).V
+	return CMYK{C: v[0], M: v[1], Y: v[2], K: v[3]}
+}
+
+func xyzModel(c color.Color) color.Color {
+	v := FromColor(c).To(SpaceXYZD65).V
+	return XYZ{X: v[0], Y: v[1], Z: v[2]}
+}
+
+func labModel(c color.Color) color.Color {
+	v := FromColor(c).To(SpaceLab).V
+	return Lab{L: v[0], A: v[1], B: v[2]}
+}
+
+// Model returns the color.Model converting to a Color in this space,
+// alpha included.
+func (s Space) Model() color.Model {
+	return color.ModelFunc(func(c color.Color) color.Color {
+		return FromColor(c).To(s)
+	})
+}
+
+// -------------------------------
+// Palettes
+// -------------------------------
+
+// Palette builds a color.Palette for image/draw or image/gif from any
+// of this package's colours, e.g. Palette(ColorChecker24[:]...). Each
+// entry is converted once, to color.NRGBA64, so quantising an image
+// does not repeat the colour conversion for every pixel.
+func Palette[T color.Color](cs ...T) color.Palette {
+	p := make(color.Palette, len(cs))
+	for i, c := range cs {
+		p[i] = FromColor(c).NRGBA64()
+	}
+	return p
+}
+
+// ParsePalette parses CSS colours into a palette of color.NRGBA64.
+func ParsePalette(specs ...string) (color.Palette, error) {
+	p := make(color.Palette, len(specs))
+	for i, s := range specs {
+		c, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = c.NRGBA64()
+	}
+	return p, nil
+}
+
+// Masstone returns the colour of the pure pigment under D65.
+func (p Pigment) Masstone() Color {
+	lin := spectrumToLinearRGB(PigmentSet{p}.Reflectance([]float64{1}))
+	return NewColor(SpaceLinearSRGB, lin[0], lin[1], lin[2])
+}
+
+// Palette returns the masstone of every pigment in the set.
+func (set PigmentSet) Palette() color.Palette {
+	p := make(color.Palette, len(set))
+	for i, pig := range set {
+		p[i] = pig.Masstone().NRGBA64()
+	}
+	return p
+}
@@ -0,0 +1,167 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestNRGBA64RoundTrip(t *testing.T) {
+	for _, n := range []color.NRGBA64{
+		{0, 0, 0, 0},
+		{0xffff, 0xffff, 0xffff, 0xffff},
+		{0x1234, 0x8000, 0xfedc, 0xffff},
+		{1, 2, 3, 0x7fff},
+		{0xffff, 0, 0x0101, 1},
+	} {
+		if got := FromNRGBA64(n).NRGBA64(); got != n {
+			t.Errorf("%v → %v", n, got)
+		}
+		if got := FromColor(n).NRGBA64(); got != n {
+			t.Errorf("FromColor(%v) → %v", n, got)
+		}
+	}
+
+	// premultiplied input comes back unpremultiplied
+	got := FromColor(color.RGBA64{R: 0x4000, A: 0x8000}).NRGBA64()
+	if got.R < 0x7ffe || got.R > 0x8001 || got.A != 0x8000 {
+		t.Errorf("RGBA64 half-alpha red → %v", got)
+	}
+}
+
+// Every type's RGBA agrees with its value in sRGB.
+func TestTypesRGBA(t *testing.T) {
+	const tol = 0x101 // one 8-bit step
+	for _, tc := range []struct {
+		name string
+		c    color.Color
+		want RGB
+	}{
+		{"RGB", RGB{255, 87, 51}, RGB{255, 87, 51}},
+		{"Hex", Hex("#FF5733"), RGB{255, 87, 51}},
+		{"HSL", HSL{H: 120, S: 1, L: 0.25}, RGB{0, 128, 0}},
+		{"HCL", HCL{H: 0, C: 0, L: 100}, RGB{255, 255, 255}},
+		{"OKLCH", OKLCH{L: 1, C: 0, H: 0}, RGB{255, 255, 255}},
+		{"CMYK", CMYK{M: 100, Y: 100}, RGB{255, 0, 0}},
+		{"XYZ", WhiteD65, RGB{255, 255, 255}},
+		{"XYY", XYY{X: 0.3127, Y: 0.3290, Lum: 1}, RGB{255, 255, 255}},
+		{"Lab", Lab{L: 100}, RGB{255, 255, 255}},
+		{"Lab mid gray", Lab{L: 53.585}, RGB{128, 128, 128}},
+	} {
+		r, g, b, a := tc.c.RGBA()
+		if a != 0xffff {
+			t.Errorf("%s: alpha %#x", tc.name, a)
+		}
+		for i, got := range []uint32{r, g, b} {
+			want := uint32([]int{tc.want.R, tc.want.G, tc.want.B}[i]) * 0x101
+			if d := int(got) - int(want); d > tol || d < -tol {
+				t.Errorf("%s: RGBA = %#x %#x %#x, want ≈ %+v", tc.name, r, g, b, tc.want)
+				break
+			}
+		}
+	}
+
+	if _, _, _, a := Hex("nope").RGBA(); a != 0 {
+		t.Error("an invalid Hex should be transparent")
+	}
+}
+
+// Lab as a color.Color is D50; HCL is D65, and HCL.Lab converts between
+// them so both describe the same colour.
+func TestLabWhitePoint(t *testing.T) {
+	for _, hcl := range []HCL{{H: 40, C: 60, L: 50}, {H: 250, C: 30, L: 70}, {H: 0, C: 0, L: 30}} {
+		lab := hcl.Lab()
+		if FromColor(lab).NRGBA64() != FromColor(hcl).NRGBA64() {
+			t.Errorf("%+v and its Lab %+v render differently", hcl, lab)
+		}
+		back := lab.ToHCL()
+		if math.Abs(back.L-hcl.L) > 1e-9 || math.Abs(back.C-hcl.C) > 1e-9 ||
+			(hcl.C > 0 && math.Abs(back.H-hcl.H) > 1e-9) {
+			t.Errorf("%+v → %+v → %+v", hcl, lab, back)
+		}
+	}
+
+	// a D50 neutral is neutral in sRGB
+	n := FromColor(Lab{L: 50}).NRGBA64()
+	if n.R != n.G || n.G != n.B {
+		t.Errorf("Lab{L: 50} = %v, want a gray", n)
+	}
+}
+
+func TestModelsConvert(t *testing.T) {
+	in := color.NRGBA64{R: 0xffff, G: 0x5757, B: 0x3333, A: 0xffff}
+	ref := FromNRGBA64(in)
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	if got := RGBModel.Convert(in); got != (RGB{255, 87, 51}) {
+		t.Errorf("RGBModel: %v", got)
+	}
+	if got := HexModel.Convert(in); got != Hex("#FF5733") {
+		t.Errorf("HexModel: %v", got)
+	}
+	if got := HSLModel.Convert(in).(HSL); !near(got.H, ref.To(SpaceHSL).V[0]) || !near(got.S*100, ref.To(SpaceHSL).V[1]) {
+		t.Errorf("HSLModel: %+v", got)
+	}
+	if got, want := HCLModel.Convert(in).(HCL), ref.To(SpaceHCL).V; !near(got.H, want[0]) || !near(got.C, want[1]) || !near(got.L, want[2]) {
+		t.Errorf("HCLModel: %+v, want %v", got, want)
+	}
+	if got, want := OKLCHModel.Convert(in).(OKLCH), ref.To(SpaceOKLCH).V; !near(got.L, want[0]) || !near(got.C, want[1]) || !near(got.H, want[2]) {
+		t.Errorf("OKLCHModel: %+v, want %v", got, want)
+	}
+	if got := CMYKModel.Convert(in).(CMYK); !near(got.C, 0) || !near(got.K, 0) || !near(got.Y, 80) {
+		t.Errorf("CMYKModel: %+v", got)
+	}
+	if got, want := XYZModel.Convert(in).(XYZ), ref.XYZ(); !near(got.X, want.X) || !near(got.Y, want.Y) || !near(got.Z, want.Z) {
+		t.Errorf("XYZModel: %+v, want %+v", got, want)
+	}
+	if got, want := LabModel.Convert(in).(Lab), ref.To(SpaceLab).V; !near(got.L, want[0]) || !near(got.A, want[1]) || !near(got.B, want[2]) {
+		t.Errorf("LabModel: %+v, want %v", got, want)
+	}
+
+	// every model's result converts back to the input
+	for name, m := range map[string]color.Model{
+		"RGB": RGBModel, "Hex": HexModel, "HSL": HSLModel, "HCL": HCLModel,
+		"OKLCH": OKLCHModel, "CMYK": CMYKModel, "XYZ": XYZModel, "Lab": LabModel,
+	} {
+		got := FromColor(m.Convert(in)).NRGBA64()
+		for i, d := range []int{int(got.R) - int(in.R), int(got.G) - int(in.G), int(got.B) - int(in.B)} {
+			if d > 0x80 || d < -0x80 {
+				t.Errorf("%s: channel %d round trips to %v", name, i, got)
+			}
+		}
+	}
+
+	// Space models keep alpha
+	half := color.NRGBA64{R: 0xffff, A: 0x8000}
+	got := SpaceOKLCH.Model().Convert(half).(Color)
+	if got.Space != SpaceOKLCH || got.NRGBA64() != half {
+		t.Errorf("SpaceOKLCH.Model: %+v", got)
+	}
+}
+
+func TestPalette(t *testing.T) {
+	p := Palette(ColorChecker24[:]...)
+	for i, entry := range p {
+		n, ok := entry.(color.NRGBA64)
+		if !ok {
+			t.Fatalf("entry %d is %T, want color.NRGBA64", i, entry)
+		}
+		if n != FromColor(ColorChecker24[i]).NRGBA64() {
+			t.Errorf("entry %d = %v", i, n)
+		}
+	}
+	if i := p.Index(ColorChecker24[14]); i != 14 {
+		t.Errorf("Index(Red) = %d", i)
+	}
+
+	parsed, err := ParsePalette("red", "#00ff0080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed[1] != (color.NRGBA64{G: 0xffff, A: 0x8080}) {
+		t.Errorf("ParsePalette = %v", parsed)
+	}
+	if _, err := ParsePalette("red", "nope"); err == nil {
+		t.Error("expected an error")
+	}
+}
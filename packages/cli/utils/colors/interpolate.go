@@ -0,0 +1,124 @@
+package colors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// -------------------------------
+// Interpolation (CSS color-mix)
+// -------------------------------
+
+// HueMethod is a CSS hue interpolation method.
+type HueMethod int
+
+const (
+	HueShorter HueMethod = iota
+	HueLonger
+	HueIncreasing
+	HueDecreasing
+)
+
+var hueMethodNames = []string{"shorter", "longer", "increasing", "decreasing"}
+
+func (m HueMethod) String() string {
+	if m < HueShorter || m > HueDecreasing {
+		return "unknown"
+	}
+	return hueMethodNames[m]
+}
+
+// ParseHueMethod accepts shorter, longer, increasing and decreasing,
+// with or without a trailing "hue".
+func ParseHueMethod(s string) (HueMethod, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "hue"))
+	for i, name := range hueMethodNames {
+		if s == name {
+			return HueMethod(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown hue interpolation %q (shorter, longer, increasing, decreasing)", s)
+}
+
+// Mix interpolates from a (t = 0) to b (t = 1) in space, as CSS
+// color-mix(in <space> <hue> hue, a, b t·100%) does: channels are
+// premultiplied by alpha, a hue that is powerless (an achromatic
+// colour's) takes the other colour's, and hue follows the given method.
+// The result is in space and is not gamut mapped.
+func Mix(a, b Color, t float64, space Space, hue HueMethod) Color {
+	ca, cb := a.To(space), b.To(space)
+	h := space.HueChannel()
+
+	if h >= 0 {
+		missA, missB := powerlessHue(ca), powerlessHue(cb)
+		switch {
+		case missA && !missB:
+			ca.V[h] = cb.V[h]
+		case missB && !missA:
+			cb.V[h] = ca.V[h]
+		}
+		ca.V[h], cb.V[h] = fixupHues(ca.V[h], cb.V[h], hue)
+	}
+
+	alpha := ca.Alpha*(1-t) + cb.Alpha*t
+	out := Color{Space: space, Alpha: alpha}
+	for i := 0; i < space.Channels(); i++ {
+		if i == h {
+			out.V[i] = normalizeHue(ca.V[i]*(1-t) + cb.V[i]*t)
+			continue
+		}
+		v := ca.V[i]*ca.Alpha*(1-t) + cb.V[i]*cb.Alpha*t
+		if alpha > 0 {
+			v /= alpha
+		}
+		out.V[i] = v
+	}
+	return out
+}
+
+// powerlessHue reports whether a colour's hue carries no information.
+func powerlessHue(c Color) bool {
+	switch c.Space {
+	case SpaceOKLCH:
+		return c.V[1] < 4e-4
+	case SpaceLCH:
+		return c.V[1] < 0.15
+	case SpaceHCL:
+		return c.V[1] < 0.15
+	case SpaceHSL:
+		return c.V[1] < 0.01 || c.V[2] <= 0 || c.V[2] >= 100
+	case SpaceHWB:
+		return c.V[1]+c.V[2] >= 99.99
+	}
+	return false
+}
+
+// fixupHues adjusts two hues (degrees) so that linear interpolation
+// between them takes the arc the method asks for.
+func fixupHues(h1, h2 float64, m HueMethod) (float64, float64) {
+	h1, h2 = normalizeHue(h1), normalizeHue(h2)
+	d := h2 - h1
+	switch m {
+	case HueShorter:
+		if d > 180 {
+			h1 += 360
+		} else if d < -180 {
+			h2 += 360
+		}
+	case HueLonger:
+		if d > 0 && d < 180 {
+			h1 += 360
+		} else if d > -180 && d <= 0 {
+			h2 += 360
+		}
+	case HueIncreasing:
+		if d < 0 {
+			h2 += 360
+		}
+	case HueDecreasing:
+		if d > 0 {
+			h1 += 360
+		}
+	}
+	return h1, h2
+}
@@ -0,0 +1,104 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMix checks Mix against CSS color-mix() results from the Web
+// Platform Tests (css/css-color/parsing/color-mix-computed.html) and the
+// CSS Color 5 examples. want is the computed color-mix() value; hex is
+// what browsers render, when the case pins it.
+func TestMix(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		t     float64 // share of b
+		space Space
+		hue   HueMethod
+		want  string
+		hex   string
+		tol   float64
+	}{
+		// one case per space
+		{"srgb", "red", "blue", 0.5, SpaceSRGB, HueShorter, "color(srgb 0.5 0 0.5)", "#800080", 1e-9},
+		{"srgb-linear", "red", "blue", 0.5, SpaceLinearSRGB, HueShorter, "color(srgb-linear 0.5 0 0.5)", "#BC00BC", 1e-9},
+		{"lab", "lab(10 20 30)", "lab(50 60 70)", 0.75, SpaceLab, HueShorter, "lab(40 50 60)", "", 1e-9},
+		{"oklab", "oklab(0.1 0.2 0.3)", "oklab(0.5 0.6 0.7)", 0.5, SpaceOklab, HueShorter, "oklab(0.3 0.4 0.5)", "", 1e-9},
+		{"xyz-d65", "color(xyz-d65 .1 .2 .3)", "color(xyz-d65 .5 .6 .7)", 0.5, SpaceXYZD65, HueShorter, "color(xyz-d65 .3 .4 .5)", "", 1e-9},
+		{"xyz-d50", "color(xyz-d50 .1 .2 .3)", "color(xyz-d50 .5 .6 .7)", 0.5, SpaceXYZD50, HueShorter, "color(xyz-d50 .3 .4 .5)", "", 1e-9},
+		{"lch", "lch(10 20 30deg)", "lch(50 60 70deg)", 0.5, SpaceLCH, HueShorter, "lch(30 40 50)", "", 1e-9},
+		{"oklch", "oklch(0.1 0.2 30deg)", "oklch(0.5 0.6 50deg)", 0.5, SpaceOKLCH, HueShorter, "oklch(0.3 0.4 40)", "", 1e-9},
+		{"hsl", "hsl(120deg 10% 20%)", "hsl(30deg 30% 40%)", 0.5, SpaceHSL, HueShorter, "hsl(75 20% 30%)", "#545C3D", 1e-9},
+		{"hsl 25%", "hsl(120deg 10% 20%)", "hsl(30deg 30% 40%)", 0.75, SpaceHSL, HueShorter, "hsl(52.5 25% 35%)", "#706A43", 1e-9},
+		{"hwb", "hwb(120deg 10% 20%)", "hwb(30deg 30% 40%)", 0.5, SpaceHWB, HueShorter, "hwb(75 20% 30%)", "#93B333", 1e-9},
+		{"srgb from hex in oklab", "#000000", "#FFFFFF", 0.5, SpaceOklab, HueShorter, "oklab(0.5 0 0)", "#636363", 1e-6},
+
+		// hue interpolation methods
+		{"shorter", "hsl(40deg 50% 50%)", "hsl(60deg 50% 50%)", 0.5, SpaceHSL, HueShorter, "hsl(50 50% 50%)", "", 1e-9},
+		{"shorter wraps", "hsl(50deg 50% 50%)", "hsl(330deg 50% 50%)", 0.5, SpaceHSL, HueShorter, "hsl(10 50% 50%)", "", 1e-9},
+		{"longer", "hsl(40deg 50% 50%)", "hsl(60deg 50% 50%)", 0.5, SpaceHSL, HueLonger, "hsl(230 50% 50%)", "", 1e-9},
+		{"longer wraps", "hsl(50deg 50% 50%)", "hsl(330deg 50% 50%)", 0.5, SpaceHSL, HueLonger, "hsl(190 50% 50%)", "", 1e-9},
+		{"increasing", "hsl(50deg 50% 50%)", "hsl(330deg 50% 50%)", 0.5, SpaceHSL, HueIncreasing, "hsl(190 50% 50%)", "", 1e-9},
+		{"increasing wraps", "hsl(330deg 50% 50%)", "hsl(50deg 50% 50%)", 0.5, SpaceHSL, HueIncreasing, "hsl(10 50% 50%)", "", 1e-9},
+		{"decreasing", "hsl(50deg 50% 50%)", "hsl(330deg 50% 50%)", 0.5, SpaceHSL, HueDecreasing, "hsl(10 50% 50%)", "", 1e-9},
+		{"decreasing wraps", "hsl(330deg 50% 50%)", "hsl(50deg 50% 50%)", 0.5, SpaceHSL, HueDecreasing, "hsl(190 50% 50%)", "", 1e-9},
+		{"oklch shorter", "oklch(0.5 0.1 10deg)", "oklch(0.5 0.1 350deg)", 0.5, SpaceOKLCH, HueShorter, "oklch(0.5 0.1 0)", "", 1e-9},
+		{"oklch longer", "oklch(0.5 0.1 10deg)", "oklch(0.5 0.1 350deg)", 0.5, SpaceOKLCH, HueLonger, "oklch(0.5 0.1 180)", "", 1e-9},
+
+		// a powerless hue takes the other colour's
+		{"powerless hsl", "hsl(120deg 0% 50%)", "hsl(30deg 40% 60%)", 0.5, SpaceHSL, HueShorter, "hsl(30 20% 55%)", "", 1e-9},
+		{"powerless lch", "lch(10 0 120deg)", "lch(50 60 30deg)", 0.5, SpaceLCH, HueShorter, "lch(30 30 30)", "", 1e-9},
+		{"powerless white", "white", "blue", 0.5, SpaceOKLCH, HueShorter, "oklch(0.7260 0.1566 264.05)", "", 5e-4},
+
+		// premultiplied alpha; hue is not premultiplied
+		{"oklab translucent", "oklab(0.1 0.2 0.3 / .4)", "oklab(0.5 0.6 0.7 / .8)", 0.5, SpaceOklab, HueShorter, "oklab(0.366667 0.466667 0.566667 / 0.6)", "", 1e-6},
+		{"hsl translucent", "hsl(120deg 10% 20% / .4)", "hsl(30deg 30% 40% / .8)", 0.5, SpaceHSL, HueShorter, "hsl(75 23.333333% 33.333333% / 0.6)", "", 1e-6},
+		{"srgb translucent", "rgb(255 0 0 / .5)", "rgb(0 0 255 / .25)", 0.5, SpaceSRGB, HueShorter, "color(srgb 0.666667 0 0.333333 / 0.375)", "", 1e-6},
+		{"transparent", "transparent", "blue", 0.5, SpaceOKLCH, HueShorter, "oklch(0.4520 0.3132 264.05 / 0.5)", "", 5e-4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b, want := MustParse(tt.a), MustParse(tt.b), MustParse(tt.want)
+			got := Mix(a, b, tt.t, tt.space, tt.hue)
+			if got.Space != tt.space {
+				t.Fatalf("space = %s, want %s", got.Space, tt.space)
+			}
+			h := tt.space.HueChannel()
+			for i := 0; i < tt.space.Channels(); i++ {
+				d := math.Abs(got.V[i] - want.V[i])
+				if i == h {
+					d = hueDiff(got.V[i], want.V[i])
+				}
+				if d > tt.tol*math.Max(1, math.Abs(want.V[i])) {
+					t.Errorf("got %s, want %s", got, want)
+					break
+				}
+			}
+			if math.Abs(got.Alpha-want.Alpha) > 1e-9 {
+				t.Errorf("alpha = %v, want %v", got.Alpha, want.Alpha)
+			}
+			if tt.hex != "" {
+				if hex := got.MapToSRGB().Hex(); hex != tt.hex {
+					t.Errorf("hex = %s, want %s", hex, tt.hex)
+				}
+			}
+		})
+	}
+}
+
+func TestParseHueMethod(t *testing.T) {
+	for _, s := range []string{"shorter", "Longer hue", " increasing ", "decreasing"} {
+		m, err := ParseHueMethod(s)
+		if err != nil {
+			t.Errorf("%q: %v", s, err)
+			continue
+		}
+		if back, _ := ParseHueMethod(m.String()); back != m {
+			t.Errorf("%q: %s does not round-trip", s, m)
+		}
+	}
+	if _, err := ParseHueMethod("sideways"); err == nil {
+		t.Error("sideways: want an error")
+	}
+}
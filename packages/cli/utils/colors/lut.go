@@ -0,0 +1,90 @@
+package colors
+
+import "math"
+
+// -------------------------------
+// 8-bit sRGB lookup tables
+// -------------------------------
+
+// srgb8ToLinear holds the decoded value of every 8-bit sRGB code.
+var srgb8ToLinear [256]float64
+
+// srgb8Threshold[k] is the linear value halfway (in encoded terms)
+// between codes k and k+1; values at or above it round up.
+var srgb8Threshold [255]float64
+
+// linearToSRGB8Hint maps linear light quantised to 1/4095 to the lowest
+// code the quantisation bucket can round to; a few threshold steps then
+// give the exact code.
+var linearToSRGB8Hint [4096]uint8
+
+func init() {
+	for i := range srgb8ToLinear {
+		srgb8ToLinear[i] = TransferSRGB.Decode(float64(i) / 255)
+	}
+	for k := range srgb8Threshold {
+		srgb8Threshold[k] = TransferSRGB.Decode((float64(k) + 0.5) / 255)
+	}
+	code := 0
+	for i := range linearToSRGB8Hint {
+		v := float64(i) / 4095
+		for code < 255 && v >= srgb8Threshold[code] {
+			code++
+		}
+		linearToSRGB8Hint[i] = uint8(code)
+	}
+}
+
+// SRGB8ToLinear decodes an 8-bit sRGB channel to linear light (0–1).
+func SRGB8ToLinear(v uint8) float64 {
+	return srgb8ToLinear[v]
+}
+
+// LinearToSRGB8 encodes linear light as the nearest 8-bit sRGB code,
+// clipping to 0–1. It matches rounding TransferSRGB.Encode(v)·255.
+func LinearToSRGB8(v float64) uint8 {
+	if !(v > 0) {
+		return 0
+	}
+	if v >= 1 {
+		return 255
+	}
+	code := linearToSRGB8Hint[int(v*4095)]
+	for code < 255 && v >= srgb8Threshold[code] {
+		code++
+	}
+	return code
+}
+
+// decodeSRGB is TransferSRGB.Decode with a table lookup for values that
+// are exact 8-bit codes, which most data converted in bulk is.
+func decodeSRGB(v float64) float64 {
+	if k := v * 255; k >= 0 && k <= 255 && k == math.Trunc(k) {
+		return srgb8ToLinear[int(k)]
+	}
+	return TransferSRGB.Decode(v)
+}
+
+// rgb8ToLinear decodes three 0–255 ints through the table. Values
+// outside 0–255, such as HSL with a lightness above 100 gives, are
+// decoded with the formula rather than wrapped.
+func rgb8ToLinear(r, g, b int) (R, G, B float64) {
+	return code8ToLinear(r), code8ToLinear(g), code8ToLinear(b)
+}
+
+func code8ToLinear(v int) float64 {
+	if v < 0 || v > 255 {
+		return linearize(float64(v) / 255)
+	}
+	return srgb8ToLinear[v]
+}
+
+// linearToRGB8 encodes linear light as clipped 0–255 ints.
+func linearToRGB8(R, G, B float64) (r, g, b int) {
+	return int(LinearToSRGB8(R)), int(LinearToSRGB8(G)), int(LinearToSRGB8(B))
+}
+
+// roundToCode is used where encoded (not linear) values are quantised.
+func roundToCode(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
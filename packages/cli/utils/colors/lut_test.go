@@ -0,0 +1,163 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// perCallHexToOKLCH is Hex.ToOKLCH as it was before the lookup tables:
+// it parses with strconv and linearizes with math.Pow on every call.
+func perCallHexToOKLCH(h Hex) (L, C, H float64, err error) {
+	hex := strings.TrimPrefix(string(h), "#")
+	if len(hex) != 6 {
+		return 0, 0, 0, fmt.Errorf("invalid hex length")
+	}
+	var ch [3]float64
+	for i := range ch {
+		v, err := strconv.ParseInt(hex[2*i:2*i+2], 16, 0)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		ch[i] = linearize(float64(v) / 255)
+	}
+	r, g, b := ch[0], ch[1], ch[2]
+
+	l_ := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m_ := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s_ := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	L = 0.2104542553*l_ + 0.7936177850*m_ - 0.0040720468*s_
+	A := 1.9779984951*l_ - 2.4285922050*m_ + 0.4505937099*s_
+	B := 0.0259040371*l_ + 0.7827717662*m_ - 0.8086757660*s_
+	C = math.Sqrt(A*A + B*B)
+	H = math.Atan2(B, A) * (180 / math.Pi)
+	if H < 0 {
+		H += 360
+	}
+	return L, C, H, nil
+}
+
+func TestRGB8ToLinear(t *testing.T) {
+	for v := -20; v <= 300; v++ {
+		got, _, _ := rgb8ToLinear(v, 0, 0)
+		if want := linearize(float64(v) / 255); math.Abs(got-want) > 1e-12 {
+			t.Errorf("rgb8ToLinear(%d) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestHSLToOKLCHOutOfRange(t *testing.T) {
+	// L above 100 gives codes above 255, which must not wrap to dark
+	if L, _, _ := HSLToOKLCH(0, 0, 101); L < 1 {
+		t.Errorf("HSLToOKLCH(0, 0, 101) L = %v, want above 1", L)
+	}
+}
+
+func TestHexToOKLCHMatchesPerCall(t *testing.T) {
+	for _, h := range benchHexes(4096) {
+		L, C, H, _ := h.ToOKLCH()
+		wl, wc, wh, _ := perCallHexToOKLCH(h)
+		if math.Abs(L-wl) > 1e-12 || math.Abs(C-wc) > 1e-12 || (C > 1e-6 && math.Abs(H-wh) > 1e-9) {
+			t.Fatalf("%s: got %v %v %v, want %v %v %v", h, L, C, H, wl, wc, wh)
+		}
+	}
+}
+
+// -------------------------------
+// Benchmarks (pixels/s)
+// -------------------------------
+
+const benchPixels = 1 << 14
+
+func benchRGB8(n int) []uint8 {
+	rng := rand.New(rand.NewPCG(1, 2))
+	px := make([]uint8, 3*n)
+	for i := range px {
+		px[i] = uint8(rng.IntN(256))
+	}
+	return px
+}
+
+func benchHexes(n int) []Hex {
+	px := benchRGB8(n)
+	hexes := make([]Hex, n)
+	for i := range hexes {
+		hexes[i] = Hex(fmt.Sprintf("#%02X%02X%02X", px[3*i], px[3*i+1], px[3*i+2]))
+	}
+	return hexes
+}
+
+func reportPixels(b *testing.B, n int) {
+	b.ReportMetric(float64(b.N)*float64(n)/b.Elapsed().Seconds(), "pixels/s")
+}
+
+func BenchmarkHexToOKLCHPerCall(b *testing.B) {
+	hexes := benchHexes(benchPixels)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, h := range hexes {
+			perCallHexToOKLCH(h)
+		}
+	}
+	reportPixels(b, benchPixels)
+}
+
+func BenchmarkHexToOKLCH(b *testing.B) {
+	hexes := benchHexes(benchPixels)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, h := range hexes {
+			h.ToOKLCH()
+		}
+	}
+	reportPixels(b, benchPixels)
+}
+
+func BenchmarkColorTo(b *testing.B) {
+	px := benchRGB8(benchPixels)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchPixels; j++ {
+			NewColor(SpaceSRGB, float64(px[3*j])/255, float64(px[3*j+1])/255, float64(px[3*j+2])/255).To(SpaceOKLCH)
+		}
+	}
+	reportPixels(b, benchPixels)
+}
+
+func BenchmarkConvertSlice(b *testing.B) {
+	px := benchRGB8(benchPixels)
+	src := make([]float64, len(px))
+	for i, v := range px {
+		src[i] = float64(v) / 255
+	}
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvertSlice(dst, src, SpaceSRGB, SpaceOKLCH)
+	}
+	reportPixels(b, benchPixels)
+}
+
+func BenchmarkConvertFromRGB8(b *testing.B) {
+	px := benchRGB8(benchPixels)
+	dst := make([]float64, len(px))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvertFromRGB8(dst, px, SpaceOKLCH)
+	}
+	reportPixels(b, benchPixels)
+}
+
+func BenchmarkConvertToRGB8(b *testing.B) {
+	px := benchRGB8(benchPixels)
+	src := make([]float64, len(px))
+	ConvertFromRGB8(src, px, SpaceOKLCH)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvertToRGB8(px, src, SpaceOKLCH)
+	}
+	reportPixels(b, benchPixels)
+}
@@ -0,0 +1,60 @@
+package colors
+
+import "math"
+
+// -------------------------------
+// 3×3 matrix helpers
+// -------------------------------
+type mat3 [3][3]float64
+
+func (m mat3) mulVec(v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+func (m mat3) mul(n mat3) mat3 {
+	var out mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+	return out
+}
+
+func (m mat3) det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// inverse returns false when the matrix is (numerically) singular.
+func (m mat3) inverse() (mat3, bool) {
+	d := m.det()
+	if math.Abs(d) < 1e-12 {
+		return mat3{}, false
+	}
+	var inv mat3
+	inv[0][0] = (m[1][1]*m[2][2] - m[1][2]*m[2][1]) / d
+	inv[0][1] = (m[0][2]*m[2][1] - m[0][1]*m[2][2]) / d
+	inv[0][2] = (m[0][1]*m[1][2] - m[0][2]*m[1][1]) / d
+	inv[1][0] = (m[1][2]*m[2][0] - m[1][0]*m[2][2]) / d
+	inv[1][1] = (m[0][0]*m[2][2] - m[0][2]*m[2][0]) / d
+	inv[1][2] = (m[0][2]*m[1][0] - m[0][0]*m[1][2]) / d
+	inv[2][0] = (m[1][0]*m[2][1] - m[1][1]*m[2][0]) / d
+	inv[2][1] = (m[0][1]*m[2][0] - m[0][0]*m[2][1]) / d
+	inv[2][2] = (m[0][0]*m[1][1] - m[0][1]*m[1][0]) / d
+	return inv, true
+}
+
+// solve3 solves m·x = v.
+func solve3(m mat3, v [3]float64) ([3]float64, bool) {
+	inv, ok := m.inverse()
+	if !ok {
+		return [3]float64{}, false
+	}
+	return inv.mulVec(v), true
+}
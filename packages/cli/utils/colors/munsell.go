@@ -0,0 +1,531 @@
+package colors
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// Munsell struct
+// -------------------------------
+type Munsell struct {
+	H float64 // Hue number 0–100: 5 = 5R, 15 = 5YR, … 100 (≡ 0) = 10RP
+	V float64 // Value 0–10
+	C float64 // Chroma, 0 for neutrals
+}
+
+var munsellFamilies = []string{"R", "YR", "Y", "GY", "G", "BG", "B", "PB", "P", "RP"}
+
+// -------------------------------
+// Validate Munsell
+// -------------------------------
+func (c Munsell) IsValid() bool {
+	return c.H >= 0 && c.H <= 100 &&
+		c.V >= 0 && c.V <= 10 &&
+		c.C >= 0
+}
+
+// -------------------------------
+// Munsell notation
+// -------------------------------
+
+// String formats the colour in Munsell notation, e.g. "5R 4/14" or "N 5/".
+func (c Munsell) String() string {
+	if c.C < 0.05 {
+		return fmt.Sprintf("N %s/", trimFloat(c.V))
+	}
+	return c.notation(false)
+}
+
+// notation formats a chromatic colour to one decimal, or exactly enough
+// for ParseMunsell to read the same values back.
+func (c Munsell) notation(exact bool) string {
+	h := math.Mod(c.H, 100)
+	if h <= 0 {
+		h += 100
+	}
+	family := int(math.Ceil(h/10)) - 1
+	base := float64(family) * 10
+	if !exact {
+		return fmt.Sprintf("%s%s %s/%s", trimFloat(h-base), munsellFamilies[family], trimFloat(c.V), trimFloat(c.C))
+	}
+
+	// the shortest step that adds back to h exactly
+	step := strconv.FormatFloat(h-base, 'f', -1, 64)
+	for prec := 0; prec < 17; prec++ {
+		s := strconv.FormatFloat(h-base, 'f', prec, 64)
+		if v, _ := strconv.ParseFloat(s, 64); base+v == h {
+			step = s
+			break
+		}
+	}
+	return fmt.Sprintf("%s%s %s/%s", step, munsellFamilies[family], exactFloat(c.V), exactFloat(c.C))
+}
+
+func trimFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
+
+// exactFloat formats v with as many decimals as it takes to read it back.
+func exactFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// ParseMunsell reads notation such as "5R 4/14", "2.5PB 3/8", and the
+// neutrals "N 5/", "N 5/0" or "N5".
+func ParseMunsell(s string) (Munsell, error) {
+	n := strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	if n == "" {
+		return Munsell{}, errors.New("empty Munsell notation")
+	}
+
+	if strings.HasPrefix(n, "N") {
+		value, chroma, _ := strings.Cut(strings.TrimPrefix(n, "N"), "/")
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || v < 0 || v > 10 {
+			return Munsell{}, fmt.Errorf("invalid Munsell neutral %q", s)
+		}
+		if chroma != "" {
+			if c, err := strconv.ParseFloat(chroma, 64); err != nil || c != 0 {
+				return Munsell{}, fmt.Errorf("invalid Munsell neutral %q: chroma must be 0", s)
+			}
+		}
+		return Munsell{V: v}, nil
+	}
+
+	slash := strings.Index(n, "/")
+	if slash < 0 {
+		return Munsell{}, fmt.Errorf("invalid Munsell notation %q", s)
+	}
+	hueAndValue, chroma := n[:slash], n[slash+1:]
+
+	// hue step, family letters, value
+	i := 0
+	for i < len(hueAndValue) && (hueAndValue[i] == '.' || (hueAndValue[i] >= '0' && hueAndValue[i] <= '9')) {
+		i++
+	}
+	j := i
+	for j < len(hueAndValue) && hueAndValue[j] >= 'A' && hueAndValue[j] <= 'Z' {
+		j++
+	}
+
+	step, err := strconv.ParseFloat(hueAndValue[:i], 64)
+	if err != nil || step <= 0 || step > 10 {
+		return Munsell{}, fmt.Errorf("invalid Munsell hue in %q", s)
+	}
+	family := -1
+	for k, f := range munsellFamilies {
+		if f == hueAndValue[i:j] {
+			family = k
+		}
+	}
+	if family < 0 {
+		return Munsell{}, fmt.Errorf("invalid Munsell hue family in %q", s)
+	}
+	v, err := strconv.ParseFloat(hueAndValue[j:], 64)
+	if err != nil || v < 0 || v > 10 {
+		return Munsell{}, fmt.Errorf("invalid Munsell value in %q", s)
+	}
+	c, err := strconv.ParseFloat(chroma, 64)
+	if err != nil || c < 0 {
+		return Munsell{}, fmt.Errorf("invalid Munsell chroma in %q", s)
+	}
+
+	return Munsell{H: float64(family)*10 + step, V: v, C: c}, nil
+}
+
+// -------------------------------
+// Munsell value ↔ luminance (ASTM D1535)
+// -------------------------------
+func munsellValueToY(v float64) float64 {
+	y := 1.1914*v - 0.22533*v*v + 0.23352*v*v*v - 0.020484*v*v*v*v + 0.00081939*v*v*v*v*v
+	return y / 100
+}
+
+func munsellYToValue(y float64) float64 {
+	if y <= 0 {
+		return 0
+	}
+	// Newton on the monotonic ASTM polynomial
+	v := 10 * math.Cbrt(y)
+	for i := 0; i < 20; i++ {
+		f := munsellValueToY(v) - y
+		d := (1.1914 - 2*0.22533*v + 3*0.23352*v*v - 4*0.020484*v*v*v + 5*0.00081939*v*v*v*v) / 100
+		v -= f / d
+	}
+	return math.Min(math.Max(v, 0), 10)
+}
+
+// -------------------------------
+// Renotation data
+// -------------------------------
+
+// MunsellRenotation holds the 1943 Munsell renotation table (xyY under
+// Illuminant C), as published by RIT in "real.dat" / "all.dat".
+type MunsellRenotation struct {
+	// chroma samples keyed by hue index (H/2.5, 1–40) and value
+	samples map[[2]int][]munsellSample
+	values  []float64
+}
+
+type munsellSample struct {
+	C, x, y float64
+}
+
+// DefaultMunsell is used by Munsell.ToXYZ and XYZ.ToMunsell. It starts
+// as the embedded renotation table; when nil the conversions fall back
+// to an approximate CIELAB-based model.
+var DefaultMunsell *MunsellRenotation
+
+// munsellReal is RIT's "real.dat", the renotation colours within the
+// MacAdam limits. go generate fetches it; a build with only the header
+// line leaves DefaultMunsell nil.
+//
+//go:generate curl -fsSL -o munsell_real.dat http://www.rit-mcsl.org/MunsellRenotation/real.dat
+//go:embed munsell_real.dat
+var munsellReal []byte
+
+func init() {
+	if d, err := LoadMunsellRenotation(bytes.NewReader(munsellReal)); err == nil {
+		DefaultMunsell = d
+	}
+}
+
+// LoadMunsellRenotation parses a renotation table with whitespace
+// separated "H V C x y Y" rows (a header line is skipped).
+func LoadMunsellRenotation(r io.Reader) (*MunsellRenotation, error) {
+	d := &MunsellRenotation{samples: map[[2]int][]munsellSample{}}
+	seenValue := map[float64]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		f := strings.Fields(scanner.Text())
+		if len(f) < 5 {
+			continue
+		}
+		m, err := ParseMunsell(f[0] + " " + f[1] + "/" + f[2])
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		x, errX := strconv.ParseFloat(f[3], 64)
+		y, errY := strconv.ParseFloat(f[4], 64)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("line %d: invalid chromaticity", line)
+		}
+
+		hue := int(math.Round(m.H/2.5)) % 40
+		if hue == 0 {
+			hue = 40
+		}
+		if m.V != math.Round(m.V) {
+			continue // only integer values are interpolated
+		}
+		key := [2]int{hue, int(m.V)}
+		d.samples[key] = append(d.samples[key], munsellSample{C: m.C, x: x, y: y})
+		if !seenValue[m.V] {
+			seenValue[m.V] = true
+			d.values = append(d.values, m.V)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(d.samples) == 0 {
+		return nil, errors.New("no renotation rows found")
+	}
+
+	for k := range d.samples {
+		s := d.samples[k]
+		sort.Slice(s, func(i, j int) bool { return s[i].C < s[j].C })
+	}
+	sort.Float64s(d.values)
+	return d, nil
+}
+
+// whitepoint of Illuminant C in xy
+var munsellWhite = WhiteC.ToXYY()
+
+// polar coordinates of xy around Illuminant C
+func toPolar(x, y float64) (rho, theta float64) {
+	dx, dy := x-munsellWhite.X, y-munsellWhite.Y
+	return math.Hypot(dx, dy), math.Atan2(dy, dx)
+}
+
+func fromPolar(rho, theta float64) (x, y float64) {
+	return munsellWhite.X + rho*math.Cos(theta), munsellWhite.Y + rho*math.Sin(theta)
+}
+
+func lerpAngle(a, b, t float64) float64 {
+	d := math.Remainder(b-a, 2*math.Pi)
+	return a + d*t
+}
+
+// chromaAt interpolates the chromaticity for a chroma on one page.
+func (d *MunsellRenotation) chromaAt(hue, value int, c float64) (float64, float64, bool) {
+	s := d.samples[[2]int{hue, value}]
+	if len(s) == 0 {
+		return 0, 0, false
+	}
+
+	// prepend the neutral axis so low chromas interpolate from white
+	pts := append([]munsellSample{{C: 0, x: munsellWhite.X, y: munsellWhite.Y}}, s...)
+
+	i := sort.Search(len(pts), func(i int) bool { return pts[i].C >= c })
+	switch {
+	case i == 0:
+		return pts[0].x, pts[0].y, true
+	case i >= len(pts):
+		i = len(pts) - 1 // extrapolate beyond the last sample
+	}
+	p0, p1 := pts[i-1], pts[i]
+	t := (c - p0.C) / (p1.C - p0.C)
+
+	r1, a1 := toPolar(p1.x, p1.y)
+	if p0.C == 0 {
+		x, y := fromPolar(r1*t, a1)
+		return x, y, true
+	}
+	r0, a0 := toPolar(p0.x, p0.y)
+	x, y := fromPolar(r0+(r1-r0)*t, lerpAngle(a0, a1, t))
+	return x, y, true
+}
+
+// ToXYY converts a Munsell colour to xyY under Illuminant C.
+func (d *MunsellRenotation) ToXYY(m Munsell) (XYY, error) {
+	if !m.IsValid() {
+		return XYY{}, errors.New("invalid Munsell")
+	}
+	Y := munsellValueToY(m.V)
+	if m.C == 0 || m.V == 0 {
+		return XYY{X: munsellWhite.X, Y: munsellWhite.Y, Lum: Y}, nil
+	}
+
+	// clamp the value to the rows present in the table
+	v := math.Min(math.Max(m.V, d.values[0]), d.values[len(d.values)-1])
+	v0 := math.Floor(v)
+	v1 := math.Ceil(v)
+
+	h := math.Mod(m.H, 100)
+	h0 := math.Floor(h/2.5) * 2.5
+	th := (h - h0) / 2.5
+	hue0 := int(h0/2.5) % 40
+	if hue0 == 0 {
+		hue0 = 40
+	}
+	hue1 := hue0%40 + 1
+
+	page := func(value float64) (float64, float64, error) {
+		x0, y0, ok0 := d.chromaAt(hue0, int(value), m.C)
+		x1, y1, ok1 := d.chromaAt(hue1, int(value), m.C)
+		if !ok0 || !ok1 {
+			return 0, 0, fmt.Errorf("no renotation data near %s", m)
+		}
+		r0, a0 := toPolar(x0, y0)
+		r1, a1 := toPolar(x1, y1)
+		x, y := fromPolar(r0+(r1-r0)*th, lerpAngle(a0, a1, th))
+		return x, y, nil
+	}
+
+	xa, ya, err := page(v0)
+	if err != nil {
+		return XYY{}, err
+	}
+	if v0 == v1 {
+		return XYY{X: xa, Y: ya, Lum: Y}, nil
+	}
+	xb, yb, err := page(v1)
+	if err != nil {
+		return XYY{}, err
+	}
+	tv := v - v0
+	return XYY{X: xa + (xb-xa)*tv, Y: ya + (yb-ya)*tv, Lum: Y}, nil
+}
+
+// FromXYY finds the Munsell colour of an xyY (Illuminant C) by
+// searching hue and chroma on the interpolated table.
+func (d *MunsellRenotation) FromXYY(c XYY) (Munsell, error) {
+	v := munsellYToValue(c.Lum)
+	rho, _ := toPolar(c.X, c.Y)
+	if rho < 1e-4 || v == 0 {
+		return Munsell{V: v}, nil
+	}
+
+	guess := approxMunsell(c.ToXYZ().Adapt(WhiteC, WhiteD65))
+	cost := func(p []float64) float64 {
+		h := math.Mod(p[0], 100)
+		if h < 0 {
+			h += 100
+		}
+		got, err := d.ToXYY(Munsell{H: h, V: v, C: math.Abs(p[1])})
+		if err != nil {
+			return math.Inf(1)
+		}
+		return math.Hypot(got.X-c.X, got.Y-c.Y)
+	}
+	best, _ := nelderMead(cost, []float64{guess.H, guess.C}, 1, 400)
+
+	h := math.Mod(best[0], 100)
+	if h < 0 {
+		h += 100
+	}
+	return Munsell{H: h, V: v, C: math.Abs(best[1])}, nil
+}
+
+// -------------------------------
+// Approximate model (no table)
+// -------------------------------
+
+// The fallback when DefaultMunsell is nil, and the starting guess for
+// FromXYY. It places Munsell hue by hand-picked CIELAB hue anchors and
+// chroma by a fixed scale, so it is only good to a hue step or two and
+// drifts at high chroma.
+
+// munsellHueAnchors maps the principal hues (5R, 5YR, …) to approximate
+// CIELAB (D65) hue angles.
+var munsellHueAnchors = [][2]float64{
+	{5, 24}, {15, 58}, {25, 88}, {35, 112}, {45, 158},
+	{55, 195}, {65, 232}, {75, 272}, {85, 310}, {95, 350},
+}
+
+// one Munsell chroma step in CIELAB chroma units (approximate)
+const munsellChromaScale = 5.0
+
+func munsellHueToLab(h float64) float64 {
+	return interpolateCircular(munsellHueAnchors, h, 100, 360)
+}
+
+func labHueToMunsell(a float64) float64 {
+	inv := make([][2]float64, len(munsellHueAnchors))
+	for i, p := range munsellHueAnchors {
+		inv[i] = [2]float64{p[1], p[0]}
+	}
+	sort.Slice(inv, func(i, j int) bool { return inv[i][0] < inv[j][0] })
+	return interpolateCircular(inv, a, 360, 100)
+}
+
+// interpolateCircular maps x on a circle of size inSize through sorted
+// anchor pairs onto a circle of size outSize.
+func interpolateCircular(anchors [][2]float64, x, inSize, outSize float64) float64 {
+	n := len(anchors)
+	x = math.Mod(x, inSize)
+	if x < 0 {
+		x += inSize
+	}
+	for i := 0; i < n; i++ {
+		a, b := anchors[i], anchors[(i+1)%n]
+		ax, bx, ay, by := a[0], b[0], a[1], b[1]
+		if i == n-1 {
+			bx += inSize
+			by += outSize
+		}
+		xx := x
+		if xx < ax {
+			xx += inSize
+		}
+		if xx >= ax && xx <= bx {
+			if by < ay {
+				by += outSize
+			}
+			y := ay + (by-ay)*(xx-ax)/(bx-ax)
+			return math.Mod(y, outSize)
+		}
+	}
+	return 0
+}
+
+func approxMunsell(xyz XYZ) Munsell {
+	lab := xyzToHCL(xyz)
+	v := munsellYToValue(xyz.Y)
+	if lab.C < 0.5 {
+		return Munsell{V: v}
+	}
+	return Munsell{H: labHueToMunsell(lab.H), V: v, C: lab.C / munsellChromaScale}
+}
+
+// -------------------------------
+// Munsell → XYZ (D65)
+// -------------------------------
+func (c Munsell) ToXYZ() (XYZ, error) {
+	if !c.IsValid() {
+		return XYZ{}, errors.New("invalid Munsell")
+	}
+
+	if DefaultMunsell != nil {
+		xyY, err := DefaultMunsell.ToXYY(c)
+		if err != nil {
+			return XYZ{}, err
+		}
+		return xyY.ToXYZ().Adapt(WhiteC, WhiteD65), nil
+	}
+
+	Y := munsellValueToY(c.V)
+	L := XYZ{Y: Y}.ToLab(XYZ{X: 1, Y: 1, Z: 1}).L
+	hcl := HCL{H: munsellHueToLab(c.H), C: c.C * munsellChromaScale, L: L}
+	return NewColor(SpaceHCL, hcl.H, hcl.C, hcl.L).XYZ(), nil
+}
+
+// -------------------------------
+// XYZ (D65) → Munsell
+// -------------------------------
+func (c XYZ) ToMunsell() (Munsell, error) {
+	if DefaultMunsell != nil {
+		return DefaultMunsell.FromXYY(c.Adapt(WhiteD65, WhiteC).ToXYY())
+	}
+	return approxMunsell(c), nil
+}
+
+// -------------------------------
+// Munsell → RGB
+// -------------------------------
+func (c Munsell) ToRGB() (int, int, int, error) {
+	xyz, err := c.ToXYZ()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return xyz.ToRGB()
+}
+
+// -------------------------------
+// Munsell → HEX
+// -------------------------------
+func (c Munsell) ToHex() (string, error) {
+	r, g, b, err := c.ToRGB()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b), nil
+}
+
+// -------------------------------
+// Munsell → HCL
+// -------------------------------
+func (c Munsell) ToHCL() (h, cVal, l float64, err error) {
+	xyz, err := c.ToXYZ()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	hcl := xyzToHCL(xyz)
+	return hcl.H, hcl.C, hcl.L, nil
+}
+
+// -------------------------------
+// RGB → Munsell
+// -------------------------------
+func (c RGB) ToMunsell() (Munsell, error) {
+	xyz, err := c.ToXYZ()
+	if err != nil {
+		return Munsell{}, err
+	}
+	return xyz.ToMunsell()
+}
@@ -0,0 +1,99 @@
+package colors
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The renotation table is embedded from munsell_real.dat. Without it
+// every Munsell conversion silently uses the CIELAB approximation, so a
+// missing table fails the build's tests rather than shipping.
+func TestMunsellTableEmbedded(t *testing.T) {
+	if DefaultMunsell == nil {
+		t.Fatal("munsell_real.dat has no renotation rows; run go generate in utils/colors to fetch RIT's real.dat")
+	}
+}
+
+// renotationRow finds a row of the embedded real.dat.
+func renotationRow(t *testing.T, hue string, value, chroma float64) (x, y, Y float64) {
+	t.Helper()
+	scanner := bufio.NewScanner(bytes.NewReader(munsellReal))
+	for scanner.Scan() {
+		f := strings.Fields(scanner.Text())
+		if len(f) < 6 || f[0] != hue {
+			continue
+		}
+		v, _ := strconv.ParseFloat(f[1], 64)
+		c, _ := strconv.ParseFloat(f[2], 64)
+		if v != value || c != chroma {
+			continue
+		}
+		x, _ = strconv.ParseFloat(f[3], 64)
+		y, _ = strconv.ParseFloat(f[4], 64)
+		Y, _ = strconv.ParseFloat(f[5], 64)
+		return x, y, Y
+	}
+	t.Fatalf("no row %s %v/%v in munsell_real.dat", hue, value, chroma)
+	return
+}
+
+// Table rows are reproduced exactly; the luminance follows ASTM D1535,
+// which is the renotation Y (relative to MgO) scaled by 0.975.
+func TestMunsellRenotationRows(t *testing.T) {
+	if DefaultMunsell == nil {
+		t.Fatal("munsell_real.dat has no renotation rows")
+	}
+	for _, tc := range []struct {
+		notation string
+		hue      string
+		v, c     float64
+	}{
+		{"5R 4/14", "5R", 4, 14},
+		{"5PB 5/10", "5PB", 5, 10},
+	} {
+		x, y, Y := renotationRow(t, tc.hue, tc.v, tc.c)
+		m, err := ParseMunsell(tc.notation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := DefaultMunsell.ToXYY(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got.X-x) > 1e-9 || math.Abs(got.Y-y) > 1e-9 {
+			t.Errorf("%s: xy = (%.4f, %.4f), want (%.4f, %.4f)", tc.notation, got.X, got.Y, x, y)
+		}
+		if want := Y * 0.975 / 100; math.Abs(got.Lum-want) > 1e-3 {
+			t.Errorf("%s: Y = %.4f, want %.4f", tc.notation, got.Lum, want)
+		}
+	}
+}
+
+// Neutrals sit on Illuminant C at the ASTM D1535 luminance, with or
+// without a trailing chroma.
+func TestMunsellNeutral(t *testing.T) {
+	table, err := LoadMunsellRenotation(strings.NewReader("h V C x y Y\n5R 5 2 0.3 0.3 19.77\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"N 5/", "N 5/0"} {
+		m, err := ParseMunsell(s)
+		if err != nil {
+			t.Fatalf("ParseMunsell(%q): %v", s, err)
+		}
+		if m != (Munsell{V: 5}) {
+			t.Errorf("ParseMunsell(%q) = %+v", s, m)
+		}
+		got, err := table.ToXYY(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got.X-0.3101) > 1e-4 || math.Abs(got.Y-0.3162) > 1e-4 || math.Abs(got.Lum-0.1927) > 1e-4 {
+			t.Errorf("%s = %+v, want Illuminant C at Y 0.1927", s, got)
+		}
+	}
+}
@@ -46,36 +46,14 @@ func oklabToLinearRGB(L, a, b float64) (R, G, B float64) {
 	m_ := L_ - 0.1055613458*A - 0.0638541728*Bb
 	s_ := L_ - 0.0894841775*A - 1.2914855480*Bb
 
-	cbrt := math.Cbrt
-	R = 4.0767416621*cbrt(l_) - 3.3077115913*cbrt(m_) + 0.2309699292*cbrt(s_)
-	G = -1.2684380046*cbrt(l_) + 2.6097574011*cbrt(m_) - 0.3413193965*cbrt(s_)
-	B = -0.0041960863*cbrt(l_) - 0.7034186147*cbrt(m_) + 1.7076147010*cbrt(s_)
+	cube := func(x float64) float64 { return x * x * x }
+	R = 4.0767416621*cube(l_) - 3.3077115913*cube(m_) + 0.2309699292*cube(s_)
+	G = -1.2684380046*cube(l_) + 2.6097574011*cube(m_) - 0.3413193965*cube(s_)
+	B = -0.0041960863*cube(l_) - 0.7034186147*cube(m_) + 1.7076147010*cube(s_)
 
 	return
 }
 
-// -------------------------------
-// linear RGB → sRGB 0–255
-// -------------------------------
-func linearToRGB255(R, G, B float64) (r, g, b int) {
-	toSRGB := func(u float64) float64 {
-		if u <= 0 {
-			return 0
-		}
-		if u >= 1 {
-			return 1
-		}
-		if u <= 0.0031308 {
-			return 12.92 * u
-		}
-		return 1.055*math.Pow(u, 1/2.4) - 0.055
-	}
-	r = int(math.Round(toSRGB(R) * 255))
-	g = int(math.Round(toSRGB(G) * 255))
-	b = int(math.Round(toSRGB(B) * 255))
-	return
-}
-
 // -------------------------------
 // OKLCH → RGB
 // -------------------------------
@@ -86,7 +64,7 @@ func (c OKLCH) ToRGB() (r, g, b int, err error) {
 
 	L, a, b_ := c.ToOklab()
 	Rlin, Glin, Blin := oklabToLinearRGB(L, a, b_)
-	r, g, b = linearToRGB255(Rlin, Glin, Blin)
+	r, g, b = linearToRGB8(Rlin, Glin, Blin)
 	return r, g, b, nil
 }
 
@@ -0,0 +1,101 @@
+package colors
+
+import (
+	"math"
+	"sort"
+)
+
+// -------------------------------
+// Nelder–Mead minimiser
+// -------------------------------
+
+// nelderMead minimises f starting from x0 with an initial simplex of
+// the given step size. It is derivative-free, which suits the ΔE-based
+// objectives used by the pigment and palette solvers.
+func nelderMead(f func([]float64) float64, x0 []float64, step float64, maxIter int) ([]float64, float64) {
+	n := len(x0)
+	type vertex struct {
+		x []float64
+		v float64
+	}
+
+	simplex := make([]vertex, n+1)
+	simplex[0] = vertex{x: append([]float64(nil), x0...), v: f(x0)}
+	for i := 0; i < n; i++ {
+		x := append([]float64(nil), x0...)
+		x[i] += step
+		simplex[i+1] = vertex{x: x, v: f(x)}
+	}
+
+	point := func(centroid, towards []float64, coef float64) []float64 {
+		p := make([]float64, n)
+		for i := range p {
+			p[i] = centroid[i] + coef*(towards[i]-centroid[i])
+		}
+		return p
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		sort.Slice(simplex, func(i, j int) bool { return simplex[i].v < simplex[j].v })
+
+		if math.Abs(simplex[n].v-simplex[0].v) < 1e-10 {
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, s := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += s.x[i] / float64(n)
+			}
+		}
+
+		worst := simplex[n]
+		reflected := point(centroid, worst.x, -1)
+		rv := f(reflected)
+
+		switch {
+		case rv < simplex[0].v:
+			expanded := point(centroid, worst.x, -2)
+			if ev := f(expanded); ev < rv {
+				simplex[n] = vertex{x: expanded, v: ev}
+			} else {
+				simplex[n] = vertex{x: reflected, v: rv}
+			}
+		case rv < simplex[n-1].v:
+			simplex[n] = vertex{x: reflected, v: rv}
+		default:
+			contracted := point(centroid, worst.x, 0.5)
+			if cv := f(contracted); cv < worst.v {
+				simplex[n] = vertex{x: contracted, v: cv}
+				continue
+			}
+			// shrink towards the best vertex
+			for k := 1; k <= n; k++ {
+				x := point(simplex[0].x, simplex[k].x, 0.5)
+				simplex[k] = vertex{x: x, v: f(x)}
+			}
+		}
+	}
+
+	sort.Slice(simplex, func(i, j int) bool { return simplex[i].v < simplex[j].v })
+	return simplex[0].x, simplex[0].v
+}
+
+// softmax maps unconstrained logits to non-negative weights summing
+// to 1, used to keep mixing proportions on the simplex.
+func softmax(logits []float64) []float64 {
+	maxV := math.Inf(-1)
+	for _, v := range logits {
+		maxV = math.Max(maxV, v)
+	}
+	out := make([]float64, len(logits))
+	sum := 0.0
+	for i, v := range logits {
+		out[i] = math.Exp(v - maxV)
+		sum += out[i]
+	}
+	for i := range out {
+		out[i] /= sum
+	}
+	return out
+}
@@ -0,0 +1,285 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// CSS colour parsing
+// -------------------------------
+
+// Parse reads a CSS colour: hex (#rgb, #rgba, #rrggbb, #rrggbbaa), named
+// colours, rgb()/rgba(), hsl()/hsla(), hwb(), lab(), lch(), oklab(),
+// oklch() and color(<space> …), in modern or legacy comma syntax. It
+// also accepts hcl(h c l) and cmyk(c m y k) / device-cmyk() as used
+// elsewhere in this package.
+func Parse(s string) (Color, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	if in == "" {
+		return Color{}, fmt.Errorf("empty color")
+	}
+
+	if hex, ok := named[in]; ok {
+		return parseHex(hex)
+	}
+	if in == "transparent" {
+		return Color{Space: SpaceSRGB}, nil
+	}
+	if strings.HasPrefix(in, "#") {
+		return parseHex(in[1:])
+	}
+
+	open := strings.IndexByte(in, '(')
+	if open < 0 || !strings.HasSuffix(in, ")") {
+		// bare hex such as FF5733
+		if len(in) == 6 || len(in) == 8 {
+			if c, err := parseHex(in); err == nil {
+				return c, nil
+			}
+		}
+		return Color{}, fmt.Errorf("unrecognised color %q", s)
+	}
+
+	fn := strings.TrimSpace(in[:open])
+	args, alpha, err := splitArgs(in[open+1 : len(in)-1])
+	if err != nil {
+		return Color{}, fmt.Errorf("%s(): %w", fn, err)
+	}
+
+	c, err := parseFunction(fn, args, alpha)
+	if err != nil {
+		return Color{}, fmt.Errorf("%s(): %w", fn, err)
+	}
+	return c, nil
+}
+
+// MustParse is Parse for constants known to be valid.
+func MustParse(s string) Color {
+	c, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func parseFunction(fn string, args []string, alpha string) (Color, error) {
+	// legacy syntax carries alpha as a fourth comma-separated value
+	if alpha == "" && len(args) == 4 && fn != "cmyk" && fn != "device-cmyk" && fn != "color" {
+		args, alpha = args[:3], args[3]
+	}
+
+	var c Color
+	var err error
+	switch fn {
+	case "rgb", "rgba":
+		c, err = components(SpaceSRGB, args, number(255), number(255), number(255))
+		for i := 0; i < 3; i++ {
+			c.V[i] /= 255
+		}
+	case "hsl", "hsla":
+		c, err = components(SpaceHSL, args, hue, number(100), number(100))
+	case "hwb":
+		c, err = components(SpaceHWB, args, hue, number(100), number(100))
+	case "lab":
+		c, err = components(SpaceLab, args, number(100), number(125), number(125))
+	case "lch":
+		c, err = components(SpaceLCH, args, number(100), number(150), hue)
+	case "oklab":
+		c, err = components(SpaceOklab, args, number(1), number(0.4), number(0.4))
+	case "oklch":
+		c, err = components(SpaceOKLCH, args, number(1), number(0.4), hue)
+	case "hcl":
+		c, err = components(SpaceHCL, args, hue, number(150), number(100))
+	case "cmyk":
+		c, err = components(SpaceCMYK, args, number(100), number(100), number(100), number(100))
+	case "device-cmyk":
+		c, err = components(SpaceCMYK, args, number(1), number(1), number(1), number(1))
+		for i := range c.V {
+			c.V[i] *= 100
+		}
+	case "color":
+		if len(args) == 0 {
+			return Color{}, fmt.Errorf("missing color space")
+		}
+		space, perr := ParseSpace(args[0])
+		_, isRGB := space.rgbSpace()
+		if perr != nil || !(isRGB || space == SpaceXYZD65 || space == SpaceXYZD50) {
+			return Color{}, fmt.Errorf("unsupported color() space %q", args[0])
+		}
+		c, err = components(space, args[1:], number(1), number(1), number(1))
+	default:
+		return Color{}, fmt.Errorf("unknown color function")
+	}
+	if err != nil {
+		return Color{}, err
+	}
+
+	c.Alpha = 1
+	if alpha != "" {
+		a, err := number(1)(alpha)
+		if err != nil {
+			return Color{}, fmt.Errorf("alpha: %w", err)
+		}
+		c.Alpha = math.Max(0, math.Min(1, a))
+	}
+	return c, nil
+}
+
+type componentParser func(string) (float64, error)
+
+// components parses one value per parser.
+func components(space Space, args []string, parsers ...componentParser) (Color, error) {
+	if len(args) != len(parsers) {
+		return Color{}, fmt.Errorf("expected %d values, got %d", len(parsers), len(args))
+	}
+	c := Color{Space: space}
+	for i, p := range parsers {
+		v, err := p(args[i])
+		if err != nil {
+			return Color{}, err
+		}
+		c.V[i] = v
+	}
+	return c, nil
+}
+
+// number parses a number, or a percentage of ref. "none" is zero.
+func number(ref float64) componentParser {
+	return func(s string) (float64, error) {
+		if s == "none" {
+			return 0, nil
+		}
+		if p, ok := strings.CutSuffix(s, "%"); ok {
+			v, err := strconv.ParseFloat(p, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid percentage %q", s)
+			}
+			return v / 100 * ref, nil
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		return v, nil
+	}
+}
+
+// hue parses an angle in deg (default), rad, grad or turn, normalised
+// to 0–360.
+func hue(s string) (float64, error) {
+	if s == "none" {
+		return 0, nil
+	}
+	scale := 1.0
+	for _, u := range []struct {
+		suffix string
+		scale  float64
+	}{{"deg", 1}, {"grad", 0.9}, {"rad", 180 / math.Pi}, {"turn", 360}} {
+		if p, ok := strings.CutSuffix(s, u.suffix); ok {
+			s, scale = p, u.scale
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hue %q", s)
+	}
+	v = math.Mod(v*scale, 360)
+	if v < 0 {
+		v += 360
+	}
+	return v, nil
+}
+
+// splitArgs separates function arguments on commas or whitespace and
+// returns the alpha given after "/" separately.
+func splitArgs(body string) (args []string, alpha string, err error) {
+	parts := strings.Split(body, "/")
+	if len(parts) > 2 {
+		return nil, "", fmt.Errorf("more than one '/'")
+	}
+	if len(parts) == 2 {
+		alpha = strings.TrimSpace(parts[1])
+		if alpha == "" || strings.ContainsAny(alpha, " ,") {
+			return nil, "", fmt.Errorf("invalid alpha %q", parts[1])
+		}
+	}
+	args = strings.FieldsFunc(parts[0], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	return args, alpha, nil
+}
+
+// parseHex reads 3, 4, 6 or 8 hex digits.
+func parseHex(h string) (Color, error) {
+	switch len(h) {
+	case 3, 4:
+		var b strings.Builder
+		for _, r := range h {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		h = b.String()
+	case 6, 8:
+	default:
+		return Color{}, fmt.Errorf("invalid hex color %q", "#"+h)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hex color %q", "#"+h)
+	}
+	alpha := 1.0
+	if len(h) == 8 {
+		alpha = float64(v&0xFF) / 255
+		v >>= 8
+	}
+	c := NewColor(SpaceSRGB, float64(v>>16&0xFF)/255, float64(v>>8&0xFF)/255, float64(v&0xFF)/255)
+	c.Alpha = alpha
+	return c, nil
+}
+
+// -------------------------------
+// CSS named colours
+// -------------------------------
+var named = map[string]string{
+	"aliceblue": "f0f8ff", "antiquewhite": "faebd7", "aqua": "00ffff", "aquamarine": "7fffd4",
+	"azure": "f0ffff", "beige": "f5f5dc", "bisque": "ffe4c4", "black": "000000",
+	"blanchedalmond": "ffebcd", "blue": "0000ff", "blueviolet": "8a2be2", "brown": "a52a2a",
+	"burlywood": "deb887", "cadetblue": "5f9ea0", "chartreuse": "7fff00", "chocolate": "d2691e",
+	"coral": "ff7f50", "cornflowerblue": "6495ed", "cornsilk": "fff8dc", "crimson": "dc143c",
+	"cyan": "00ffff", "darkblue": "00008b", "darkcyan": "008b8b", "darkgoldenrod": "b8860b",
+	"darkgray": "a9a9a9", "darkgreen": "006400", "darkgrey": "a9a9a9", "darkkhaki": "bdb76b",
+	"darkmagenta": "8b008b", "darkolivegreen": "556b2f", "darkorange": "ff8c00", "darkorchid": "9932cc",
+	"darkred": "8b0000", "darksalmon": "e9967a", "darkseagreen": "8fbc8f", "darkslateblue": "483d8b",
+	"darkslategray": "2f4f4f", "darkslategrey": "2f4f4f", "darkturquoise": "00ced1", "darkviolet": "9400d3",
+	"deeppink": "ff1493", "deepskyblue": "00bfff", "dimgray": "696969", "dimgrey": "696969",
+	"dodgerblue": "1e90ff", "firebrick": "b22222", "floralwhite": "fffaf0", "forestgreen": "228b22",
+	"fuchsia": "ff00ff", "gainsboro": "dcdcdc", "ghostwhite": "f8f8ff", "gold": "ffd700",
+	"goldenrod": "daa520", "gray": "808080", "green": "008000", "greenyellow": "adff2f",
+	"grey": "808080", "honeydew": "f0fff0", "hotpink": "ff69b4", "indianred": "cd5c5c",
+	"indigo": "4b0082", "ivory": "fffff0", "khaki": "f0e68c", "lavender": "e6e6fa",
+	"lavenderblush": "fff0f5", "lawngreen": "7cfc00", "lemonchiffon": "fffacd", "lightblue": "add8e6",
+	"lightcoral": "f08080", "lightcyan": "e0ffff", "lightgoldenrodyellow": "fafad2", "lightgray": "d3d3d3",
+	"lightgreen": "90ee90", "lightgrey": "d3d3d3", "lightpink": "ffb6c1", "lightsalmon": "ffa07a",
+	"lightseagreen": "20b2aa", "lightskyblue": "87cefa", "lightslategray": "778899", "lightslategrey": "778899",
+	"lightsteelblue": "b0c4de", "lightyellow": "ffffe0", "lime": "00ff00", "limegreen": "32cd32",
+	"linen": "faf0e6", "magenta": "ff00ff", "maroon": "800000", "mediumaquamarine": "66cdaa",
+	"mediumblue": "0000cd", "mediumorchid": "ba55d3", "mediumpurple": "9370db", "mediumseagreen": "3cb371",
+	"mediumslateblue": "7b68ee", "mediumspringgreen": "00fa9a", "mediumturquoise": "48d1cc", "mediumvioletred": "c71585",
+	"midnightblue": "191970", "mintcream": "f5fffa", "mistyrose": "ffe4e1", "moccasin": "ffe4b5",
+	"navajowhite": "ffdead", "navy": "000080", "oldlace": "fdf5e6", "olive": "808000",
+	"olivedrab": "6b8e23", "orange": "ffa500", "orangered": "ff4500", "orchid": "da70d6",
+	"palegoldenrod": "eee8aa", "palegreen": "98fb98", "paleturquoise": "afeeee", "palevioletred": "db7093",
+	"papayawhip": "ffefd5", "peachpuff": "ffdab9", "peru": "cd853f", "pink": "ffc0cb",
+	"plum": "dda0dd", "powderblue": "b0e0e6", "purple": "800080", "rebeccapurple": "663399",
+	"red": "ff0000", "rosybrown": "bc8f8f", "royalblue": "4169e1", "saddlebrown": "8b4513",
+	"salmon": "fa8072", "sandybrown": "f4a460", "seagreen": "2e8b57", "seashell": "fff5ee",
+	"sienna": "a0522d", "silver": "c0c0c0", "skyblue": "87ceeb", "slateblue": "6a5acd",
+	"slategray": "708090", "slategrey": "708090", "snow": "fffafa", "springgreen": "00ff7f",
+	"steelblue": "4682b4", "tan": "d2b48c", "teal": "008080", "thistle": "d8bfd8",
+	"tomato": "ff6347", "turquoise": "40e0d0", "violet": "ee82ee", "wheat": "f5deb3",
+	"white": "ffffff", "whitesmoke": "f5f5f5", "yellow": "ffff00", "yellowgreen": "9acd32",
+}
@@ -0,0 +1,103 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		space Space
+		v     [4]float64
+		alpha float64
+	}{
+		// named colours
+		{"red", SpaceSRGB, [4]float64{1, 0, 0}, 1},
+		{"  RebeccaPurple ", SpaceSRGB, [4]float64{0x66 / 255.0, 0x33 / 255.0, 0x99 / 255.0}, 1},
+		{"transparent", SpaceSRGB, [4]float64{}, 0},
+
+		// hex lengths
+		{"#f00", SpaceSRGB, [4]float64{1, 0, 0}, 1},
+		{"#f008", SpaceSRGB, [4]float64{1, 0, 0}, 0x88 / 255.0},
+		{"#FF5733", SpaceSRGB, [4]float64{1, 0x57 / 255.0, 0x33 / 255.0}, 1},
+		{"#ff573380", SpaceSRGB, [4]float64{1, 0x57 / 255.0, 0x33 / 255.0}, 0x80 / 255.0},
+		{"FF5733", SpaceSRGB, [4]float64{1, 0x57 / 255.0, 0x33 / 255.0}, 1},
+
+		// rgb()
+		{"rgb(255 0 0)", SpaceSRGB, [4]float64{1, 0, 0}, 1},
+		{"rgb(255, 128, 0)", SpaceSRGB, [4]float64{1, 128 / 255.0, 0}, 1},
+		{"rgba(255, 0, 0, 0.5)", SpaceSRGB, [4]float64{1, 0, 0}, 0.5},
+		{"rgb(100% 50% 0% / 25%)", SpaceSRGB, [4]float64{1, 0.5, 0}, 0.25},
+		{"rgb(none 255 none)", SpaceSRGB, [4]float64{0, 1, 0}, 1},
+		{"rgb(0 0 0 / 2)", SpaceSRGB, [4]float64{}, 1},
+
+		// hsl() and hwb()
+		{"hsl(120 100% 50%)", SpaceHSL, [4]float64{120, 100, 50}, 1},
+		{"hsla(120deg, 100%, 50%, 0.3)", SpaceHSL, [4]float64{120, 100, 50}, 0.3},
+		{"hsl(-90 50 50)", SpaceHSL, [4]float64{270, 50, 50}, 1},
+		{"hsl(none 0% 40%)", SpaceHSL, [4]float64{0, 0, 40}, 1},
+		{"hwb(0.5turn 10% 20%)", SpaceHWB, [4]float64{180, 10, 20}, 1},
+		{"hwb(200grad none 0%)", SpaceHWB, [4]float64{180, 0, 0}, 1},
+
+		// lab() and oklch()
+		{"lab(50% 40 -20)", SpaceLab, [4]float64{50, 40, -20}, 1},
+		{"lab(50 100% -100%)", SpaceLab, [4]float64{50, 125, -125}, 1},
+		{"lch(50 30 400)", SpaceLCH, [4]float64{50, 30, 40}, 1},
+		{"oklch(70% 0.1 none)", SpaceOKLCH, [4]float64{0.7, 0.1, 0}, 1},
+		{"oklch(0.5 50% 3.14159265358979rad / none)", SpaceOKLCH, [4]float64{0.5, 0.2, 180}, 0},
+		{"oklab(1 -100% 0.1)", SpaceOklab, [4]float64{1, -0.4, 0.1}, 1},
+
+		// color(), hcl() and cmyk()
+		{"color(display-p3 1 0 0)", SpaceDisplayP3, [4]float64{1, 0, 0}, 1},
+		{"color(xyz-d50 0.5 50% 0)", SpaceXYZD50, [4]float64{0.5, 0.5, 0}, 1},
+		{"hcl(30 80 50)", SpaceHCL, [4]float64{30, 80, 50}, 1},
+		{"cmyk(0 66 80 0)", SpaceCMYK, [4]float64{0, 66, 80, 0}, 1},
+		{"device-cmyk(0 0.5 100% 0)", SpaceCMYK, [4]float64{0, 50, 100, 0}, 1},
+	} {
+		got, err := Parse(tc.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.in, err)
+			continue
+		}
+		ok := got.Space == tc.space && math.Abs(got.Alpha-tc.alpha) < 1e-9
+		for i := range tc.v {
+			ok = ok && math.Abs(got.V[i]-tc.v[i]) < 1e-9
+		}
+		if !ok {
+			t.Errorf("Parse(%q) = %v %v / %v, want %v %v / %v", tc.in, got.Space, got.V, got.Alpha, tc.space, tc.v, tc.alpha)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"notacolor",
+		"#12",
+		"#12345",
+		"#1234567",
+		"#ggg",
+		"12345",
+		"rgb(1 2)",
+		"rgb(1 2 3 4 5)",
+		"rgb(1 2 3 / 0.5 / 1)",
+		"rgb(1 2 3 / )",
+		"rgb(1 2 3 / 0 5)",
+		"rgb(a b c)",
+		"rgb(1 2 3",
+		"hsl(xdeg 50% 50%)",
+		"hsl(10 50%% 50%)",
+		"oklch(0.5 0.1 1.5lightyears)",
+		"foo(1 2 3)",
+		"color(cmyk 1 2 3)",
+		"color()",
+		"cmyk(1 2 3)",
+		"lab(1 2 3 / x)",
+	} {
+		if c, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, c)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package colors
+
+import (
+	"errors"
+	"math"
+)
+
+// -------------------------------
+// Pigment (Kubelka–Munk)
+// -------------------------------
+type Pigment struct {
+	Name string
+	K    Spectrum // absorption coefficient
+	S    Spectrum // scattering coefficient
+}
+
+// PigmentSet is a palette of colorants that can be mixed together.
+type PigmentSet []Pigment
+
+// pigmentCurve builds a smooth absorption curve from a baseline and
+// Gaussian bands given as {centre nm, width nm, peak}.
+func pigmentCurve(base float64, bands ...[3]float64) Spectrum {
+	var s Spectrum
+	for i := range s {
+		nm := s.Wavelength(i)
+		s[i] = base
+		for _, b := range bands {
+			d := (nm - b[0]) / b[1]
+			s[i] += b[2] * math.Exp(-0.5*d*d)
+		}
+	}
+	return s
+}
+
+func flatCurve(v float64) Spectrum {
+	var s Spectrum
+	for i := range s {
+		s[i] = v
+	}
+	return s
+}
+
+// BasePigments approximates a typical artist's palette. The K and S
+// curves are synthetic: each is a baseline plus Gaussian absorption
+// bands placed where the real pigment absorbs, not measured data, so
+// masstones and mixtures are plausible rather than accurate. Relative
+// tinting strength is set by scaling K and S together (which keeps the
+// masstone); Hansa Yellow is scaled up so blue + yellow gives green.
+var BasePigments = PigmentSet{
+	{Name: "Titanium White", K: pigmentCurve(0.002, [3]float64{380, 12, 0.5}), S: flatCurve(1)},
+	{Name: "Carbon Black", K: flatCurve(8), S: flatCurve(0.1)},
+	{Name: "Ultramarine Blue", K: pigmentCurve(0.03, [3]float64{590, 65, 7}), S: flatCurve(0.3)},
+	{Name: "Phthalo Blue", K: pigmentCurve(0.05, [3]float64{625, 50, 9}, [3]float64{690, 45, 6}), S: flatCurve(0.2)},
+	{Name: "Quinacridone Magenta", K: pigmentCurve(0.03, [3]float64{545, 35, 9}, [3]float64{495, 22, 2}), S: flatCurve(0.3)},
+	{Name: "Hansa Yellow", K: pigmentCurve(0.03, [3]float64{405, 35, 24}, [3]float64{450, 15, 9}), S: flatCurve(1.5)},
+	{Name: "Pyrrole Red", K: pigmentCurve(0.02, [3]float64{440, 50, 6}, [3]float64{525, 32, 10}), S: flatCurve(0.6)},
+	{Name: "Phthalo Green", K: pigmentCurve(0.04, [3]float64{430, 35, 5}, [3]float64{640, 60, 10}), S: flatCurve(0.3)},
+}
+
+// -------------------------------
+// Concentrations → reflectance
+// -------------------------------
+
+// Reflectance returns the Kubelka–Munk reflectance of an opaque layer
+// mixing the pigments in the given (relative) concentrations.
+func (set PigmentSet) Reflectance(conc []float64) Spectrum {
+	var R Spectrum
+	for i := range R {
+		var K, S float64
+		for j, p := range set {
+			K += conc[j] * p.K[i]
+			S += conc[j] * p.S[i]
+		}
+		if S <= 0 {
+			S = 1e-9
+		}
+		ks := K / S
+		R[i] = 1 + ks - math.Sqrt(ks*ks+2*ks)
+	}
+	return R
+}
+
+// spectrumToLinearRGB renders a reflectance under D65 into linear sRGB
+// (unclipped).
+func spectrumToLinearRGB(s Spectrum) [3]float64 {
+	xyz := s.Render(IlluminantD65).Adapt(IlluminantD65.White(), WhiteD65)
+	R, G, B := xyzToLinearRGB(xyz.X, xyz.Y, xyz.Z)
+	return [3]float64{R, G, B}
+}
+
+func rgbToLinear(c RGB) [3]float64 {
+	return [3]float64{
+		linearize(float64(c.R) / 255),
+		linearize(float64(c.G) / 255),
+		linearize(float64(c.B) / 255),
+	}
+}
+
+func linearToLab(lin [3]float64) Lab {
+	X := 0.4124564*lin[0] + 0.3575761*lin[1] + 0.1804375*lin[2]
+	Y := 0.2126729*lin[0] + 0.7151522*lin[1] + 0.0721750*lin[2]
+	Z := 0.0193339*lin[0] + 0.1191920*lin[1] + 0.9503041*lin[2]
+	return XYZ{X: X, Y: Y, Z: Z}.ToLab(WhiteD65)
+}
+
+// -------------------------------
+// RGB → concentrations
+// -------------------------------
+
+// Unmix finds pigment concentrations (non-negative, summing to 1) whose
+// mixture best matches the colour in CIELAB.
+func (set PigmentSet) Unmix(c RGB) ([]float64, error) {
+	if !c.IsValid() {
+		return nil, errors.New("invalid RGB value")
+	}
+	if len(set) == 0 {
+		return nil, errors.New("empty pigment set")
+	}
+
+	conc, _ := set.fit(linearToLab(rgbToLinear(c)), DeltaE76)
+	return conc, nil
+}
+
+// fit minimises the colour difference between the mixture and the
+// target over the simplex of concentrations.
+func (set PigmentSet) fit(target Lab, metric func(a, b Lab) float64) ([]float64, float64) {
+	cost := func(logits []float64) float64 {
+		lin := spectrumToLinearRGB(set.Reflectance(softmax(logits)))
+		return metric(linearToLab(lin), target)
+	}
+
+	best, bestV := nelderMead(cost, make([]float64, len(set)), 2, 400*len(set))
+	// restart from each pure pigment to escape poor local minima
+	for i := range set {
+		x0 := make([]float64, len(set))
+		x0[i] = 4
+		if x, v := nelderMead(cost, x0, 2, 400*len(set)); v < bestV {
+			best, bestV = x, v
+		}
+	}
+	return softmax(best), bestV
+}
+
+// -------------------------------
+// Recipe solver
+// -------------------------------
+
+// PigmentFromReflectance builds a single-constant Kubelka–Munk colorant
+// (S = 1) from the measured masstone reflectance of an ink or paint.
+func PigmentFromReflectance(name string, r Spectrum) Pigment {
+	p := Pigment{Name: name, S: flatCurve(1)}
+	for i, v := range r {
+		v = math.Min(math.Max(v, 1e-4), 1)
+		p.K[i] = (1 - v) * (1 - v) / (2 * v)
+	}
+	return p
+}
+
+// PigmentFromRGB estimates a colorant from its sRGB masstone by
+// upsampling it to a reflectance spectrum.
+func PigmentFromRGB(name string, c RGB) (Pigment, error) {
+	r, err := UpsampleRGB(c)
+	if err != nil {
+		return Pigment{}, err
+	}
+	return PigmentFromReflectance(name, r), nil
+}
+
+// Recipe is the result of solving for a mixture of base colorants.
+type Recipe struct {
+	Proportions []float64 // same order as the set, summing to 1
+	Result      RGB       // colour of the predicted mixture
+	DeltaE      float64   // CIEDE2000 between Result and the target
+}
+
+// Recipe finds the proportions of the set's colorants whose
+// Kubelka–Munk mixture minimises CIEDE2000 to the target.
+func (set PigmentSet) Recipe(target RGB) (Recipe, error) {
+	if !target.IsValid() {
+		return Recipe{}, errors.New("invalid RGB value")
+	}
+	if len(set) == 0 {
+		return Recipe{}, errors.New("empty pigment set")
+	}
+
+	conc, dE := set.fit(linearToLab(rgbToLinear(target)), DeltaE2000)
+	lin := spectrumToLinearRGB(set.Reflectance(conc))
+	r, g, b := linearToRGB8(lin[0], lin[1], lin[2])
+	return Recipe{Proportions: conc, Result: RGB{R: r, G: g, B: b}, DeltaE: dE}, nil
+}
+
+// -------------------------------
+// Pigment mixing
+// -------------------------------
+
+// MixPigment mixes two sRGB colours as if they were paints, using
+// Kubelka–Munk on the base pigment palette: each colour is unmixed into
+// pigment concentrations, the concentrations are interpolated, and the
+// mixture's reflectance is rendered under D65.
+//
+// The result is the mixture of the pigment matches, so t = 0 and t = 1
+// give each input as closely as the palette reproduces it rather than
+// exactly (a saturated #0000FF is outside the palette's gamut).
+func MixPigment(a, b RGB, t float64) (RGB, error) {
+	if t < 0 || t > 1 {
+		return RGB{}, errors.New("mix amount must be between 0 and 1")
+	}
+
+	ca, err := BasePigments.Unmix(a)
+	if err != nil {
+		return RGB{}, err
+	}
+	cb, err := BasePigments.Unmix(b)
+	if err != nil {
+		return RGB{}, err
+	}
+
+	conc := make([]float64, len(BasePigments))
+	for i := range conc {
+		conc[i] = (1-t)*ca[i] + t*cb[i]
+	}
+	mixed := spectrumToLinearRGB(BasePigments.Reflectance(conc))
+	r, g, bl := linearToRGB8(mixed[0], mixed[1], mixed[2])
+	return RGB{R: r, G: g, B: bl}, nil
+}
+
+// MixRGB linearly interpolates two colours channel by channel in
+// gamma-encoded sRGB.
+func MixRGB(a, b RGB, t float64) (RGB, error) {
+	if !a.IsValid() || !b.IsValid() {
+		return RGB{}, errors.New("invalid RGB value")
+	}
+	if t < 0 || t > 1 {
+		return RGB{}, errors.New("mix amount must be between 0 and 1")
+	}
+	lerp := func(x, y int) int {
+		return int(math.Round(float64(x)*(1-t) + float64(y)*t))
+	}
+	return RGB{R: lerp(a.R, b.R), G: lerp(a.G, b.G), B: lerp(a.B, b.B)}, nil
+}
@@ -0,0 +1,80 @@
+package colors
+
+import "testing"
+
+func TestMixPigmentBlueYellowIsGreen(t *testing.T) {
+	got, err := MixPigment(RGB{B: 255}, RGB{R: 255, G: 255}, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewColor(SpaceSRGB, float64(got.R)/255, float64(got.G)/255, float64(got.B)/255).To(SpaceOKLCH).V[2]
+	if h < 120 || h > 165 {
+		t.Errorf("blue + yellow = %+v, OKLCH hue %.1f; want a green (120–165)", got, h)
+	}
+}
+
+// At t = 0 and t = 1 the mix is the Kubelka–Munk render of each input's
+// pigment match.
+func TestMixPigmentEndpoints(t *testing.T) {
+	a, b := RGB{B: 255}, RGB{R: 255, G: 255}
+	for _, tc := range []struct {
+		t float64
+		c RGB
+	}{{0, a}, {1, b}} {
+		conc, err := BasePigments.Unmix(tc.c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lin := spectrumToLinearRGB(BasePigments.Reflectance(conc))
+		r, g, bl := linearToRGB8(lin[0], lin[1], lin[2])
+		want := RGB{R: r, G: g, B: bl}
+
+		got, err := MixPigment(a, b, tc.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("MixPigment(t=%v) = %+v, want K/S render %+v", tc.t, got, want)
+		}
+	}
+}
+
+// K/S of the mixture is a ratio of functions linear in t, so the
+// reflectance at every wavelength moves monotonically from one
+// endpoint to the other.
+func TestPigmentReflectanceMonotonic(t *testing.T) {
+	ca, err := BasePigments.Unmix(RGB{B: 255})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := BasePigments.Unmix(RGB{R: 255, G: 255})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const steps = 20
+	curves := make([]Spectrum, steps+1)
+	for s := range curves {
+		f := float64(s) / steps
+		conc := make([]float64, len(BasePigments))
+		for i := range conc {
+			conc[i] = (1-f)*ca[i] + f*cb[i]
+		}
+		curves[s] = BasePigments.Reflectance(conc)
+	}
+
+	const eps = 1e-12
+	for w := range curves[0] {
+		up := curves[steps][w] >= curves[0][w]
+		for s := 1; s <= steps; s++ {
+			prev, cur := curves[s-1][w], curves[s][w]
+			if cur < 0 || cur > 1 {
+				t.Fatalf("band %d step %d: reflectance %v outside 0–1", w, s, cur)
+			}
+			if (up && cur < prev-eps) || (!up && cur > prev+eps) {
+				t.Errorf("band %d: reflectance not monotonic at step %d (%v → %v)", w, s, prev, cur)
+				break
+			}
+		}
+	}
+}
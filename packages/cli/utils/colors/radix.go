@@ -0,0 +1,77 @@
+package colors
+
+import "math"
+
+// -------------------------------
+// Radix-style 12-step scales
+// -------------------------------
+
+// RadixRoles names what each of the 12 steps is for.
+var RadixRoles = [12]string{
+	"app background",
+	"subtle background",
+	"element background",
+	"hovered element background",
+	"active element background",
+	"subtle border",
+	"element border",
+	"hovered element border",
+	"solid background",
+	"hovered solid background",
+	"low-contrast text",
+	"high-contrast text",
+}
+
+// RadixStep is one step of a 12-step scale.
+type RadixStep struct {
+	Step  int    // 1–12
+	Role  string // see RadixRoles
+	Color Color  // opaque, OKLCH, within the sRGB gamut
+	Alpha Color  // translucent sRGB giving Color over the background
+}
+
+// OKLCH lightness of steps 1–8, 11 and 12 (9 is the base colour and 10
+// is derived from it), and chroma as a share of the base chroma, after
+// Radix Colors' light and dark scales.
+var (
+	radixLightL = [12]float64{0.993, 0.982, 0.96, 0.936, 0.906, 0.87, 0.82, 0.75, 0, 0, 0.55, 0.32}
+	radixLightC = [12]float64{0.03, 0.06, 0.13, 0.21, 0.3, 0.39, 0.5, 0.66, 1, 0.95, 0.85, 0.47}
+	radixDarkL  = [12]float64{0.18, 0.205, 0.26, 0.3, 0.34, 0.385, 0.44, 0.51, 0, 0, 0.78, 0.92}
+	radixDarkC  = [12]float64{0.1, 0.15, 0.3, 0.45, 0.5, 0.52, 0.55, 0.65, 1, 0.97, 0.7, 0.25}
+)
+
+// Radix backgrounds the alpha steps composite over.
+var (
+	RadixLightBackground = NewColor(SpaceSRGB, 1, 1, 1)
+	RadixDarkBackground  = NewColor(SpaceSRGB, 0x11/255.0, 0x11/255.0, 0x11/255.0)
+)
+
+// RadixScale builds the 12 steps around base, which becomes step 9, in
+// OKLCH at base's hue. Step 10 is step 9 darkened (lightened in dark
+// mode) for hover. Each step also gets the alpha colour that shows the
+// same over background, solved with SolveAlpha.
+func RadixScale(base Color, dark bool, background Color) []RadixStep {
+	b := base.MapToSRGB().To(SpaceOKLCH)
+	lightness, chroma := radixLightL, radixLightC
+	hover := -0.03
+	if dark {
+		lightness, chroma = radixDarkL, radixDarkC
+		hover = 0.04
+	}
+
+	steps := make([]RadixStep, 12)
+	for i := range steps {
+		c := b
+		switch i {
+		case 8:
+		case 9:
+			c.V[0] = math.Max(0, math.Min(1, b.V[0]+hover))
+		default:
+			c.V[0] = lightness[i]
+		}
+		c.V[1] = b.V[1] * chroma[i]
+		c = c.MapToSRGB().To(SpaceOKLCH)
+		steps[i] = RadixStep{Step: i + 1, Role: RadixRoles[i], Color: c, Alpha: SolveAlpha(c, background)}
+	}
+	return steps
+}
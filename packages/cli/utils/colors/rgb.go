@@ -110,9 +110,7 @@ func (c RGB) ToHCL() (h, cVal, l float64, err error) {
 	}
 
 	// RGB → XYZ
-	R := linearize(float64(c.R) / 255)
-	G := linearize(float64(c.G) / 255)
-	B := linearize(float64(c.B) / 255)
+	R, G, B := rgb8ToLinear(c.R, c.G, c.B)
 
 	X := 0.4124564*R + 0.3575761*G + 0.1804375*B
 	Y := 0.2126729*R + 0.7151522*G + 0.0721750*B
@@ -151,16 +149,7 @@ func (c RGB) ToOKLCH() (L, C, H float64, err error) {
 	}
 
 	// RGB → linear RGB
-	toLinear := func(v float64) float64 {
-		v = v / 255
-		if v <= 0.04045 {
-			return v / 12.92
-		}
-		return math.Pow((v+0.055)/1.055, 2.4)
-	}
-	R := toLinear(float64(c.R))
-	G := toLinear(float64(c.G))
-	B := toLinear(float64(c.B))
+	R, G, B := rgb8ToLinear(c.R, c.G, c.B)
 
 	// linear RGB → LMS
 	l_ := 0.4122214708*R + 0.5363325363*G + 0.0514459929*B
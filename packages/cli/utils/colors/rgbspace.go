@@ -0,0 +1,204 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// Transfer function (ICC parametric type 3)
+// -------------------------------
+
+// TransferFunction decodes an encoded channel value X to linear light:
+// Y = (A·X + B)^Gamma for X ≥ D, else C·X.
+type TransferFunction struct {
+	Name  string
+	Gamma float64
+	A     float64
+	B     float64
+	C     float64
+	D     float64
+}
+
+var (
+	TransferSRGB   = TransferFunction{Name: "srgb", Gamma: 2.4, A: 1 / 1.055, B: 0.055 / 1.055, C: 1 / 12.92, D: 0.04045}
+	TransferRec709 = TransferFunction{Name: "rec709", Gamma: 1 / 0.45, A: 1 / 1.099, B: 0.099 / 1.099, C: 1 / 4.5, D: 0.081}
+	TransferLinear = TransferFunction{Name: "linear", Gamma: 1, A: 1}
+)
+
+// GammaTransfer is a pure power law.
+func GammaTransfer(g float64) TransferFunction {
+	return TransferFunction{Name: "gamma:" + strconv.FormatFloat(g, 'f', -1, 64), Gamma: g, A: 1}
+}
+
+// ParseTransferFunction accepts srgb, rec709, linear or gamma:<value>.
+func ParseTransferFunction(s string) (TransferFunction, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "srgb":
+		return TransferSRGB, nil
+	case "rec709", "rec2020", "bt709", "bt2020":
+		return TransferRec709, nil
+	case "linear":
+		return TransferLinear, nil
+	}
+	if g, ok := strings.CutPrefix(s, "gamma:"); ok {
+		v, err := strconv.ParseFloat(g, 64)
+		if err == nil && v > 0 {
+			return GammaTransfer(v), nil
+		}
+	}
+	return TransferFunction{}, fmt.Errorf("unknown transfer function %q (srgb, rec709, linear, gamma:<g>)", s)
+}
+
+// Decode converts an encoded value (0–1) to linear light, mirroring
+// negative values.
+func (t TransferFunction) Decode(x float64) float64 {
+	if x < 0 {
+		return -t.Decode(-x)
+	}
+	if x < t.D {
+		return t.C * x
+	}
+	return math.Pow(t.A*x+t.B, t.Gamma)
+}
+
+// Encode converts linear light to an encoded value.
+func (t TransferFunction) Encode(y float64) float64 {
+	if y < 0 {
+		return -t.Encode(-y)
+	}
+	if t.C > 0 && y < t.C*t.D {
+		return y / t.C
+	}
+	return (math.Pow(y, 1/t.Gamma) - t.B) / t.A
+}
+
+// -------------------------------
+// RGB colour spaces
+// -------------------------------
+type RGBSpace struct {
+	Name  string
+	Red   [2]float64 // xy chromaticity
+	Green [2]float64
+	Blue  [2]float64
+	White XYZ
+	TRC   TransferFunction
+}
+
+var (
+	SRGB        = RGBSpace{Name: "sRGB", Red: [2]float64{0.64, 0.33}, Green: [2]float64{0.30, 0.60}, Blue: [2]float64{0.15, 0.06}, White: WhiteD65, TRC: TransferSRGB}
+	DisplayP3   = RGBSpace{Name: "Display P3", Red: [2]float64{0.680, 0.320}, Green: [2]float64{0.265, 0.690}, Blue: [2]float64{0.150, 0.060}, White: WhiteD65, TRC: TransferSRGB}
+	AdobeRGB    = RGBSpace{Name: "Adobe RGB (1998)", Red: [2]float64{0.64, 0.33}, Green: [2]float64{0.21, 0.71}, Blue: [2]float64{0.15, 0.06}, White: WhiteD65, TRC: GammaTransfer(563.0 / 256)}
+	Rec2020     = RGBSpace{Name: "Rec. 2020", Red: [2]float64{0.708, 0.292}, Green: [2]float64{0.170, 0.797}, Blue: [2]float64{0.131, 0.046}, White: WhiteD65, TRC: TransferRec709}
+	ProPhotoRGB = RGBSpace{Name: "ProPhoto RGB", Red: [2]float64{0.734699, 0.265301}, Green: [2]float64{0.159597, 0.840403}, Blue: [2]float64{0.036598, 0.000105}, White: WhiteD50, TRC: GammaTransfer(1.8)}
+)
+
+var rgbSpaces = map[string]RGBSpace{
+	"srgb":         SRGB,
+	"p3":           DisplayP3,
+	"display-p3":   DisplayP3,
+	"adobe":        AdobeRGB,
+	"a98-rgb":      AdobeRGB,
+	"rec2020":      Rec2020,
+	"prophoto":     ProPhotoRGB,
+	"prophoto-rgb": ProPhotoRGB,
+}
+
+// RGBSpaceByName looks up a predefined space (srgb, display-p3,
+// a98-rgb, rec2020, prophoto-rgb and short aliases).
+func RGBSpaceByName(name string) (RGBSpace, error) {
+	s, ok := rgbSpaces[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return RGBSpace{}, fmt.Errorf("unknown RGB space %q", name)
+	}
+	return s, nil
+}
+
+// WhitePointByName resolves A, C, E, D50, D55, D65, D75 or an "x,y"
+// chromaticity pair.
+func WhitePointByName(name string) (XYZ, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "A":
+		return WhiteA, nil
+	case "C":
+		return WhiteC, nil
+	case "E":
+		return WhiteE, nil
+	case "D50":
+		return WhiteD50, nil
+	case "D55":
+		return WhiteD55, nil
+	case "D65":
+		return WhiteD65, nil
+	case "D75":
+		return WhiteD75, nil
+	}
+
+	parts := strings.Split(name, ",")
+	if len(parts) == 2 {
+		x, errX := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		y, errY := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errX == nil && errY == nil && y > 0 {
+			return XYY{X: x, Y: y, Lum: 1}.ToXYZ(), nil
+		}
+	}
+	return XYZ{}, fmt.Errorf("unknown white point %q", name)
+}
+
+// ToXYZMatrix returns the matrix taking linear RGB to XYZ relative to
+// the space's own white.
+func (s RGBSpace) ToXYZMatrix() ([3][3]float64, error) {
+	col := func(xy [2]float64) [3]float64 {
+		return [3]float64{xy[0] / xy[1], 1, (1 - xy[0] - xy[1]) / xy[1]}
+	}
+	r, g, b := col(s.Red), col(s.Green), col(s.Blue)
+	P := mat3{
+		{r[0], g[0], b[0]},
+		{r[1], g[1], b[1]},
+		{r[2], g[2], b[2]},
+	}
+	S, ok := solve3(P, [3]float64{s.White.X, s.White.Y, s.White.Z})
+	if !ok {
+		return [3][3]float64{}, errors.New("primaries are collinear")
+	}
+	var M [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			M[i][j] = P[i][j] * S[j]
+		}
+	}
+	return M, nil
+}
+
+// AdaptationMatrix returns the Bradford matrix from one white to another.
+func AdaptationMatrix(from, to XYZ) [3][3]float64 {
+	return adaptationMatrix(from, to)
+}
+
+// ToXYZ decodes encoded RGB (0–1) to XYZ under the space's white.
+func (s RGBSpace) ToXYZ(r, g, b float64) (XYZ, error) {
+	M, err := s.ToXYZMatrix()
+	if err != nil {
+		return XYZ{}, err
+	}
+	v := mat3(M).mulVec([3]float64{s.TRC.Decode(r), s.TRC.Decode(g), s.TRC.Decode(b)})
+	return XYZ{X: v[0], Y: v[1], Z: v[2]}, nil
+}
+
+// FromXYZ encodes XYZ (under the space's white) as RGB (0–1, unclipped).
+func (s RGBSpace) FromXYZ(c XYZ) (r, g, b float64, err error) {
+	M, err := s.ToXYZMatrix()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	inv, ok := mat3(M).inverse()
+	if !ok {
+		return 0, 0, 0, errors.New("primaries are collinear")
+	}
+	v := inv.mulVec([3]float64{c.X, c.Y, c.Z})
+	return s.TRC.Encode(v[0]), s.TRC.Encode(v[1]), s.TRC.Encode(v[2]), nil
+}
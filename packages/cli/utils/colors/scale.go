@@ -0,0 +1,203 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// -------------------------------
+// Data scales
+// -------------------------------
+
+// ScaleOptions configures NewScale.
+type ScaleOptions struct {
+	// Domain maps data to the scale: its first value to the first colour
+	// and its last to the last, with any values between placed evenly
+	// along the colours (so one value per colour pins each colour to a
+	// value). It may descend. Default 0–1.
+	Domain []float64
+	Space  Space     // interpolation space (ignored with Bezier)
+	Hue    HueMethod // hue interpolation in polar spaces
+	// Bezier interpolates one Bézier curve in Lab through all the colours
+	// as control points instead of piecewise between them, for smoother
+	// ramps that pass only through the first and last.
+	Bezier bool
+	// CorrectLightness respaces the colours so that Lab lightness changes
+	// evenly from the first colour to the last.
+	CorrectLightness bool
+	// Breaks, when set, makes the scale discrete: the class boundaries in
+	// domain units from ClassBreaks, lowest first (n+1 values for n
+	// classes). Each class gets one colour, spread evenly along the scale.
+	Breaks []float64
+}
+
+// Scale maps numbers to colours, in the manner of chroma.js scales.
+type Scale struct {
+	colors []Color
+	opt    ScaleOptions
+	domain []float64 // ascending; negated when the domain descends
+	negate bool
+	lStart float64 // Lab lightness at the ends, for CorrectLightness
+	lEnd   float64
+}
+
+// NewScale builds a scale through the given colours.
+func NewScale(colors []Color, opt ScaleOptions) (*Scale, error) {
+	if len(colors) < 2 {
+		return nil, fmt.Errorf("a scale needs at least 2 colors, got %d", len(colors))
+	}
+	s := &Scale{colors: append([]Color(nil), colors...), opt: opt}
+
+	domain := opt.Domain
+	if len(domain) == 0 {
+		domain = []float64{0, 1}
+	}
+	if len(domain) < 2 {
+		return nil, fmt.Errorf("a domain needs at least 2 values, got %d", len(domain))
+	}
+	s.negate = domain[0] > domain[len(domain)-1]
+	s.domain = make([]float64, len(domain))
+	for i, d := range domain {
+		if s.negate {
+			d = -d
+		}
+		if math.IsNaN(d) || math.IsInf(d, 0) || (i > 0 && d < s.domain[i-1]) {
+			return nil, fmt.Errorf("domain %v is not monotonic", domain)
+		}
+		s.domain[i] = d
+	}
+	if s.domain[0] == s.domain[len(s.domain)-1] {
+		return nil, fmt.Errorf("domain %v is empty", domain)
+	}
+	if len(opt.Breaks) == 1 {
+		return nil, fmt.Errorf("class breaks need at least 2 values")
+	}
+	if !sort.Float64sAreSorted(opt.Breaks) {
+		return nil, fmt.Errorf("class breaks %v are not ascending", opt.Breaks)
+	}
+
+	if opt.CorrectLightness {
+		s.lStart = s.curve(0).To(SpaceLab).V[0]
+		s.lEnd = s.curve(1).To(SpaceLab).V[0]
+	}
+	return s, nil
+}
+
+// At returns the colour for v. Values outside the domain (or the class
+// breaks) take the nearest end's colour. The colour is in the
+// interpolation space (Lab for Bezier) and not gamut mapped.
+func (s *Scale) At(v float64) Color {
+	if class := s.Class(v); class >= 0 {
+		classes := len(s.opt.Breaks) - 1
+		if classes == 1 {
+			return s.along(0)
+		}
+		return s.along(float64(class) / float64(classes-1))
+	}
+	return s.along(s.position(v))
+}
+
+// Class returns the 0-based class of v under the breaks, or -1 when
+// the scale is continuous.
+func (s *Scale) Class(v float64) int {
+	b := s.opt.Breaks
+	if len(b) < 2 {
+		return -1
+	}
+	class := sort.SearchFloat64s(b, v)
+	if class == len(b) || b[class] > v {
+		class--
+	}
+	return max(0, min(len(b)-2, class))
+}
+
+// Colors returns n colours evenly spaced along the scale, or one per
+// class when n is 0 and the scale has breaks.
+func (s *Scale) Colors(n int) []Color {
+	if n <= 0 && len(s.opt.Breaks) > 1 {
+		n = len(s.opt.Breaks) - 1
+	}
+	out := make([]Color, max(n, 0))
+	for i := range out {
+		if n == 1 {
+			out[i] = s.along(0)
+			continue
+		}
+		out[i] = s.along(float64(i) / float64(n-1))
+	}
+	return out
+}
+
+// position maps a domain value to 0–1 along the colours.
+func (s *Scale) position(v float64) float64 {
+	if s.negate {
+		v = -v
+	}
+	d := s.domain
+	if v <= d[0] {
+		return 0
+	}
+	if v >= d[len(d)-1] {
+		return 1
+	}
+	i := sort.SearchFloat64s(d, v)
+	if d[i] == v {
+		return float64(i) / float64(len(d)-1)
+	}
+	local := (v - d[i-1]) / (d[i] - d[i-1])
+	return (float64(i-1) + local) / float64(len(d)-1)
+}
+
+// along returns the colour at position t (0–1) along the colours, after
+// lightness correction.
+func (s *Scale) along(t float64) Color {
+	if !s.opt.CorrectLightness || t <= 0 || t >= 1 {
+		return s.curve(t)
+	}
+	// find where the curve reaches the evenly spaced lightness; assumes
+	// lightness is monotonic along it
+	target := s.lStart + (s.lEnd-s.lStart)*t
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 30; i++ {
+		mid := (lo + hi) / 2
+		l := s.curve(mid).To(SpaceLab).V[0]
+		if (l < target) == (s.lEnd > s.lStart) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return s.curve((lo + hi) / 2)
+}
+
+// curve is the uncorrected interpolation at position t (0–1).
+func (s *Scale) curve(t float64) Color {
+	t = math.Max(0, math.Min(1, t))
+	if s.opt.Bezier {
+		return bezierLab(s.colors, t)
+	}
+	segments := len(s.colors) - 1
+	i := min(int(t*float64(segments)), segments-1)
+	return Mix(s.colors[i], s.colors[i+1], t*float64(segments)-float64(i), s.opt.Space, s.opt.Hue)
+}
+
+// bezierLab evaluates the Bézier curve through the colours' Lab values
+// (and alpha) at t with de Casteljau's algorithm.
+func bezierLab(colors []Color, t float64) Color {
+	pts := make([][4]float64, len(colors))
+	for i, c := range colors {
+		lab := c.To(SpaceLab)
+		pts[i] = [4]float64{lab.V[0], lab.V[1], lab.V[2], lab.Alpha}
+	}
+	for n := len(pts) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			for k := range pts[i] {
+				pts[i][k] += (pts[i+1][k] - pts[i][k]) * t
+			}
+		}
+	}
+	out := NewColor(SpaceLab, pts[0][0], pts[0][1], pts[0][2])
+	out.Alpha = pts[0][3]
+	return out
+}
@@ -0,0 +1,138 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// grayAt returns the sRGB red channel of the scale's colour at v.
+func grayAt(s *Scale, v float64) float64 {
+	return s.At(v).To(SpaceSRGB).V[0]
+}
+
+func TestScaleAt(t *testing.T) {
+	bw := []Color{MustParse("black"), MustParse("white")}
+	tests := []struct {
+		name   string
+		colors []Color
+		domain []float64
+		at     map[float64]float64 // value → sRGB channel
+	}{
+		{"default domain", bw, nil, map[float64]float64{-1: 0, 0: 0, 0.25: 0.25, 1: 1, 2: 1}},
+		{"ascending", bw, []float64{10, 20}, map[float64]float64{10: 0, 12.5: 0.25, 20: 1}},
+		{"descending", bw, []float64{20, 10}, map[float64]float64{25: 0, 20: 0, 17.5: 0.25, 10: 1, 0: 1}},
+		{"descending negative", bw, []float64{0, -4}, map[float64]float64{0: 0, -1: 0.25, -3: 0.75, -4: 1}},
+		// one value per colour pins each colour to its value
+		{"pinned", []Color{MustParse("black"), MustParse("#808080"), MustParse("white")}, []float64{0, 10, 100},
+			map[float64]float64{5: 0.5 * 128 / 255, 10: 128.0 / 255, 55: (1 + 128.0/255) / 2}},
+		{"pinned descending", []Color{MustParse("black"), MustParse("#808080"), MustParse("white")}, []float64{100, 10, 0},
+			map[float64]float64{100: 0, 55: 0.5 * 128 / 255, 10: 128.0 / 255, 5: (1 + 128.0/255) / 2, 0: 1}},
+	}
+	for _, tt := range tests {
+		s, err := NewScale(tt.colors, ScaleOptions{Domain: tt.domain, Space: SpaceSRGB})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for v, want := range tt.at {
+			if got := grayAt(s, v); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s: At(%v) = %.6f, want %.6f", tt.name, v, got, want)
+			}
+		}
+	}
+}
+
+func TestNewScaleErrors(t *testing.T) {
+	bw := []Color{MustParse("black"), MustParse("white")}
+	for name, tc := range map[string]struct {
+		colors []Color
+		opt    ScaleOptions
+	}{
+		"one color":         {bw[:1], ScaleOptions{}},
+		"one domain value":  {bw, ScaleOptions{Domain: []float64{1}}},
+		"empty domain":      {bw, ScaleOptions{Domain: []float64{1, 1}}},
+		"non-monotonic":     {bw, ScaleOptions{Domain: []float64{0, 5, 2}}},
+		"NaN in domain":     {bw, ScaleOptions{Domain: []float64{0, math.NaN()}}},
+		"one break":         {bw, ScaleOptions{Breaks: []float64{1}}},
+		"descending breaks": {bw, ScaleOptions{Breaks: []float64{3, 2, 1}}},
+	} {
+		if _, err := NewScale(tc.colors, tc.opt); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
+
+// A value on a break belongs to the class above it; values past either
+// end belong to the end classes.
+func TestScaleClassBoundaries(t *testing.T) {
+	bw := []Color{MustParse("black"), MustParse("white")}
+	s, err := NewScale(bw, ScaleOptions{Space: SpaceSRGB, Breaks: []float64{0, 2, 4, 8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v, want := range map[float64]int{
+		-1: 0, 0: 0, 1.999: 0, 2: 1, 3.5: 1, 4: 2, 7.999: 2, 8: 2, 100: 2,
+	} {
+		if got := s.Class(v); got != want {
+			t.Errorf("Class(%v) = %d, want %d", v, got, want)
+		}
+	}
+	// each class takes one colour, evenly along the scale
+	for v, want := range map[float64]float64{0: 0, 2: 0.5, 4: 1, 8: 1} {
+		if got := grayAt(s, v); math.Abs(got-want) > 1e-9 {
+			t.Errorf("At(%v) = %.6f, want %.6f", v, got, want)
+		}
+	}
+	if got := len(s.Colors(0)); got != 3 {
+		t.Errorf("Colors(0) gives %d colors, want one per class", got)
+	}
+
+	continuous, _ := NewScale(bw, ScaleOptions{Space: SpaceSRGB})
+	if got := continuous.Class(0.5); got != -1 {
+		t.Errorf("continuous Class = %d, want -1", got)
+	}
+}
+
+func TestScaleColors(t *testing.T) {
+	s, _ := NewScale([]Color{MustParse("black"), MustParse("white")}, ScaleOptions{Space: SpaceSRGB})
+	got := s.Colors(5)
+	for i, c := range got {
+		if want := float64(i) / 4; math.Abs(c.V[0]-want) > 1e-9 {
+			t.Errorf("color %d = %s, want gray %.2f", i, c, want)
+		}
+	}
+	if one := s.Colors(1); len(one) != 1 || one[0].V[0] != 0 {
+		t.Errorf("Colors(1) = %v", one)
+	}
+}
+
+// With CorrectLightness, Lab lightness steps evenly even when the
+// colours are not evenly spaced in lightness.
+func TestScaleCorrectLightness(t *testing.T) {
+	stops := []Color{MustParse("#FFFFE0"), MustParse("#FF8A65"), MustParse("#F4511E"), MustParse("#00429D")}
+	s, err := NewScale(stops, ScaleOptions{Space: SpaceLab, CorrectLightness: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	colors := s.Colors(9)
+	first, last := colors[0].To(SpaceLab).V[0], colors[8].To(SpaceLab).V[0]
+	for i, c := range colors {
+		want := first + (last-first)*float64(i)/8
+		if l := c.To(SpaceLab).V[0]; math.Abs(l-want) > 0.01 {
+			t.Errorf("step %d has L %.3f, want %.3f", i, l, want)
+		}
+	}
+}
+
+// The Bézier scale passes through its first and last colours only.
+func TestScaleBezierEnds(t *testing.T) {
+	stops := []Color{MustParse("yellow"), MustParse("red"), MustParse("black")}
+	s, _ := NewScale(stops, ScaleOptions{Bezier: true})
+	for i, v := range map[int]float64{0: 0, 2: 1} {
+		if d := DeltaEOK(s.At(v), stops[i]); d > 1e-9 {
+			t.Errorf("At(%v) is ΔEOK %.4f from %s", v, d, stops[i].Hex())
+		}
+	}
+	if d := DeltaEOK(s.At(0.5), stops[1]); d < 0.05 {
+		t.Errorf("At(0.5) passes through the middle control point")
+	}
+}
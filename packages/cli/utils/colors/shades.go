@@ -0,0 +1,120 @@
+package colors
+
+import (
+	"math"
+	"strconv"
+)
+
+// -------------------------------
+// Tints and shades
+// -------------------------------
+
+// ShadeOptions configures Shades. Every field is used as given, zero
+// included, so start from DefaultShadeOptions.
+type ShadeOptions struct {
+	Steps    int     // number of steps; 11 gives 50–950
+	Lightest float64 // OKLCH lightness of the first step, above Darkest
+	Darkest  float64 // OKLCH lightness of the last step
+	HueShift float64 // degrees the hue turns toward yellow at the light end and blue at the dark end
+}
+
+// DefaultShadeOptions is an 11-step ramp from 0.97 to 0.27 without hue
+// shift.
+var DefaultShadeOptions = ShadeOptions{Steps: 11, Lightest: 0.97, Darkest: 0.27}
+
+// Shade is one step of a ramp.
+type Shade struct {
+	Name  string // 50, 100, … 950 for 11 steps
+	Color Color  // OKLCH, within the sRGB gamut
+	Base  bool   // the step the input colour landed on
+}
+
+// warm and cool hues (OKLCH) that HueShift turns toward
+const (
+	warmHue = 90
+	coolHue = 265
+)
+
+// Shades builds a light-to-dark ramp around base in OKLCH, Tailwind
+// style. Lightness follows an eased curve, denser at the light end, and
+// the base replaces the step nearest its own lightness, the other steps
+// stretching to meet it. Chroma tapers toward both ends (more sharply
+// toward white) and every step is mapped into sRGB. Fewer than one step
+// gives no shades.
+func Shades(base Color, opt ShadeOptions) []Shade {
+	n := opt.Steps
+	if n < 1 {
+		return nil
+	}
+	hi, lo := opt.Lightest, opt.Darkest
+
+	b := base.To(SpaceOKLCH)
+	curve := make([]float64, n)
+	k := 0
+	for i := range curve {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		curve[i] = hi - (hi-lo)*math.Pow(t, 1.3)
+		if math.Abs(curve[i]-b.V[0]) < math.Abs(curve[k]-b.V[0]) {
+			k = i
+		}
+	}
+
+	names := ShadeNames(n)
+	out := make([]Shade, n)
+	for i := range out {
+		c := b
+		if i != k {
+			// d runs from 0 at the base step to 1 at either end
+			var d float64
+			if i < k {
+				d = float64(k-i) / float64(k)
+				c.V[0] = hi + (curve[i]-hi)*(b.V[0]-hi)/(curve[k]-hi)
+				c.V[1] = b.V[1] * (0.05 + 0.95*math.Pow(1-d, 1.5))
+				c.V[2] = turnHue(b.V[2], warmHue, opt.HueShift*d)
+			} else {
+				d = float64(i-k) / float64(n-1-k)
+				c.V[0] = b.V[0] + (curve[i]-curve[k])*(lo-b.V[0])/(lo-curve[k])
+				c.V[1] = b.V[1] * (1 - 0.6*math.Pow(d, 1.6))
+				c.V[2] = turnHue(b.V[2], coolHue, opt.HueShift*d)
+			}
+		}
+		out[i] = Shade{Name: names[i], Color: c.MapToSRGB().To(SpaceOKLCH), Base: i == k}
+	}
+	return out
+}
+
+// ShadeNames returns Tailwind's 50–950 for 11 steps, 50–900 for 10, and
+// 100, 200, … otherwise.
+func ShadeNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		v := (i + 1) * 100
+		if n == 10 || n == 11 {
+			v = i * 100
+			if i == 0 {
+				v = 50
+			}
+			if i == 10 {
+				v = 950
+			}
+		}
+		names[i] = strconv.Itoa(v)
+	}
+	return names
+}
+
+// turnHue moves h toward target by up to deg degrees along the shorter
+// way round.
+func turnHue(h, target, deg float64) float64 {
+	if deg <= 0 {
+		return h
+	}
+	diff := math.Mod(target-h+540, 360) - 180
+	if math.Abs(diff) <= deg {
+		return target
+	}
+	return normalizeHue(h + math.Copysign(deg, diff))
+}
@@ -0,0 +1,89 @@
+package colors
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestShadeNames(t *testing.T) {
+	for _, tc := range []struct {
+		n    int
+		want []string
+	}{
+		{11, []string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900", "950"}},
+		{10, []string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900"}},
+		{5, []string{"100", "200", "300", "400", "500"}},
+	} {
+		if got := ShadeNames(tc.n); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ShadeNames(%d) = %v, want %v", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestShades(t *testing.T) {
+	for _, in := range []string{"#3B82F6", "#EF4444", "#FACC15", "#10B981", "#111827", "#F5F5F4", "oklch(0.62 0.3 330)"} {
+		base := MustParse(in)
+		for _, opt := range []ShadeOptions{
+			DefaultShadeOptions,
+			{Steps: 7, Lightest: 0.99, Darkest: 0.1, HueShift: 15},
+		} {
+			shades := Shades(base, opt)
+			if len(shades) != opt.Steps {
+				t.Fatalf("%s: %d shades, want %d", in, len(shades), opt.Steps)
+			}
+
+			// the base lands on the step nearest its lightness
+			b := base.To(SpaceOKLCH)
+			k, nearest := -1, math.Inf(1)
+			for i := range shades {
+				tt := float64(i) / float64(opt.Steps-1)
+				l := opt.Lightest - (opt.Lightest-opt.Darkest)*math.Pow(tt, 1.3)
+				if d := math.Abs(l - b.V[0]); d < nearest {
+					k, nearest = i, d
+				}
+			}
+			for i, s := range shades {
+				if s.Base != (i == k) {
+					t.Errorf("%s: step %s base = %v, want the base on step %d", in, s.Name, s.Base, k)
+				}
+			}
+			if got := shades[k].Color; DeltaEOK(got, base.MapToSRGB()) > 1e-6 {
+				t.Errorf("%s: base step is %v, want the input", in, got)
+			}
+
+			for i, s := range shades {
+				if !s.Color.To(SpaceSRGB).InGamut() {
+					t.Errorf("%s: step %s (%v) is outside sRGB", in, s.Name, s.Color)
+				}
+				if i > 0 && s.Color.V[0] >= shades[i-1].Color.V[0] {
+					t.Errorf("%s: lightness rises from step %s to %s", in, shades[i-1].Name, s.Name)
+				}
+			}
+		}
+	}
+}
+
+// Zero is a real value, not "use the default".
+func TestShadesZeroOptions(t *testing.T) {
+	base := MustParse("#3B82F6")
+
+	opt := DefaultShadeOptions
+	opt.Darkest = 0
+	shades := Shades(base, opt)
+	if last := shades[len(shades)-1].Color; last.V[0] > 1e-6 {
+		t.Errorf("Darkest 0: last step %v, want black", last)
+	}
+
+	shades = Shades(base, DefaultShadeOptions) // HueShift 0
+	h := base.To(SpaceOKLCH).V[2]
+	for _, s := range shades {
+		if s.Color.V[1] > 0.02 && hueDiff(s.Color.V[2], h) > 3 {
+			t.Errorf("HueShift 0: step %s hue %.1f, base %.1f", s.Name, s.Color.V[2], h)
+		}
+	}
+
+	if got := Shades(base, ShadeOptions{}); got != nil {
+		t.Errorf("zero steps gave %d shades", len(got))
+	}
+}
@@ -0,0 +1,153 @@
+package colors
+
+import "fmt"
+
+// -------------------------------
+// Slice conversion
+// -------------------------------
+
+// ConvertSlice converts packed colours from one space to another. src
+// holds from.Channels() values per colour and dst receives
+// to.Channels() values per colour, in the units documented on Space.
+// Values are not clipped. dst may be src itself when to has no more
+// channels than from.
+func ConvertSlice(dst, src []float64, from, to Space) error {
+	nIn, nOut := from.Channels(), to.Channels()
+	if len(src)%nIn != 0 {
+		return fmt.Errorf("src length %d is not a multiple of %d (%s)", len(src), nIn, from)
+	}
+	n := len(src) / nIn
+	if len(dst) < n*nOut {
+		return fmt.Errorf("dst holds %d values, need %d", len(dst), n*nOut)
+	}
+
+	if from == to {
+		copy(dst, src)
+		return nil
+	}
+
+	// Conversions among sRGB, its linear form and Oklab go through linear
+	// sRGB; everything else goes through XYZ D65.
+	if from.linearHub() && to.linearHub() {
+		for i := 0; i < n; i++ {
+			var v [4]float64
+			copy(v[:], src[i*nIn:i*nIn+nIn])
+			out := fromLinearSRGB(to, toLinearSRGB(from, v))
+			copy(dst[i*nOut:i*nOut+nOut], out[:nOut])
+		}
+		return nil
+	}
+
+	for i := 0; i < n; i++ {
+		var v [4]float64
+		copy(v[:], src[i*nIn:i*nIn+nIn])
+		out := spaceFromXYZ(to, spaceToXYZ(from, v))
+		copy(dst[i*nOut:i*nOut+nOut], out[:nOut])
+	}
+	return nil
+}
+
+// ConvertFromRGB8 converts packed 8-bit sRGB (3 bytes per pixel, e.g.
+// the Pix of an RGB image with alpha stripped) to another space. dst
+// receives to.Channels() values per pixel. Decoding uses the lookup
+// table, so no power function is evaluated.
+func ConvertFromRGB8(dst []float64, src []uint8, to Space) error {
+	if len(src)%3 != 0 {
+		return fmt.Errorf("src length %d is not a multiple of 3", len(src))
+	}
+	n, nOut := len(src)/3, to.Channels()
+	if len(dst) < n*nOut {
+		return fmt.Errorf("dst holds %d values, need %d", len(dst), n*nOut)
+	}
+
+	for i := 0; i < n; i++ {
+		lin := [3]float64{srgb8ToLinear[src[3*i]], srgb8ToLinear[src[3*i+1]], srgb8ToLinear[src[3*i+2]]}
+		var out [4]float64
+		switch {
+		case to == SpaceSRGB:
+			out = [4]float64{float64(src[3*i]) / 255, float64(src[3*i+1]) / 255, float64(src[3*i+2]) / 255}
+		case to.linearHub():
+			out = fromLinearSRGB(to, lin)
+		default:
+			out = spaceFromXYZ(to, linearSRGBToXYZ(lin))
+		}
+		copy(dst[i*nOut:i*nOut+nOut], out[:nOut])
+	}
+	return nil
+}
+
+// ConvertToRGB8 converts packed colours to 8-bit sRGB (3 bytes per
+// colour), clipping each channel. Encoding uses the lookup table.
+func ConvertToRGB8(dst []uint8, src []float64, from Space) error {
+	nIn := from.Channels()
+	if len(src)%nIn != 0 {
+		return fmt.Errorf("src length %d is not a multiple of %d (%s)", len(src), nIn, from)
+	}
+	n := len(src) / nIn
+	if len(dst) < n*3 {
+		return fmt.Errorf("dst holds %d values, need %d", len(dst), n*3)
+	}
+
+	for i := 0; i < n; i++ {
+		var v [4]float64
+		copy(v[:], src[i*nIn:i*nIn+nIn])
+		if from == SpaceSRGB {
+			dst[3*i], dst[3*i+1], dst[3*i+2] = roundToCode(v[0]), roundToCode(v[1]), roundToCode(v[2])
+			continue
+		}
+		var lin [3]float64
+		if from.linearHub() {
+			lin = toLinearSRGB(from, v)
+		} else {
+			xyz := spaceToXYZ(from, v)
+			lin = rgbMatrices[SpaceLinearSRGB].fromXYZ.mulVec([3]float64{xyz.X, xyz.Y, xyz.Z})
+		}
+		dst[3*i], dst[3*i+1], dst[3*i+2] = LinearToSRGB8(lin[0]), LinearToSRGB8(lin[1]), LinearToSRGB8(lin[2])
+	}
+	return nil
+}
+
+// linearHub reports whether the space converts to linear sRGB without
+// going through XYZ.
+func (s Space) linearHub() bool {
+	switch s {
+	case SpaceSRGB, SpaceLinearSRGB, SpaceOklab, SpaceOKLCH:
+		return true
+	}
+	return false
+}
+
+func toLinearSRGB(s Space, v [4]float64) [3]float64 {
+	switch s {
+	case SpaceSRGB:
+		return [3]float64{decodeSRGB(v[0]), decodeSRGB(v[1]), decodeSRGB(v[2])}
+	case SpaceOklab:
+		R, G, B := oklabToLinearRGB(v[0], v[1], v[2])
+		return [3]float64{R, G, B}
+	case SpaceOKLCH:
+		a, b := fromPolarDeg(v[1], v[2])
+		R, G, B := oklabToLinearRGB(v[0], a, b)
+		return [3]float64{R, G, B}
+	}
+	return [3]float64{v[0], v[1], v[2]}
+}
+
+func fromLinearSRGB(s Space, lin [3]float64) [4]float64 {
+	switch s {
+	case SpaceSRGB:
+		return [4]float64{TransferSRGB.Encode(lin[0]), TransferSRGB.Encode(lin[1]), TransferSRGB.Encode(lin[2])}
+	case SpaceOklab:
+		L, a, b := linearRGBToOklab(lin[0], lin[1], lin[2])
+		return [4]float64{L, a, b}
+	case SpaceOKLCH:
+		L, a, b := linearRGBToOklab(lin[0], lin[1], lin[2])
+		C, h := toPolarDeg(a, b)
+		return [4]float64{L, C, h}
+	}
+	return [4]float64{lin[0], lin[1], lin[2]}
+}
+
+func linearSRGBToXYZ(lin [3]float64) XYZ {
+	v := rgbMatrices[SpaceLinearSRGB].toXYZ.mulVec(lin)
+	return XYZ{X: v[0], Y: v[1], Z: v[2]}
+}
@@ -0,0 +1,369 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// -------------------------------
+// Space enum
+// -------------------------------
+
+// Space identifies the colour space of a Color's channel values.
+type Space int
+
+const (
+	SpaceSRGB        Space = iota // r, g, b 0–1
+	SpaceLinearSRGB               // r, g, b 0–1
+	SpaceHSL                      // h°, s 0–100, l 0–100
+	SpaceHWB                      // h°, w 0–100, b 0–100
+	SpaceLab                      // CIELAB D50: L 0–100, a, b
+	SpaceLCH                      // CIELCh D50: L 0–100, C, h°
+	SpaceOklab                    // L 0–1, a, b
+	SpaceOKLCH                    // L 0–1, C, h°
+	SpaceHCL                      // CIELCh D65: h°, C, L 0–100
+	SpaceXYZD65                   // X, Y, Z (Y = 1 for white)
+	SpaceXYZD50                   // X, Y, Z (Y = 1 for white)
+	SpaceDisplayP3                // r, g, b 0–1
+	SpaceA98RGB                   // r, g, b 0–1
+	SpaceProPhotoRGB              // r, g, b 0–1
+	SpaceRec2020                  // r, g, b 0–1
+	SpaceCMYK                     // c, m, y, k 0–100
+)
+
+var spaceNames = []string{
+	"srgb", "srgb-linear", "hsl", "hwb", "lab", "lch", "oklab", "oklch",
+	"hcl", "xyz-d65", "xyz-d50", "display-p3", "a98-rgb", "prophoto-rgb",
+	"rec2020", "cmyk",
+}
+
+func (s Space) String() string {
+	if s < 0 || int(s) >= len(spaceNames) {
+		return "unknown"
+	}
+	return spaceNames[s]
+}
+
+// ParseSpace accepts the CSS names (srgb, srgb-linear, display-p3, …)
+// plus rgb, xyz, p3, hcl and cmyk.
+func ParseSpace(name string) (Space, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "rgb", "hex":
+		return SpaceSRGB, nil
+	case "xyz":
+		return SpaceXYZD65, nil
+	case "p3":
+		return SpaceDisplayP3, nil
+	case "a98", "adobe":
+		return SpaceA98RGB, nil
+	case "prophoto":
+		return SpaceProPhotoRGB, nil
+	case "device-cmyk":
+		return SpaceCMYK, nil
+	}
+	for i, n := range spaceNames {
+		if name == n {
+			return Space(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown color space %q", name)
+}
+
+// Channels returns the number of channel values (4 for CMYK, 3 otherwise).
+func (s Space) Channels() int {
+	if s == SpaceCMYK {
+		return 4
+	}
+	return 3
+}
+
+// HueChannel returns the index of the hue channel, or -1.
+func (s Space) HueChannel() int {
+	switch s {
+	case SpaceHSL, SpaceHWB, SpaceHCL:
+		return 0
+	case SpaceLCH, SpaceOKLCH:
+		return 2
+	}
+	return -1
+}
+
+// Bounded reports whether the space has a gamut (channels limited to a
+// fixed range) rather than being unbounded like Lab or XYZ.
+func (s Space) Bounded() bool {
+	switch s {
+	case SpaceSRGB, SpaceLinearSRGB, SpaceHSL, SpaceHWB, SpaceDisplayP3,
+		SpaceA98RGB, SpaceProPhotoRGB, SpaceRec2020, SpaceCMYK:
+		return true
+	}
+	return false
+}
+
+// isRGB reports whether the space is an RGB encoding with primaries.
+func (s Space) isRGB() bool {
+	switch s {
+	case SpaceSRGB, SpaceLinearSRGB, SpaceDisplayP3, SpaceA98RGB, SpaceProPhotoRGB, SpaceRec2020:
+		return true
+	}
+	return false
+}
+
+// rgbSpace returns the RGBSpace behind an RGB-like Space.
+func (s Space) rgbSpace() (RGBSpace, bool) {
+	switch s {
+	case SpaceSRGB:
+		return SRGB, true
+	case SpaceLinearSRGB:
+		lin := SRGB
+		lin.TRC = TransferLinear
+		return lin, true
+	case SpaceDisplayP3:
+		return DisplayP3, true
+	case SpaceA98RGB:
+		return AdobeRGB, true
+	case SpaceProPhotoRGB:
+		return ProPhotoRGB, true
+	case SpaceRec2020:
+		return Rec2020, true
+	}
+	return RGBSpace{}, false
+}
+
+// -------------------------------
+// Space ↔ XYZ (D65)
+// -------------------------------
+
+// rgbMatrix holds, for an RGB-like space, the matrices between its
+// linear RGB and XYZ D65, chromatic adaptation included.
+type rgbMatrix struct {
+	trc            TransferFunction
+	toXYZ, fromXYZ mat3
+}
+
+// rgbMatrices is indexed by Space and filled for RGB-like spaces only,
+// so per-colour conversions never rebuild a matrix.
+var rgbMatrices [SpaceCMYK + 1]rgbMatrix
+
+// d50ToD65 and d65ToD50 are the Bradford matrices between the two
+// whites the spaces use.
+var d50ToD65, d65ToD50 mat3
+
+func init() {
+	d50ToD65 = adaptationMatrix(WhiteD50, WhiteD65)
+	d65ToD50 = adaptationMatrix(WhiteD65, WhiteD50)
+	for s := range rgbMatrices {
+		rs, ok := Space(s).rgbSpace()
+		if !ok {
+			continue
+		}
+		M, _ := rs.ToXYZMatrix()
+		toXYZ := adaptationMatrix(rs.White, WhiteD65).mul(mat3(M))
+		fromXYZ, _ := toXYZ.inverse()
+		rgbMatrices[s] = rgbMatrix{trc: rs.TRC, toXYZ: toXYZ, fromXYZ: fromXYZ}
+	}
+}
+
+func mulXYZ(m mat3, c XYZ) XYZ {
+	v := m.mulVec([3]float64{c.X, c.Y, c.Z})
+	return XYZ{X: v[0], Y: v[1], Z: v[2]}
+}
+
+// spaceToXYZ converts channel values to XYZ D65.
+func spaceToXYZ(s Space, v [4]float64) XYZ {
+	if s.isRGB() {
+		m := &rgbMatrices[s]
+		var lin [3]float64
+		if s == SpaceSRGB {
+			lin = [3]float64{decodeSRGB(v[0]), decodeSRGB(v[1]), decodeSRGB(v[2])}
+		} else {
+			lin = [3]float64{m.trc.Decode(v[0]), m.trc.Decode(v[1]), m.trc.Decode(v[2])}
+		}
+		xyz := m.toXYZ.mulVec(lin)
+		return XYZ{X: xyz[0], Y: xyz[1], Z: xyz[2]}
+	}
+
+	switch s {
+	case SpaceHSL:
+		r, g, b := hslToSRGB(v[0], v[1]/100, v[2]/100)
+		return spaceToXYZ(SpaceSRGB, [4]float64{r, g, b})
+	case SpaceHWB:
+		r, g, b := hwbToSRGB(v[0], v[1]/100, v[2]/100)
+		return spaceToXYZ(SpaceSRGB, [4]float64{r, g, b})
+	case SpaceLab:
+		return mulXYZ(d50ToD65, Lab{L: v[0], A: v[1], B: v[2]}.ToXYZ(WhiteD50))
+	case SpaceLCH:
+		a, b := fromPolarDeg(v[1], v[2])
+		return mulXYZ(d50ToD65, Lab{L: v[0], A: a, B: b}.ToXYZ(WhiteD50))
+	case SpaceOklab:
+		R, G, B := oklabToLinearRGB(v[0], v[1], v[2])
+		return spaceToXYZ(SpaceLinearSRGB, [4]float64{R, G, B})
+	case SpaceOKLCH:
+		a, b := fromPolarDeg(v[1], v[2])
+		R, G, B := oklabToLinearRGB(v[0], a, b)
+		return spaceToXYZ(SpaceLinearSRGB, [4]float64{R, G, B})
+	case SpaceHCL:
+		l, a, b := HCL{H: v[0], C: v[1], L: v[2]}.ToLab()
+		return Lab{L: l, A: a, B: b}.ToXYZ(WhiteD65)
+	case SpaceXYZD65:
+		return XYZ{X: v[0], Y: v[1], Z: v[2]}
+	case SpaceXYZD50:
+		return mulXYZ(d50ToD65, XYZ{X: v[0], Y: v[1], Z: v[2]})
+	case SpaceCMYK:
+		k := 1 - v[3]/100
+		return spaceToXYZ(SpaceSRGB, [4]float64{(1 - v[0]/100) * k, (1 - v[1]/100) * k, (1 - v[2]/100) * k})
+	}
+	return XYZ{}
+}
+
+// spaceFromXYZ converts XYZ D65 to channel values (unclipped).
+func spaceFromXYZ(s Space, c XYZ) [4]float64 {
+	if s.isRGB() {
+		m := &rgbMatrices[s]
+		lin := m.fromXYZ.mulVec([3]float64{c.X, c.Y, c.Z})
+		return [4]float64{m.trc.Encode(lin[0]), m.trc.Encode(lin[1]), m.trc.Encode(lin[2])}
+	}
+
+	switch s {
+	case SpaceHSL:
+		rgb := spaceFromXYZ(SpaceSRGB, c)
+		h, sat, l := srgbToHSL(rgb[0], rgb[1], rgb[2])
+		return [4]float64{h, sat * 100, l * 100}
+	case SpaceHWB:
+		rgb := spaceFromXYZ(SpaceSRGB, c)
+		h, _, _ := srgbToHSL(rgb[0], rgb[1], rgb[2])
+		w := math.Min(rgb[0], math.Min(rgb[1], rgb[2]))
+		b := 1 - math.Max(rgb[0], math.Max(rgb[1], rgb[2]))
+		return [4]float64{h, w * 100, b * 100}
+	case SpaceLab:
+		lab := mulXYZ(d65ToD50, c).ToLab(WhiteD50)
+		return [4]float64{lab.L, lab.A, lab.B}
+	case SpaceLCH:
+		lab := mulXYZ(d65ToD50, c).ToLab(WhiteD50)
+		C, h := toPolarDeg(lab.A, lab.B)
+		return [4]float64{lab.L, C, h}
+	case SpaceOklab:
+		lin := spaceFromXYZ(SpaceLinearSRGB, c)
+		L, a, b := linearRGBToOklab(lin[0], lin[1], lin[2])
+		return [4]float64{L, a, b}
+	case SpaceOKLCH:
+		lin := spaceFromXYZ(SpaceLinearSRGB, c)
+		L, a, b := linearRGBToOklab(lin[0], lin[1], lin[2])
+		C, h := toPolarDeg(a, b)
+		return [4]float64{L, C, h}
+	case SpaceHCL:
+		lab := c.ToLab(WhiteD65)
+		C, h := toPolarDeg(lab.A, lab.B)
+		return [4]float64{h, C, lab.L}
+	case SpaceXYZD65:
+		return [4]float64{c.X, c.Y, c.Z}
+	case SpaceXYZD50:
+		d := mulXYZ(d65ToD50, c)
+		return [4]float64{d.X, d.Y, d.Z}
+	case SpaceCMYK:
+		rgb := spaceFromXYZ(SpaceSRGB, c)
+		r, g, b := clamp01(rgb[0]), clamp01(rgb[1]), clamp01(rgb[2])
+		k := 1 - math.Max(r, math.Max(g, b))
+		if k >= 1 {
+			return [4]float64{0, 0, 0, 100}
+		}
+		return [4]float64{
+			(1 - r - k) / (1 - k) * 100,
+			(1 - g - k) / (1 - k) * 100,
+			(1 - b - k) / (1 - k) * 100,
+			k * 100,
+		}
+	}
+	return [4]float64{}
+}
+
+// -------------------------------
+// Helpers
+// -------------------------------
+
+// achromatic is the chroma below which hue is treated as powerless.
+const achromatic = 1e-4
+
+func toPolarDeg(a, b float64) (c, h float64) {
+	c = math.Hypot(a, b)
+	if c < achromatic {
+		return c, 0
+	}
+	h = math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return c, h
+}
+
+func fromPolarDeg(c, h float64) (a, b float64) {
+	rad := h * math.Pi / 180
+	return c * math.Cos(rad), c * math.Sin(rad)
+}
+
+func linearRGBToOklab(R, G, B float64) (L, a, b float64) {
+	l := math.Cbrt(0.4122214708*R + 0.5363325363*G + 0.0514459929*B)
+	m := math.Cbrt(0.2119034982*R + 0.6806995451*G + 0.1073969566*B)
+	s := math.Cbrt(0.0883024619*R + 0.2817188376*G + 0.6299787005*B)
+	L = 0.2104542553*l + 0.7936177850*m - 0.0040720468*s
+	a = 1.9779984951*l - 2.4285922050*m + 0.4505937099*s
+	b = 0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+	return
+}
+
+// hslToSRGB takes s and l in 0–1 and returns r, g, b in 0–1.
+func hslToSRGB(h, s, l float64) (r, g, b float64) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(k-3, math.Min(9-k, 1)))
+	}
+	return f(0), f(8), f(4)
+}
+
+// hwbToSRGB takes w and b in 0–1.
+func hwbToSRGB(h, w, bl float64) (r, g, b float64) {
+	if w+bl >= 1 {
+		gray := w / (w + bl)
+		return gray, gray, gray
+	}
+	r, g, b = hslToSRGB(h, 1, 0.5)
+	scale := 1 - w - bl
+	return r*scale + w, g*scale + w, b*scale + w
+}
+
+// srgbToHSL returns h in degrees and s, l in 0–1.
+func srgbToHSL(r, g, b float64) (h, s, l float64) {
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+	d := max - min
+	if d < 1e-9 {
+		return 0, 0, l
+	}
+	if l > 0 && l < 1 {
+		s = d / (1 - math.Abs(2*l-1))
+	}
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+func clamp01(x float64) float64 {
+	return math.Max(0, math.Min(1, x))
+}
@@ -0,0 +1,373 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// Spectrum (380–730 nm, 10 nm)
+// -------------------------------
+const (
+	SpectrumStart   = 380 // nm
+	SpectrumStep    = 10  // nm
+	SpectrumSamples = 36
+)
+
+// Spectrum holds a reflectance (0–1) or relative spectral power
+// distribution sampled every SpectrumStep nm from SpectrumStart.
+type Spectrum [SpectrumSamples]float64
+
+// Wavelength returns the wavelength in nm of sample i.
+func (s Spectrum) Wavelength(i int) float64 {
+	return float64(SpectrumStart + i*SpectrumStep)
+}
+
+// At linearly interpolates the spectrum at nm, clamping at both ends.
+func (s Spectrum) At(nm float64) float64 {
+	x := (nm - SpectrumStart) / SpectrumStep
+	if x <= 0 {
+		return s[0]
+	}
+	if x >= SpectrumSamples-1 {
+		return s[SpectrumSamples-1]
+	}
+	i := int(x)
+	t := x - float64(i)
+	return s[i]*(1-t) + s[i+1]*t
+}
+
+// NewSpectrum resamples measurements taken every `step` nm from `start`
+// nm onto the Spectrum grid, holding the end values outside the range.
+func NewSpectrum(start, step float64, values []float64) (Spectrum, error) {
+	if len(values) == 0 || step <= 0 {
+		return Spectrum{}, errors.New("empty spectrum")
+	}
+	var s Spectrum
+	for i := range s {
+		x := (s.Wavelength(i) - start) / step
+		switch {
+		case x <= 0:
+			s[i] = values[0]
+		case x >= float64(len(values)-1):
+			s[i] = values[len(values)-1]
+		default:
+			j := int(x)
+			t := x - float64(j)
+			s[i] = values[j]*(1-t) + values[j+1]*t
+		}
+	}
+	return s, nil
+}
+
+// -------------------------------
+// CIE 1931 2° colour matching functions
+// -------------------------------
+var cmfX = Spectrum{
+	0.001368, 0.004243, 0.014310, 0.043510, 0.134380, 0.283900, 0.348280, 0.336200, 0.290800,
+	0.195360, 0.095640, 0.032010, 0.004900, 0.009300, 0.063270, 0.165500, 0.290400, 0.433450,
+	0.594500, 0.762100, 0.916300, 1.026300, 1.062200, 1.002600, 0.854450, 0.642400, 0.447900,
+	0.283500, 0.164900, 0.087400, 0.046770, 0.022700, 0.011359, 0.005790, 0.002899, 0.001440,
+}
+
+var cmfY = Spectrum{
+	0.000039, 0.000120, 0.000396, 0.001210, 0.004000, 0.011600, 0.023000, 0.038000, 0.060000,
+	0.090980, 0.139020, 0.208020, 0.323000, 0.503000, 0.710000, 0.862000, 0.954000, 0.994950,
+	0.995000, 0.952000, 0.870000, 0.757000, 0.631000, 0.503000, 0.381000, 0.265000, 0.175000,
+	0.107000, 0.061000, 0.032000, 0.017000, 0.008210, 0.004102, 0.002091, 0.001047, 0.000520,
+}
+
+var cmfZ = Spectrum{
+	0.006450, 0.020050, 0.067850, 0.207400, 0.645600, 1.385600, 1.747060, 1.772110, 1.669200,
+	1.287640, 0.812950, 0.465180, 0.272000, 0.158200, 0.078250, 0.042160, 0.020300, 0.008750,
+	0.003900, 0.002100, 0.001650, 0.001100, 0.000800, 0.000340, 0.000190, 0.000050, 0.000020,
+	0, 0, 0, 0, 0, 0, 0, 0, 0,
+}
+
+// -------------------------------
+// Illuminants
+// -------------------------------
+
+// IlluminantD65 is the CIE standard daylight illuminant D65.
+var IlluminantD65 = Spectrum{
+	49.9755, 54.6482, 82.7549, 91.4860, 93.4318, 86.6823, 104.8650, 117.0080, 117.8120,
+	114.8610, 115.9230, 108.8110, 109.3540, 107.8020, 104.7900, 107.6890, 104.4050, 104.0460,
+	100.0000, 96.3342, 95.7880, 88.6856, 90.0062, 89.5991, 87.6987, 83.2886, 83.6992,
+	80.0268, 80.2146, 82.2778, 78.2842, 69.7213, 71.6091, 74.3490, 61.6040, 69.8856,
+}
+
+// IlluminantA is the CIE standard incandescent illuminant A.
+var IlluminantA = func() Spectrum {
+	var s Spectrum
+	c2 := 1.435e7 // second radiation constant as defined for illuminant A (nm·K)
+	ref := math.Exp(c2/(2848*560)) - 1
+	for i := range s {
+		nm := s.Wavelength(i)
+		s[i] = 100 * math.Pow(560/nm, 5) * ref / (math.Exp(c2/(2848*nm)) - 1)
+	}
+	return s
+}()
+
+// IlluminantE is the equal-energy illuminant.
+var IlluminantE = func() Spectrum {
+	var s Spectrum
+	for i := range s {
+		s[i] = 100
+	}
+	return s
+}()
+
+// daylight basis functions S0, S1, S2 (CIE 15)
+var (
+	daylightS0 = Spectrum{
+		63.4, 65.8, 94.8, 104.8, 105.9, 96.8, 113.9, 125.6, 125.5,
+		121.3, 121.3, 113.5, 113.1, 110.8, 106.5, 108.8, 105.3, 104.4,
+		100.0, 96.0, 95.1, 89.1, 90.5, 90.3, 88.4, 84.0, 85.1,
+		81.9, 82.6, 84.9, 81.3, 71.9, 74.3, 76.4, 63.3, 71.7,
+	}
+	daylightS1 = Spectrum{
+		38.5, 35.0, 43.4, 46.3, 43.9, 37.1, 36.7, 35.9, 32.6,
+		27.9, 24.3, 20.1, 16.2, 13.2, 8.6, 6.1, 4.2, 1.9,
+		0.0, -1.6, -3.5, -3.5, -5.8, -7.2, -8.6, -9.5, -10.9,
+		-10.7, -12.0, -14.0, -13.6, -12.0, -13.3, -12.9, -10.6, -11.6,
+	}
+	daylightS2 = Spectrum{
+		3.0, 1.2, -1.1, -0.5, -0.7, -1.2, -2.6, -2.9, -2.8,
+		-2.6, -2.6, -1.8, -1.5, -1.3, -1.2, -1.0, -0.5, -0.3,
+		0.0, 0.2, 0.5, 2.1, 3.2, 4.1, 4.7, 5.1, 6.7,
+		7.3, 8.6, 9.8, 10.2, 8.3, 9.6, 8.5, 7.0, 7.6,
+	}
+)
+
+// DaylightIlluminant builds a CIE D-series illuminant for a correlated
+// colour temperature between 4000 K and 25000 K.
+func DaylightIlluminant(cct float64) (Spectrum, error) {
+	if cct < 4000 || cct > 25000 {
+		return Spectrum{}, fmt.Errorf("daylight CCT %.0fK out of range 4000–25000", cct)
+	}
+
+	t := cct
+	var x float64
+	if t <= 7000 {
+		x = -4.6070e9/(t*t*t) + 2.9678e6/(t*t) + 0.09911e3/t + 0.244063
+	} else {
+		x = -2.0064e9/(t*t*t) + 1.9018e6/(t*t) + 0.24748e3/t + 0.237040
+	}
+	y := -3*x*x + 2.870*x - 0.275
+
+	m := 0.0241 + 0.2562*x - 0.7341*y
+	m1 := (-1.3515 - 1.7703*x + 5.9114*y) / m
+	m2 := (0.0300 - 31.4424*x + 30.0717*y) / m
+
+	var s Spectrum
+	for i := range s {
+		s[i] = daylightS0[i] + m1*daylightS1[i] + m2*daylightS2[i]
+	}
+	return s, nil
+}
+
+// BlackbodyIlluminant returns a Planckian radiator at the given
+// temperature, normalised to 100 at 560 nm.
+func BlackbodyIlluminant(kelvin float64) (Spectrum, error) {
+	if kelvin <= 0 {
+		return Spectrum{}, errors.New("blackbody temperature must be positive")
+	}
+	c2 := 1.4388e7 // nm·K
+	ref := math.Exp(c2/(kelvin*560)) - 1
+	var s Spectrum
+	for i := range s {
+		nm := s.Wavelength(i)
+		s[i] = 100 * math.Pow(560/nm, 5) * ref / (math.Exp(c2/(kelvin*nm)) - 1)
+	}
+	return s, nil
+}
+
+// IlluminantByName resolves A, E, D50, D55, D65, D75, any "D<cct>" or
+// a blackbody temperature such as "3000K".
+func IlluminantByName(name string) (Spectrum, error) {
+	n := strings.ToUpper(strings.TrimSpace(name))
+	switch n {
+	case "A":
+		return IlluminantA, nil
+	case "E":
+		return IlluminantE, nil
+	case "D65":
+		return IlluminantD65, nil
+	}
+
+	if strings.HasPrefix(n, "D") {
+		v, err := strconv.ParseFloat(n[1:], 64)
+		if err == nil {
+			// D50 is 5000 K on the old c2 scale, i.e. ≈5003 K today
+			if v < 100 {
+				v = v * 100 * 1.4388 / 1.4380
+			}
+			return DaylightIlluminant(v)
+		}
+	}
+	if strings.HasSuffix(n, "K") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(n, "K"), 64)
+		if err == nil {
+			return BlackbodyIlluminant(v)
+		}
+	}
+	return Spectrum{}, fmt.Errorf("unknown illuminant %q", name)
+}
+
+// -------------------------------
+// Spectrum → XYZ
+// -------------------------------
+
+// White returns the XYZ of a perfect reflector under the illuminant,
+// normalised to Y = 1.
+func (illum Spectrum) White() XYZ {
+	var one Spectrum
+	for i := range one {
+		one[i] = 1
+	}
+	return one.Render(illum)
+}
+
+// Render integrates a reflectance spectrum under an illuminant. The
+// result is normalised so a perfect reflector has Y = 1.
+func (s Spectrum) Render(illum Spectrum) XYZ {
+	var X, Y, Z, N float64
+	for i := range s {
+		e := illum[i]
+		X += s[i] * e * cmfX[i]
+		Y += s[i] * e * cmfY[i]
+		Z += s[i] * e * cmfZ[i]
+		N += e * cmfY[i]
+	}
+	return XYZ{X: X / N, Y: Y / N, Z: Z / N}
+}
+
+// LabUnder returns the CIELAB coordinates of a reflectance under an
+// illuminant, relative to that illuminant's own white.
+func (s Spectrum) LabUnder(illum Spectrum) Lab {
+	return s.Render(illum).ToLab(illum.White())
+}
+
+// AppearanceUnder renders the reflectance under the illuminant and
+// adapts the result back to D65 (Bradford), giving the sRGB colour an
+// observer adapted to that light would perceive.
+func (s Spectrum) AppearanceUnder(illum Spectrum) (r, g, b int, err error) {
+	xyz := s.Render(illum).Adapt(illum.White(), WhiteD65)
+	return xyz.ToRGB()
+}
+
+// -------------------------------
+// RGB → reflectance (Jakob–Hanika)
+// -------------------------------
+
+// sigmoid-polynomial reflectance model from Jakob & Hanika (2019)
+func sigmoidSpectrum(coef [3]float64) Spectrum {
+	var s Spectrum
+	for i := range s {
+		x := float64(i) / (SpectrumSamples - 1)
+		z := coef[0]*x*x + coef[1]*x + coef[2]
+		s[i] = 0.5 + z/(2*math.Sqrt(1+z*z))
+	}
+	return s
+}
+
+// UpsampleRGB estimates a smooth, physically plausible reflectance
+// spectrum that reproduces the sRGB colour under D65. It fits the
+// three coefficients of the Jakob–Hanika sigmoid-polynomial model with
+// Gauss–Newton in CIELAB, walking the target out from mid-gray so the
+// solve stays stable for saturated colours.
+func UpsampleRGB(c RGB) (Spectrum, error) {
+	xyz, err := c.ToXYZ()
+	if err != nil {
+		return Spectrum{}, err
+	}
+
+	white := IlluminantD65.White()
+	target := xyz.Adapt(WhiteD65, white).ToLab(white)
+
+	// the model cannot reach exactly 0 or 1, keep the target inside
+	target.L = math.Min(math.Max(target.L, 0.1), 99.9)
+
+	lab := func(coef [3]float64) [3]float64 {
+		l := sigmoidSpectrum(coef).Render(IlluminantD65).ToLab(white)
+		return [3]float64{l.L, l.A, l.B}
+	}
+
+	coef := [3]float64{}
+	start := lab(coef)
+	goal := [3]float64{target.L, target.A, target.B}
+
+	const steps = 8
+	for step := 1; step <= steps; step++ {
+		t := float64(step) / steps
+		want := [3]float64{
+			start[0] + (goal[0]-start[0])*t,
+			start[1] + (goal[1]-start[1])*t,
+			start[2] + (goal[2]-start[2])*t,
+		}
+
+		for iter := 0; iter < 25; iter++ {
+			cur := lab(coef)
+			res := [3]float64{cur[0] - want[0], cur[1] - want[1], cur[2] - want[2]}
+			if math.Abs(res[0])+math.Abs(res[1])+math.Abs(res[2]) < 1e-4 {
+				break
+			}
+
+			// finite-difference Jacobian
+			var J mat3
+			const h = 1e-5
+			for k := 0; k < 3; k++ {
+				p := coef
+				p[k] += h
+				v := lab(p)
+				for row := 0; row < 3; row++ {
+					J[row][k] = (v[row] - cur[row]) / h
+				}
+			}
+
+			delta, ok := solve3(J, res)
+			if !ok {
+				break
+			}
+
+			// backtrack if the step makes things worse
+			prev := res[0]*res[0] + res[1]*res[1] + res[2]*res[2]
+			scale := 1.0
+			for try := 0; try < 8; try++ {
+				next := [3]float64{coef[0] - scale*delta[0], coef[1] - scale*delta[1], coef[2] - scale*delta[2]}
+				v := lab(next)
+				r := [3]float64{v[0] - want[0], v[1] - want[1], v[2] - want[2]}
+				if r[0]*r[0]+r[1]*r[1]+r[2]*r[2] < prev {
+					coef = next
+					break
+				}
+				scale /= 2
+			}
+		}
+	}
+
+	return sigmoidSpectrum(coef), nil
+}
+
+// -------------------------------
+// Metamerism
+// -------------------------------
+
+// MetamerismIndex computes the CIE special metamerism index (change in
+// illuminant) of two reflectances: the ΔE*ab under the test illuminant
+// after the additive correction for any mismatch under the reference
+// illuminant.
+func MetamerismIndex(a, b Spectrum, reference, test Spectrum) float64 {
+	aRef, bRef := a.LabUnder(reference), b.LabUnder(reference)
+	aTest, bTest := a.LabUnder(test), b.LabUnder(test)
+
+	corrected := Lab{
+		L: bTest.L - (bRef.L - aRef.L),
+		A: bTest.A - (bRef.A - aRef.A),
+		B: bTest.B - (bRef.B - aRef.B),
+	}
+	return DeltaE76(aTest, corrected)
+}
@@ -0,0 +1,54 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpsampleRGBRoundTrip(t *testing.T) {
+	for _, c := range []RGB{
+		{0, 0, 0}, {255, 0, 0}, {0, 255, 0}, {0, 0, 255},
+		{255, 255, 0}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+		{128, 128, 128}, {200, 80, 40}, {30, 120, 90}, {90, 60, 200},
+	} {
+		s, err := UpsampleRGB(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range s {
+			if v < 0 || v > 1 {
+				t.Fatalf("%v: reflectance %v at %v nm is outside 0–1", c, v, s.Wavelength(i))
+			}
+		}
+
+		r, g, b, err := s.AppearanceUnder(IlluminantD65)
+		if err != nil || (RGB{r, g, b}) != c {
+			t.Errorf("%v → spectrum → sRGB = %v, %v", c, RGB{r, g, b}, err)
+		}
+
+		// black and white sit on the clamp at L* 0.1 and 99.9
+		xyz, _ := c.ToXYZ()
+		got := s.Render(IlluminantD65).Adapt(IlluminantD65.White(), WhiteD65).ToLab(WhiteD65)
+		tol := 1e-3
+		if c == (RGB{}) || c == (RGB{255, 255, 255}) {
+			tol = 0.11
+		}
+		if d := DeltaE76(got, xyz.ToLab(WhiteD65)); d > tol {
+			t.Errorf("%v: round trip ΔE76 %.5f, want ≤ %g", c, d, tol)
+		}
+	}
+}
+
+func TestMetamerismIndex(t *testing.T) {
+	a, _ := UpsampleRGB(RGB{200, 80, 40})
+	if mi := MetamerismIndex(a, a, IlluminantD65, IlluminantA); mi != 0 {
+		t.Errorf("identical spectra: index %v, want 0", mi)
+	}
+
+	// the additive correction makes the index symmetric
+	b, _ := UpsampleRGB(RGB{190, 90, 50})
+	ab := MetamerismIndex(a, b, IlluminantD65, IlluminantA)
+	if ba := MetamerismIndex(b, a, IlluminantD65, IlluminantA); ab <= 0 || math.Abs(ab-ba) > 1e-9 {
+		t.Errorf("index(a, b) = %v, index(b, a) = %v; want equal and positive", ab, ba)
+	}
+}
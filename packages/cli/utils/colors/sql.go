@@ -0,0 +1,121 @@
+package colors
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"image/color"
+)
+
+// -------------------------------
+// database/sql
+// -------------------------------
+
+// Every colour type is a driver.Valuer storing its CSS text form (see
+// MarshalText) and an sql.Scanner reading text in any notation Parse
+// accepts, or an integer packed as 0xRRGGBB sRGB. Scanning NULL is an
+// error; use sql.Null[colors.Color] for nullable columns.
+
+func (c Color) Value() (driver.Value, error)   { return textValue(c) }
+func (c RGB) Value() (driver.Value, error)     { return textValue(c) }
+func (h Hex) Value() (driver.Value, error)     { return textValue(h) }
+func (c HSL) Value() (driver.Value, error)     { return textValue(c) }
+func (c HCL) Value() (driver.Value, error)     { return textValue(c) }
+func (c OKLCH) Value() (driver.Value, error)   { return textValue(c) }
+func (c CMYK) Value() (driver.Value, error)    { return textValue(c) }
+func (c XYZ) Value() (driver.Value, error)     { return textValue(c) }
+func (c XYY) Value() (driver.Value, error)     { return textValue(c) }
+func (c Lab) Value() (driver.Value, error)     { return textValue(c) }
+func (c Munsell) Value() (driver.Value, error) { return textValue(c) }
+
+func (c *Color) Scan(src any) error { return scanColor(src, c) }
+func (c *RGB) Scan(src any) error   { return scanColor(src, c) }
+func (h *Hex) Scan(src any) error   { return scanColor(src, h) }
+func (c *HSL) Scan(src any) error   { return scanColor(src, c) }
+func (c *HCL) Scan(src any) error   { return scanColor(src, c) }
+func (c *OKLCH) Scan(src any) error { return scanColor(src, c) }
+func (c *CMYK) Scan(src any) error  { return scanColor(src, c) }
+func (c *XYZ) Scan(src any) error   { return scanColor(src, c) }
+func (c *XYY) Scan(src any) error   { return scanColor(src, c) }
+func (c *Lab) Scan(src any) error   { return scanColor(src, c) }
+
+// Scan reads Munsell notation, or a packed sRGB integer converted with
+// RGB.ToMunsell.
+func (c *Munsell) Scan(src any) error {
+	if v, ok := src.(int64); ok {
+		rgb, err := UnpackRGB(v)
+		if err != nil {
+			return err
+		}
+		m, err := rgb.ToMunsell()
+		if err == nil {
+			*c = m
+		}
+		return err
+	}
+	return scanColor(src, c)
+}
+
+func textValue(c textColor) (driver.Value, error) {
+	b, err := c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func scanColor(src any, text textUnmarshaler) error {
+	switch v := src.(type) {
+	case string:
+		return text.UnmarshalText([]byte(v))
+	case []byte:
+		return text.UnmarshalText(v)
+	case int64:
+		rgb, err := UnpackRGB(v)
+		if err != nil {
+			return err
+		}
+		return text.UnmarshalText(fmt.Appendf(nil, "#%02X%02X%02X", rgb.R, rgb.G, rgb.B))
+	case nil:
+		return fmt.Errorf("cannot scan NULL into a color")
+	}
+	return fmt.Errorf("cannot scan %T into a color", src)
+}
+
+// -------------------------------
+// Packed sRGB
+// -------------------------------
+
+// Packed stores the colour it wraps as an integer column holding
+// 0xRRGGBB, clipped to 8-bit sRGB with alpha dropped:
+//
+//	db.Exec(`INSERT INTO brand (accent) VALUES (?)`, colors.Packed[colors.OKLCH]{Color: accent})
+//
+// It scans from an integer or from text.
+type Packed[T color.Color] struct {
+	Color T
+}
+
+func (p Packed[T]) Value() (driver.Value, error) {
+	return PackRGB(FromColor(p.Color).RGB()), nil
+}
+
+func (p *Packed[T]) Scan(src any) error {
+	s, ok := any(&p.Color).(interface{ Scan(any) error })
+	if !ok {
+		return fmt.Errorf("cannot scan into %T", p.Color)
+	}
+	return s.Scan(src)
+}
+
+// PackRGB returns c, which must be valid, as 0xRRGGBB.
+func PackRGB(c RGB) int64 {
+	return int64(c.R)<<16 | int64(c.G)<<8 | int64(c.B)
+}
+
+// UnpackRGB reads a 0xRRGGBB integer.
+func UnpackRGB(v int64) (RGB, error) {
+	if v < 0 || v > 0xFFFFFF {
+		return RGB{}, fmt.Errorf("packed color %d is outside 0–0xFFFFFF", v)
+	}
+	return RGB{R: int(v >> 16), G: int(v >> 8 & 0xFF), B: int(v & 0xFF)}, nil
+}
@@ -0,0 +1,162 @@
+package colors
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+)
+
+// memDriver is an in-memory stand-in for SQLite with one single-column
+// table: Exec appends its argument as a row, and Query returns the row
+// whose 1-based id it is given. Values are stored and returned as the
+// driver receives them, as SQLite does for TEXT and INTEGER.
+type memDriver struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+func (d *memDriver) Open(string) (driver.Conn, error) { return memConn{d}, nil }
+
+type memConn struct{ d *memDriver }
+
+func (c memConn) Prepare(query string) (driver.Stmt, error) { return memStmt{c.d}, nil }
+func (memConn) Close() error                                { return nil }
+func (memConn) Begin() (driver.Tx, error)                   { return nil, driver.ErrSkip }
+
+type memStmt struct{ d *memDriver }
+
+func (memStmt) Close() error  { return nil }
+func (memStmt) NumInput() int { return 1 }
+
+func (s memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.rows = append(s.d.rows, args[0])
+	return memResult(len(s.d.rows)), nil
+}
+
+func (s memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &memRows{v: s.d.rows[args[0].(int64)-1]}, nil
+}
+
+type memResult int64
+
+func (r memResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r memResult) RowsAffected() (int64, error) { return 1, nil }
+
+type memRows struct {
+	v    driver.Value
+	done bool
+}
+
+func (*memRows) Columns() []string { return []string{"color"} }
+func (*memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0], r.done = r.v, true
+	return nil
+}
+
+func init() {
+	sql.Register("colors-mem", &memDriver{})
+}
+
+// roundTrip inserts in and scans the row back into out.
+func roundTrip(t *testing.T, db *sql.DB, in any, out any) driver.Value {
+	t.Helper()
+	res, err := db.Exec(`INSERT INTO colors (color) VALUES (?)`, in)
+	if err != nil {
+		t.Fatalf("insert %v: %v", in, err)
+	}
+	id, _ := res.LastInsertId()
+	var stored any
+	if err := db.QueryRow(`SELECT color FROM colors WHERE id = ?`, id).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow(`SELECT color FROM colors WHERE id = ?`, id).Scan(out); err != nil {
+		t.Fatalf("scan %v (stored %#v): %v", in, stored, err)
+	}
+	return stored
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	db, err := sql.Open("colors-mem", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	oklch, _ := Parse("oklch(0.6279 0.2577 29.23 / 0.5)")
+	for _, tc := range []struct {
+		name      string
+		in        textColor
+		out       any // *T
+		packed    driver.Valuer
+		packedOut driver.Valuer // *Packed[T]
+	}{
+		{"Color", oklch, new(Color), Packed[Color]{oklch}, new(Packed[Color])},
+		{"RGB", RGB{R: 12, G: 34, B: 168}, new(RGB), Packed[RGB]{RGB{R: 12, G: 34, B: 168}}, new(Packed[RGB])},
+		{"Hex", Hex("#0C22A8"), new(Hex), Packed[Hex]{"#0C22A8"}, new(Packed[Hex])},
+		{"HSL", HSL{H: 210, S: 0.5, L: 0.4}, new(HSL), Packed[HSL]{HSL{H: 210, S: 0.5, L: 0.4}}, new(Packed[HSL])},
+		{"HCL", HCL{H: 120, C: 40, L: 60}, new(HCL), Packed[HCL]{HCL{H: 120, C: 40, L: 60}}, new(Packed[HCL])},
+		{"OKLCH", OKLCH{L: 0.7, C: 0.1, H: 200}, new(OKLCH), Packed[OKLCH]{OKLCH{L: 0.7, C: 0.1, H: 200}}, new(Packed[OKLCH])},
+		{"CMYK", CMYK{C: 10, M: 20, Y: 30, K: 40}, new(CMYK), Packed[CMYK]{CMYK{C: 10, M: 20, Y: 30, K: 40}}, new(Packed[CMYK])},
+		{"XYZ", XYZ{X: 0.3, Y: 0.25, Z: 0.2}, new(XYZ), Packed[XYZ]{XYZ{X: 0.3, Y: 0.25, Z: 0.2}}, new(Packed[XYZ])},
+		{"XYY", XYY{X: 0.25, Y: 0.5, Lum: 0.25}, new(XYY), Packed[XYY]{XYY{X: 0.25, Y: 0.5, Lum: 0.25}}, new(Packed[XYY])},
+		{"Lab", Lab{L: 50, A: 20, B: -30}, new(Lab), Packed[Lab]{Lab{L: 50, A: 20, B: -30}}, new(Packed[Lab])},
+		{"Munsell", Munsell{H: 25, V: 5, C: 6}, new(Munsell), Packed[Munsell]{Munsell{H: 25, V: 5, C: 6}}, new(Packed[Munsell])},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want, _ := tc.in.MarshalText()
+			stored := roundTrip(t, db, tc.in, tc.out)
+			if stored != string(want) {
+				t.Errorf("stored %#v, want %q", stored, want)
+			}
+			if got, _ := tc.out.(textColor).MarshalText(); string(got) != string(want) {
+				t.Errorf("text round trip gave %s, want %s", got, want)
+			}
+
+			wantPacked, _ := tc.packed.Value()
+			stored = roundTrip(t, db, tc.packed, tc.packedOut)
+			if _, ok := stored.(int64); !ok || stored != wantPacked {
+				t.Errorf("packed stored %#v, want %#v", stored, wantPacked)
+			}
+			if got, _ := tc.packedOut.Value(); got != wantPacked {
+				t.Errorf("packed round trip gave %#X, want %#X", got, wantPacked)
+			}
+		})
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	db, err := sql.Open("colors-mem", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var c Color
+	if roundTripErr(db, nil, &c) == nil {
+		t.Error("scanning NULL into a Color succeeded")
+	}
+	var n sql.Null[Color]
+	if err := roundTripErr(db, nil, &n); err != nil || n.Valid {
+		t.Errorf("sql.Null[Color] from NULL = %+v, %v", n, err)
+	}
+}
+
+func roundTripErr(db *sql.DB, in any, out any) error {
+	res, err := db.Exec(`INSERT INTO colors (color) VALUES (?)`, in)
+	if err != nil {
+		return err
+	}
+	id, _ := res.LastInsertId()
+	return db.QueryRow(`SELECT color FROM colors WHERE id = ?`, id).Scan(out)
+}
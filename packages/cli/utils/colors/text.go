@@ -0,0 +1,395 @@
+package colors
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// -------------------------------
+// Text and JSON encoding
+// -------------------------------
+
+// Every colour type marshals to its CSS string at full precision, e.g.
+// "oklch(0.7 0.1 200)", so values in config structs round-trip. Each
+// unmarshals from any colour Parse accepts, converting to its own space,
+// or from an object of its fields such as {"L":0.7,"C":0.1,"H":200}.
+// Wrap a value in Object to marshal the object form instead.
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.css(exactNum)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Color) UnmarshalText(b []byte) error {
+	p, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*c = p
+	return nil
+}
+
+// Set implements pflag.Value, so a Color can back a command-line flag:
+//
+//	brand := colors.MustParse("oklch(0.62 0.2 260)")
+//	cmd.Flags().Var(&brand, "brand-color", "Brand colour")
+func (c *Color) Set(s string) error {
+	return c.UnmarshalText([]byte(s))
+}
+
+// Type implements pflag.Value.
+func (c *Color) Type() string {
+	return "color"
+}
+
+// colorFields is the object form of a Color.
+type colorFields struct {
+	Space  Space     `json:"space" yaml:"space"`
+	Values []float64 `json:"values" yaml:"values"`
+	Alpha  float64   `json:"alpha" yaml:"alpha"`
+}
+
+func (c Color) fields() any {
+	return colorFields{Space: c.Space, Values: c.V[:c.Space.Channels()], Alpha: c.Alpha}
+}
+
+func (c Color) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+func (c *Color) UnmarshalJSON(b []byte) error {
+	var f colorFields
+	if err := unmarshalColorJSON(b, c, &f); err != nil || f.Values == nil {
+		return err
+	}
+	return c.setFields(f)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler's older function form,
+// accepting a CSS string or the object form.
+func (c *Color) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if unmarshal(&s) == nil {
+		return c.UnmarshalText([]byte(s))
+	}
+	var f colorFields
+	if err := unmarshal(&f); err != nil {
+		return err
+	}
+	return c.setFields(f)
+}
+
+func (c *Color) setFields(f colorFields) error {
+	if len(f.Values) != f.Space.Channels() {
+		return fmt.Errorf("%s takes %d values, got %d", f.Space, f.Space.Channels(), len(f.Values))
+	}
+	*c = Color{Space: f.Space, Alpha: f.Alpha}
+	copy(c.V[:], f.Values)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Space) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(spaceNames) {
+		return nil, fmt.Errorf("unknown color space %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Space) UnmarshalText(b []byte) error {
+	p, err := ParseSpace(string(b))
+	if err != nil {
+		return err
+	}
+	*s = p
+	return nil
+}
+
+// Set implements pflag.Value.
+func (s *Space) Set(v string) error {
+	return s.UnmarshalText([]byte(v))
+}
+
+// Type implements pflag.Value.
+func (s *Space) Type() string {
+	return "space"
+}
+
+// FlagValue is the pflag.Value interface.
+type FlagValue interface {
+	String() string
+	Set(string) error
+	Type() string
+}
+
+type flagTarget[T any] interface {
+	*T
+	textColor
+	textUnmarshaler
+}
+
+// Flag adapts a pointer to any colour type to pflag.Value, parsing the
+// flag like UnmarshalText:
+//
+//	var bg colors.OKLCH
+//	cmd.Flags().Var(colors.Flag(&bg), "background", "Background colour")
+func Flag[T any, P flagTarget[T]](p P) FlagValue {
+	return colorFlag[T, P]{p: p}
+}
+
+type colorFlag[T any, P flagTarget[T]] struct {
+	p P
+}
+
+func (f colorFlag[T, P]) String() string {
+	if f.p == nil {
+		return ""
+	}
+	b, _ := f.p.MarshalText()
+	return string(b)
+}
+
+func (f colorFlag[T, P]) Set(s string) error { return f.p.UnmarshalText([]byte(s)) }
+func (f colorFlag[T, P]) Type() string       { return "color" }
+
+// -------------------------------
+// Per-type encoding
+// -------------------------------
+
+// The *Fields types share their colour's layout without its methods, so
+// they encode as plain objects.
+type (
+	rgbFields     RGB
+	hslFields     HSL
+	hclFields     HCL
+	oklchFields   OKLCH
+	cmykFields    CMYK
+	xyzFields     XYZ
+	xyyFields     XYY
+	labFields     Lab
+	munsellFields Munsell
+)
+
+func (c RGB) MarshalText() ([]byte, error)   { return c.toColor().MarshalText() }
+func (c HSL) MarshalText() ([]byte, error)   { return c.toColor().MarshalText() }
+func (c HCL) MarshalText() ([]byte, error)   { return c.toColor().MarshalText() }
+func (c OKLCH) MarshalText() ([]byte, error) { return c.toColor().MarshalText() }
+func (c CMYK) MarshalText() ([]byte, error)  { return c.toColor().MarshalText() }
+func (c XYZ) MarshalText() ([]byte, error)   { return c.toColor().MarshalText() }
+func (c XYY) MarshalText() ([]byte, error)   { return c.toColor().MarshalText() }
+func (c Lab) MarshalText() ([]byte, error)   { return c.toColor().MarshalText() }
+
+// MarshalText writes Munsell notation at full precision; only a zero
+// chroma is written as a neutral.
+func (c Munsell) MarshalText() ([]byte, error) {
+	if c.C == 0 {
+		return []byte("N " + exactFloat(c.V) + "/"), nil
+	}
+	return []byte(c.notation(true)), nil
+}
+
+// MarshalText returns the hex string as stored.
+func (h Hex) MarshalText() ([]byte, error) { return []byte(h), nil }
+
+func (c *RGB) UnmarshalText(b []byte) error {
+	p, err := Parse(string(b))
+	if err == nil {
+		*c = p.RGB()
+	}
+	return err
+}
+
+func (c *HSL) UnmarshalText(b []byte) error {
+	v, err := parseTo(b, SpaceHSL)
+	if err == nil {
+		*c = HSL{H: v[0], S: v[1] / 100, L: v[2] / 100}
+	}
+	return err
+}
+
+func (c *HCL) UnmarshalText(b []byte) error {
+	v, err := parseTo(b, SpaceHCL)
+	if err == nil {
+		*c = HCL{H: v[0], C: v[1], L: v[2]}
+	}
+	return err
+}
+
+func (c *OKLCH) UnmarshalText(b []byte) error {
+	v, err := parseTo(b, SpaceOKLCH)
+	if err == nil {
+		*c = OKLCH{L: v[0], C: v[1], H: v[2]}
+	}
+	return err
+}
+
+func (c *CMYK) UnmarshalText(b []byte) error {
+	v, err := parseTo(b, SpaceCMYK)
+	if err == nil {
+		*c = CMYK{C: v[0], M: v[1], Y: v[2], K: v[3]}
+	}
+	return err
+}
+
+func (c *XYZ) UnmarshalText(b []byte) error {
+	v, err := parseTo(b, SpaceXYZD65)
+	if err == nil {
+		*c = XYZ{X: v[0], Y: v[1], Z: v[2]}
+	}
+	return err
+}
+
+func (c *XYY) UnmarshalText(b []byte) error {
+	var xyz XYZ
+	err := xyz.UnmarshalText(b)
+	if err == nil {
+		*c = xyz.ToXYY()
+	}
+	return err
+}
+
+func (c *Lab) UnmarshalText(b []byte) error {
+	v, err := parseTo(b, SpaceLab)
+	if err == nil {
+		*c = Lab{L: v[0], A: v[1], B: v[2]}
+	}
+	return err
+}
+
+// UnmarshalText reads Munsell notation ("5R 4/14").
+func (c *Munsell) UnmarshalText(b []byte) error {
+	m, err := ParseMunsell(string(b))
+	if err == nil {
+		*c = m
+	}
+	return err
+}
+
+// UnmarshalText accepts any colour and stores it as #RRGGBB (#RRGGBBAA
+// when translucent).
+func (h *Hex) UnmarshalText(b []byte) error {
+	p, err := Parse(string(b))
+	if err == nil {
+		*h = Hex(p.Hex())
+	}
+	return err
+}
+
+func parseTo(b []byte, space Space) ([4]float64, error) {
+	c, err := Parse(string(b))
+	if err != nil {
+		return [4]float64{}, err
+	}
+	return c.To(space).V, nil
+}
+
+func (c RGB) fields() any     { return rgbFields(c) }
+func (c HSL) fields() any     { return hslFields(c) }
+func (c HCL) fields() any     { return hclFields(c) }
+func (c OKLCH) fields() any   { return oklchFields(c) }
+func (c CMYK) fields() any    { return cmykFields(c) }
+func (c XYZ) fields() any     { return xyzFields(c) }
+func (c XYY) fields() any     { return xyyFields(c) }
+func (c Lab) fields() any     { return labFields(c) }
+func (c Munsell) fields() any { return munsellFields(c) }
+
+func (c RGB) MarshalJSON() ([]byte, error)     { return marshalColorJSON(c) }
+func (h Hex) MarshalJSON() ([]byte, error)     { return marshalColorJSON(h) }
+func (c HSL) MarshalJSON() ([]byte, error)     { return marshalColorJSON(c) }
+func (c HCL) MarshalJSON() ([]byte, error)     { return marshalColorJSON(c) }
+func (c OKLCH) MarshalJSON() ([]byte, error)   { return marshalColorJSON(c) }
+func (c CMYK) MarshalJSON() ([]byte, error)    { return marshalColorJSON(c) }
+func (c XYZ) MarshalJSON() ([]byte, error)     { return marshalColorJSON(c) }
+func (c XYY) MarshalJSON() ([]byte, error)     { return marshalColorJSON(c) }
+func (c Lab) MarshalJSON() ([]byte, error)     { return marshalColorJSON(c) }
+func (c Munsell) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+func (c *RGB) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, c, (*rgbFields)(c)) }
+func (h *Hex) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, h, nil) }
+func (c *HSL) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, c, (*hslFields)(c)) }
+func (c *HCL) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, c, (*hclFields)(c)) }
+func (c *OKLCH) UnmarshalJSON(b []byte) error   { return unmarshalColorJSON(b, c, (*oklchFields)(c)) }
+func (c *CMYK) UnmarshalJSON(b []byte) error    { return unmarshalColorJSON(b, c, (*cmykFields)(c)) }
+func (c *XYZ) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, c, (*xyzFields)(c)) }
+func (c *XYY) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, c, (*xyyFields)(c)) }
+func (c *Lab) UnmarshalJSON(b []byte) error     { return unmarshalColorJSON(b, c, (*labFields)(c)) }
+func (c *Munsell) UnmarshalJSON(b []byte) error { return unmarshalColorJSON(b, c, (*munsellFields)(c)) }
+
+type textColor interface {
+	MarshalText() ([]byte, error)
+}
+
+type textUnmarshaler interface {
+	UnmarshalText([]byte) error
+}
+
+func marshalColorJSON(c textColor) ([]byte, error) {
+	b, err := c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+// unmarshalColorJSON decodes a JSON string through text and anything
+// else into fields; null leaves the value unchanged.
+func unmarshalColorJSON(b []byte, text textUnmarshaler, fields any) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		return text.UnmarshalText([]byte(s))
+	}
+	if string(b) == "null" {
+		return nil
+	}
+	if fields == nil {
+		return fmt.Errorf("color must be a string, got %s", b)
+	}
+	return json.Unmarshal(b, fields)
+}
+
+// -------------------------------
+// Object form
+// -------------------------------
+
+// Object marshals the colour it wraps as an object of its fields
+// instead of a CSS string, in JSON and YAML:
+//
+//	type Swatch struct {
+//		Lab colors.Object[colors.Lab] `json:"lab"` // {"L":50,"A":20,"B":-30}
+//	}
+//
+// It unmarshals from either form.
+type Object[T any] struct {
+	Color T
+}
+
+type hasFields interface {
+	fields() any
+}
+
+func (o Object[T]) MarshalJSON() ([]byte, error) {
+	if f, ok := any(o.Color).(hasFields); ok {
+		return json.Marshal(f.fields())
+	}
+	return json.Marshal(o.Color)
+}
+
+func (o *Object[T]) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &o.Color)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (o Object[T]) MarshalYAML() (any, error) {
+	if f, ok := any(o.Color).(hasFields); ok {
+		return f.fields(), nil
+	}
+	return o.Color, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler's older function form.
+func (o *Object[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshal(&o.Color)
+}
@@ -0,0 +1,179 @@
+package colors
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// near compares two values field by field, floats to within 1e-9.
+func near(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Struct:
+		for i := 0; i < va.NumField(); i++ {
+			if !near(va.Field(i).Interface(), vb.Field(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < va.Len(); i++ {
+			if !near(va.Index(i).Interface(), vb.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float64:
+		return math.Abs(va.Float()-vb.Float()) <= 1e-9
+	}
+	return a == b
+}
+
+// textRoundTrip checks text, JSON (string and object form) and flag
+// round trips of one value.
+func textRoundTrip[T any, P flagTarget[T]](t *testing.T, v T) {
+	t.Helper()
+	text, err := P(&v).MarshalText()
+	if err != nil {
+		t.Fatalf("%T: MarshalText: %v", v, err)
+	}
+
+	var fromText T
+	if err := P(&fromText).UnmarshalText(text); err != nil {
+		t.Fatalf("%T: UnmarshalText(%q): %v", v, text, err)
+	}
+	if !near(fromText, v) {
+		t.Errorf("%T: text %q gave %+v, want %+v", v, text, fromText, v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("%T: json: %v", v, err)
+	}
+	var fromJSON T
+	if err := json.Unmarshal(b, &fromJSON); err != nil {
+		t.Fatalf("%T: json %s: %v", v, b, err)
+	}
+	if !near(fromJSON, v) {
+		t.Errorf("%T: json %s gave %+v, want %+v", v, b, fromJSON, v)
+	}
+
+	ob, err := json.Marshal(Object[T]{Color: v})
+	if err != nil {
+		t.Fatalf("%T: object json: %v", v, err)
+	}
+	var fromObject Object[T]
+	if err := json.Unmarshal(ob, &fromObject); err != nil {
+		t.Fatalf("%T: object json %s: %v", v, ob, err)
+	}
+	if !near(fromObject.Color, v) {
+		t.Errorf("%T: object json %s gave %+v, want %+v", v, ob, fromObject.Color, v)
+	}
+
+	var fromFlag T
+	flag := Flag[T, P](&fromFlag)
+	if err := flag.Set(string(text)); err != nil {
+		t.Fatalf("%T: Set(%q): %v", v, text, err)
+	}
+	if !near(fromFlag, v) {
+		t.Errorf("%T: Set(%q) gave %+v, want %+v", v, text, fromFlag, v)
+	}
+	var fromString T
+	if err := P(&fromString).UnmarshalText([]byte(flag.String())); err != nil || !near(fromString, v) || flag.Type() != "color" {
+		t.Errorf("%T: flag String %q, Type %q", v, flag.String(), flag.Type())
+	}
+	if err := flag.Set("not a colour"); err == nil {
+		t.Errorf("%T: Set accepted an invalid colour", v)
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	textRoundTrip(t, RGB{R: 255, G: 87, B: 51})
+	textRoundTrip(t, Hex("#FF5733"))
+	textRoundTrip(t, Hex("#FF573380"))
+	textRoundTrip(t, HSL{H: 123.456789, S: 0.3333333, L: 0.6666667})
+	textRoundTrip(t, HCL{H: 271.123456789, C: 33.3333333, L: 47.1234567})
+	textRoundTrip(t, OKLCH{L: 0.712345678, C: 0.123456789, H: 200.987654321})
+	textRoundTrip(t, CMYK{C: 12.3456789, M: 0, Y: 98.7654321, K: 5.5})
+	textRoundTrip(t, XYZ{X: 0.412345678, Y: 0.212345678, Z: 0.019876543})
+	textRoundTrip(t, XYY{X: 0.3127, Y: 0.329, Lum: 0.5})
+	textRoundTrip(t, Lab{L: 53.2408, A: 80.0924, B: 67.2032})
+	textRoundTrip(t, Munsell{H: 37.123456, V: 4.56789, C: 12.345678})
+	textRoundTrip(t, Munsell{H: 95, V: 6, C: 0.02})
+	textRoundTrip(t, Munsell{V: 5.25})
+	textRoundTrip(t, MustParse("oklch(0.62 0.2 260 / 0.5)"))
+	textRoundTrip(t, MustParse("color(display-p3 0.123456789 1 0)"))
+}
+
+func TestMunsellMarshalTextPrecision(t *testing.T) {
+	for _, tc := range []struct {
+		m    Munsell
+		want string
+	}{
+		{Munsell{H: 5, V: 4, C: 14}, "5R 4/14"},
+		{Munsell{H: 37.123456, V: 4.56789, C: 12.345678}, "7.123456GY 4.56789/12.345678"},
+		{Munsell{H: 95, V: 6, C: 0.02}, "5RP 6/0.02"},
+		{Munsell{V: 5.25}, "N 5.25/"},
+	} {
+		got, err := tc.m.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%+v: MarshalText = %q, want %q", tc.m, got, tc.want)
+		}
+	}
+}
+
+func TestSpaceText(t *testing.T) {
+	for s := Space(0); int(s) < len(spaceNames); s++ {
+		b, err := s.MarshalText()
+		if err != nil {
+			t.Fatalf("%d: %v", s, err)
+		}
+		var got Space
+		if err := got.UnmarshalText(b); err != nil || got != s {
+			t.Errorf("%s: UnmarshalText gave %v, %v", b, got, err)
+		}
+
+		var flag Space
+		if err := flag.Set(string(b)); err != nil || flag != s || flag.Type() != "space" {
+			t.Errorf("%s: Set gave %v, %v", b, flag, err)
+		}
+
+		j, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fromJSON Space
+		if err := json.Unmarshal(j, &fromJSON); err != nil || fromJSON != s {
+			t.Errorf("%s: json gave %v, %v", j, fromJSON, err)
+		}
+	}
+
+	if _, err := Space(-1).MarshalText(); err == nil {
+		t.Error("expected an error for an unknown space")
+	}
+	var s Space
+	if err := s.Set("cmyk-ish"); err == nil {
+		t.Error("expected an error for an unknown space name")
+	}
+}
+
+func TestColorFlag(t *testing.T) {
+	var c Color
+	if err := c.Set("rebeccapurple"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Hex() != "#663399" || c.Type() != "color" {
+		t.Errorf("Set gave %v", c)
+	}
+	if err := c.Set("#12"); err == nil {
+		t.Error("expected an error")
+	}
+}
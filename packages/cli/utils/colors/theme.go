@@ -0,0 +1,142 @@
+package colors
+
+import "fmt"
+
+// -------------------------------
+// Semantic themes
+// -------------------------------
+
+// ThemeOptions configures BuildTheme.
+type ThemeOptions struct {
+	Primary Color
+	Accent  *Color           // optional second brand colour
+	Status  map[string]Color // overrides for success, warning, danger and info
+	Target  ContrastTarget   // level every foreground/background pair must meet
+}
+
+// ThemeToken is one semantic colour of a theme.
+type ThemeToken struct {
+	Name     string
+	Color    Color    // OKLCH of the 8-bit sRGB colour
+	On       []string // tokens it is shown over as a foreground
+	Contrast float64  // lowest contrast over On (0 when On is empty)
+}
+
+// ThemeStatuses are the status roles, in token order.
+var ThemeStatuses = []string{"success", "warning", "danger", "info"}
+
+// default status hues and chroma (OKLCH)
+var themeStatusSeeds = map[string][2]float64{
+	"success": {150, 0.15},
+	"warning": {75, 0.16},
+	"danger":  {27, 0.2},
+	"info":    {245, 0.14},
+}
+
+// neutral lightness and chroma per role, light then dark
+var themeNeutrals = map[string][2][2]float64{
+	"background": {{0.99, 0.004}, {0.17, 0.01}},
+	"surface":    {{0.965, 0.008}, {0.22, 0.012}},
+	"border":     {{0.88, 0.015}, {0.34, 0.02}},
+	"on-surface": {{0.2, 0.02}, {0.95, 0.008}},
+	"muted":      {{0.5, 0.03}, {0.72, 0.025}},
+}
+
+// BuildTheme derives the semantic tokens for light or dark mode:
+// background, surface, on-surface, muted, border, primary, on-primary,
+// accent and on-accent when an accent is given, then the statuses.
+// Neutrals are tinted with the primary hue. on-surface, muted and the
+// statuses are text over background and surface; on-primary and
+// on-accent are text over their fills. Foregrounds (and fills, when no
+// text colour can meet the target over them) move in OKLCH lightness
+// until every pair passes opt.Target. Fills are not text: like borders,
+// they are not checked over background or surface, and dark mode keeps
+// them at OKLCH lightness 0.65 or above. Token colours are quantized to the 8-bit sRGB of their hex
+// form, and contrast is checked and reported on those.
+func BuildTheme(opt ThemeOptions, dark bool) ([]ThemeToken, error) {
+	mode := 0
+	if dark {
+		mode = 1
+	}
+	hue := opt.Primary.To(SpaceOKLCH).V[2]
+	neutral := func(role string) Color {
+		v := themeNeutrals[role][mode]
+		return quantize8(NewColor(SpaceOKLCH, v[0], v[1], hue).MapToSRGB())
+	}
+
+	var tokens []ThemeToken
+	colors := map[string]Color{}
+	add := func(name string, c Color, on ...string) error {
+		tok := ThemeToken{Name: name, Color: quantize8(c), On: on}
+		if len(on) > 0 {
+			bgs := make([]Color, len(on))
+			for i, o := range on {
+				bgs[i] = colors[o]
+			}
+			fixed, ok := EnsureContrast(c, opt.Target, bgs...)
+			if !ok {
+				return fmt.Errorf("%s cannot reach %s over %v", name, opt.Target, on)
+			}
+			tok.Color = fixed
+			tok.Contrast = opt.Target.Contrast(fixed, bgs[0])
+			for _, bg := range bgs[1:] {
+				tok.Contrast = min(tok.Contrast, opt.Target.Contrast(fixed, bg))
+			}
+		}
+		colors[name] = tok.Color
+		tokens = append(tokens, tok)
+		return nil
+	}
+	// fill adds a brand fill and the text colour over it. Dark mode keeps
+	// fills light with dark text; light mode takes whichever text contrasts
+	// more. A fill the text fails on moves away from the text's lightness,
+	// judged with the fill as the background.
+	fill := func(name string, c Color) error {
+		f := c.MapToSRGB().To(SpaceOKLCH)
+		if dark && f.V[0] < 0.65 {
+			f.V[0] = 0.65
+			f = f.MapToSRGB().To(SpaceOKLCH)
+		}
+		f = quantize8(f)
+		light := quantize8(NewColor(SpaceOKLCH, 0.99, 0.01, f.V[2]).MapToSRGB())
+		darkText := quantize8(NewColor(SpaceOKLCH, 0.18, 0.02, f.V[2]).MapToSRGB())
+		text, away := light, 0.0
+		if dark || opt.Target.Contrast(darkText, f) > opt.Target.Contrast(light, f) {
+			text, away = darkText, 1.0
+		}
+		f, ok := seekLightness(f, func(bg Color) bool { return opt.Target.Passes(text, bg) }, away)
+		if !ok {
+			return fmt.Errorf("%s cannot reach %s with any text color", name, opt.Target)
+		}
+		if err := add(name, f); err != nil {
+			return err
+		}
+		return add("on-"+name, text, name)
+	}
+
+	steps := []func() error{
+		func() error { return add("background", neutral("background")) },
+		func() error { return add("surface", neutral("surface")) },
+		func() error { return add("on-surface", neutral("on-surface"), "surface", "background") },
+		func() error { return add("muted", neutral("muted"), "surface", "background") },
+		func() error { return add("border", neutral("border")) },
+		func() error { return fill("primary", opt.Primary) },
+	}
+	if opt.Accent != nil {
+		steps = append(steps, func() error { return fill("accent", *opt.Accent) })
+	}
+	for _, status := range ThemeStatuses {
+		c, ok := opt.Status[status]
+		if !ok {
+			seed := themeStatusSeeds[status]
+			c = NewColor(SpaceOKLCH, []float64{0.5, 0.75}[mode], seed[1], seed[0])
+		}
+		steps = append(steps, func() error { return add(status, c, "background", "surface") })
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
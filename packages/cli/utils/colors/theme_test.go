@@ -0,0 +1,65 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// TestBuildThemeEmittedContrast checks contrast on the colours as
+// written out: each token's hex, parsed back.
+func TestBuildThemeEmittedContrast(t *testing.T) {
+	info, _ := Parse("#38BDF8")
+	primary, _ := Parse("#3B82F6")
+	opt := ThemeOptions{Primary: primary, Status: map[string]Color{"info": info}, Target: ContrastTarget{Min: 4.5}}
+	for _, dark := range []bool{false, true} {
+		tokens, err := BuildTheme(opt, dark)
+		if err != nil {
+			t.Fatal(err)
+		}
+		emitted := map[string]Color{}
+		for _, tok := range tokens {
+			emitted[tok.Name], _ = Parse(tok.Color.Hex())
+		}
+		for _, tok := range tokens {
+			for _, on := range tok.On {
+				if c := ContrastRatio(emitted[tok.Name], emitted[on]); c < 4.5 {
+					t.Errorf("dark=%v: %s %s over %s %s is %.3f:1", dark, tok.Name, tok.Color.Hex(), on, emitted[on].Hex(), c)
+				}
+			}
+		}
+	}
+}
+
+// TestBuildThemeAPCAFills checks that under APCA dark-mode fills stay
+// light, and that text over every fill passes with the fill as the
+// background.
+func TestBuildThemeAPCAFills(t *testing.T) {
+	primary, _ := Parse("#3B82F6")
+	accent, _ := Parse("#7C3AED")
+	opt := ThemeOptions{Primary: primary, Accent: &accent, Target: ContrastTarget{APCA: true, Min: 75}}
+	fills := map[bool]map[string]Color{}
+	for _, dark := range []bool{false, true} {
+		tokens, err := BuildTheme(opt, dark)
+		if err != nil {
+			t.Fatal(err)
+		}
+		emitted := map[string]Color{}
+		for _, tok := range tokens {
+			emitted[tok.Name], _ = Parse(tok.Color.Hex())
+		}
+		fills[dark] = emitted
+		for _, name := range []string{"primary", "accent"} {
+			if l := emitted[name].To(SpaceOKLCH).V[0]; dark && l < 0.65 {
+				t.Errorf("dark %s %s has lightness %.3f, want at least 0.65", name, emitted[name].Hex(), l)
+			}
+			if lc := math.Abs(APCAContrast(emitted["on-"+name], emitted[name])); lc < 75 {
+				t.Errorf("dark=%v: on-%s over %s is Lc %.2f", dark, name, emitted[name].Hex(), lc)
+			}
+		}
+	}
+	for _, name := range []string{"primary", "accent"} {
+		if d := DeltaEOK(fills[false][name], fills[true][name]); d < 0.1 {
+			t.Errorf("%s is %s in light and %s in dark mode (ΔEOK %.3f)", name, fills[false][name].Hex(), fills[true][name].Hex(), d)
+		}
+	}
+}
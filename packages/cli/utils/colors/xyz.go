@@ -0,0 +1,195 @@
+package colors
+
+import (
+	"errors"
+	"math"
+)
+
+// -------------------------------
+// XYZ struct
+// -------------------------------
+type XYZ struct {
+	X float64 // Y = 1 for the reference white
+	Y float64
+	Z float64
+}
+
+// -------------------------------
+// Reference whites (CIE 1931 2°)
+// -------------------------------
+var (
+	WhiteA   = XYZ{X: 1.09850, Y: 1, Z: 0.35585}
+	WhiteC   = XYZ{X: 0.98074, Y: 1, Z: 1.18232}
+	WhiteD50 = XYZ{X: 0.96422, Y: 1, Z: 0.82521}
+	WhiteD55 = XYZ{X: 0.95682, Y: 1, Z: 0.92149}
+	WhiteD65 = XYZ{X: 0.95047, Y: 1, Z: 1.08883}
+	WhiteD75 = XYZ{X: 0.94972, Y: 1, Z: 1.22638}
+	WhiteE   = XYZ{X: 1, Y: 1, Z: 1}
+)
+
+// -------------------------------
+// XYY struct (CIE xyY)
+// -------------------------------
+type XYY struct {
+	X   float64 // chromaticity x
+	Y   float64 // chromaticity y
+	Lum float64 // luminance Y (1 for the reference white)
+}
+
+// -------------------------------
+// XYZ ↔ xyY
+// -------------------------------
+func (c XYZ) ToXYY() XYY {
+	sum := c.X + c.Y + c.Z
+	if sum == 0 {
+		// black takes the chromaticity of D65
+		return XYY{X: 0.3127, Y: 0.3290, Lum: 0}
+	}
+	return XYY{X: c.X / sum, Y: c.Y / sum, Lum: c.Y}
+}
+
+func (c XYY) ToXYZ() XYZ {
+	if c.Y == 0 {
+		return XYZ{}
+	}
+	return XYZ{
+		X: c.X * c.Lum / c.Y,
+		Y: c.Lum,
+		Z: (1 - c.X - c.Y) * c.Lum / c.Y,
+	}
+}
+
+// -------------------------------
+// Lab struct (CIELAB)
+// -------------------------------
+
+// Lab is CIELAB. As a color.Color, from HCL.Lab and in ToHCL it is
+// relative to D50, as in CSS, ICC and CGATS; XYZ.ToLab and Lab.ToXYZ
+// take the white explicitly.
+type Lab struct {
+	L float64 // Lightness 0–100
+	A float64 // green–red
+	B float64 // blue–yellow
+}
+
+// -------------------------------
+// RGB → XYZ (D65, unclamped)
+// -------------------------------
+func (c RGB) ToXYZ() (XYZ, error) {
+	if !c.IsValid() {
+		return XYZ{}, errors.New("invalid RGB value")
+	}
+
+	R, G, B := rgb8ToLinear(c.R, c.G, c.B)
+
+	return XYZ{
+		X: 0.4124564*R + 0.3575761*G + 0.1804375*B,
+		Y: 0.2126729*R + 0.7151522*G + 0.0721750*B,
+		Z: 0.0193339*R + 0.1191920*G + 0.9503041*B,
+	}, nil
+}
+
+// -------------------------------
+// XYZ (D65) → RGB 0–255, clipped
+// -------------------------------
+func (c XYZ) ToRGB() (r, g, b int, err error) {
+	if math.IsNaN(c.X) || math.IsNaN(c.Y) || math.IsNaN(c.Z) {
+		return 0, 0, 0, errors.New("invalid XYZ value")
+	}
+	R, G, B := xyzToLinearRGB(c.X, c.Y, c.Z)
+	r, g, b = linearToRGB8(R, G, B)
+	return r, g, b, nil
+}
+
+// -------------------------------
+// XYZ → Lab relative to a white
+// -------------------------------
+func (c XYZ) ToLab(white XYZ) Lab {
+	f := func(t float64) float64 {
+		if t > 0.008856 {
+			return math.Cbrt(t)
+		}
+		return 7.787*t + 16.0/116
+	}
+
+	fX, fY, fZ := f(c.X/white.X), f(c.Y/white.Y), f(c.Z/white.Z)
+	return Lab{
+		L: 116*fY - 16,
+		A: 500 * (fX - fY),
+		B: 200 * (fY - fZ),
+	}
+}
+
+// -------------------------------
+// Lab → XYZ relative to a white
+// -------------------------------
+func (c Lab) ToXYZ(white XYZ) XYZ {
+	fY := (c.L + 16) / 116
+	fX := c.A/500 + fY
+	fZ := fY - c.B/200
+
+	finv := func(t float64) float64 {
+		if t*t*t > 0.008856 {
+			return t * t * t
+		}
+		return (t - 16.0/116) / 7.787
+	}
+
+	return XYZ{
+		X: white.X * finv(fX),
+		Y: white.Y * finv(fY),
+		Z: white.Z * finv(fZ),
+	}
+}
+
+// -------------------------------
+// Lab (D50) → HCL (CIELCh D65)
+// -------------------------------
+func (c Lab) ToHCL() HCL {
+	v := NewColor(SpaceLab, c.L, c.A, c.B).To(SpaceHCL).V
+	return HCL{H: v[0], C: v[1], L: v[2]}
+}
+
+// -------------------------------
+// HCL (D65) → Lab struct (D50)
+// -------------------------------
+func (c HCL) Lab() Lab {
+	v := NewColor(SpaceHCL, c.H, c.C, c.L).To(SpaceLab).V
+	return Lab{L: v[0], A: v[1], B: v[2]}
+}
+
+// xyzToHCL returns CIELCh relative to D65.
+func xyzToHCL(c XYZ) HCL {
+	v := spaceFromXYZ(SpaceHCL, c)
+	return HCL{H: v[0], C: v[1], L: v[2]}
+}
+
+// -------------------------------
+// Chromatic adaptation (Bradford)
+// -------------------------------
+var bradford = mat3{
+	{0.8951, 0.2664, -0.1614},
+	{-0.7502, 1.7135, 0.0367},
+	{0.0389, -0.0685, 1.0296},
+}
+
+// adaptationMatrix returns the Bradford matrix mapping XYZ seen under
+// `from` to the corresponding colour under `to`.
+func adaptationMatrix(from, to XYZ) mat3 {
+	src := bradford.mulVec([3]float64{from.X, from.Y, from.Z})
+	dst := bradford.mulVec([3]float64{to.X, to.Y, to.Z})
+	scale := mat3{
+		{dst[0] / src[0], 0, 0},
+		{0, dst[1] / src[1], 0},
+		{0, 0, dst[2] / src[2]},
+	}
+	inv, _ := bradford.inverse()
+	return inv.mul(scale).mul(bradford)
+}
+
+// Adapt converts a colour seen under the `from` white to the
+// corresponding colour under the `to` white.
+func (c XYZ) Adapt(from, to XYZ) XYZ {
+	v := adaptationMatrix(from, to).mulVec([3]float64{c.X, c.Y, c.Z})
+	return XYZ{X: v[0], Y: v[1], Z: v[2]}
+}
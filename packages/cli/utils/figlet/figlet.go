@@ -2,12 +2,20 @@ package figlet
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/mbndr/figlet4go"
+	"golang.org/x/term"
 )
 
-// LogProgramName ...
+// Quiet suppresses the banner, e.g. for machine-readable output.
+var Quiet bool
+
+// LogProgramName prints the banner when stdout is a terminal.
 func LogProgramName() {
+	if Quiet || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return
+	}
 	ascii := figlet4go.NewAsciiRender()
 	programString, _ := ascii.Render("Colors")
 	fmt.Print(programString)
@@ -0,0 +1,180 @@
+package icc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// -------------------------------
+// Curves
+// -------------------------------
+
+// Curve is a one-dimensional tone curve on 0–1.
+type Curve interface {
+	Eval(x float64) float64
+}
+
+// GammaCurve is y = x^γ (curveType with one entry, or parametric type 0).
+type GammaCurve float64
+
+func (g GammaCurve) Eval(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return math.Pow(x, float64(g))
+}
+
+// TableCurve is a sampled curve, linearly interpolated.
+type TableCurve []float64
+
+func (t TableCurve) Eval(x float64) float64 {
+	n := len(t)
+	if n == 0 {
+		return x
+	}
+	if n == 1 {
+		return t[0]
+	}
+	x = clamp01(x) * float64(n-1)
+	i := int(x)
+	if i >= n-1 {
+		return t[n-1]
+	}
+	f := x - float64(i)
+	return t[i]*(1-f) + t[i+1]*f
+}
+
+// ParametricCurve implements parametricCurveType function types 0–4.
+type ParametricCurve struct {
+	Type   int
+	Params []float64 // g, a, b, c, d, e, f as used by Type
+}
+
+func (p ParametricCurve) Eval(x float64) float64 {
+	g := p.Params[0]
+	pow := func(v float64) float64 {
+		if v <= 0 {
+			return 0
+		}
+		return math.Pow(v, g)
+	}
+	switch p.Type {
+	case 0:
+		return pow(x)
+	case 1:
+		a, b := p.Params[1], p.Params[2]
+		if x >= -b/a {
+			return pow(a*x + b)
+		}
+		return 0
+	case 2:
+		a, b, c := p.Params[1], p.Params[2], p.Params[3]
+		if x >= -b/a {
+			return pow(a*x+b) + c
+		}
+		return c
+	case 3:
+		a, b, c, d := p.Params[1], p.Params[2], p.Params[3], p.Params[4]
+		if x >= d {
+			return pow(a*x + b)
+		}
+		return c * x
+	case 4:
+		a, b, c, d, e, f := p.Params[1], p.Params[2], p.Params[3], p.Params[4], p.Params[5], p.Params[6]
+		if x >= d {
+			return pow(a*x+b) + e
+		}
+		return c*x + f
+	}
+	return x
+}
+
+type identityCurve struct{}
+
+func (identityCurve) Eval(x float64) float64 { return x }
+
+var parametricParamCount = []int{1, 3, 4, 5, 7}
+
+// parseCurve decodes a curveType or parametricCurveType and returns the
+// number of bytes used, padded to a 4-byte boundary.
+func parseCurve(b []byte) (Curve, int, error) {
+	if len(b) < 12 {
+		return nil, 0, errors.New("curve too short")
+	}
+	switch string(b[0:4]) {
+	case "curv":
+		n := int(binary.BigEndian.Uint32(b[8:12]))
+		size := pad4(12 + 2*n)
+		if 12+2*n > len(b) {
+			return nil, 0, errors.New("curve table truncated")
+		}
+		switch n {
+		case 0:
+			return identityCurve{}, size, nil
+		case 1:
+			return GammaCurve(float64(binary.BigEndian.Uint16(b[12:14])) / 256), size, nil
+		}
+		t := make(TableCurve, n)
+		for i := range t {
+			t[i] = float64(binary.BigEndian.Uint16(b[12+2*i:])) / 65535
+		}
+		return t, size, nil
+
+	case "para":
+		typ := int(binary.BigEndian.Uint16(b[8:10]))
+		if typ < 0 || typ >= len(parametricParamCount) {
+			return nil, 0, fmt.Errorf("unknown parametric curve type %d", typ)
+		}
+		n := parametricParamCount[typ]
+		if 12+4*n > len(b) {
+			return nil, 0, errors.New("parametric curve truncated")
+		}
+		params := make([]float64, n)
+		for i := range params {
+			params[i] = s15Fixed16(b[12+4*i:])
+		}
+		return ParametricCurve{Type: typ, Params: params}, pad4(12 + 4*n), nil
+	}
+	return nil, 0, fmt.Errorf("unsupported curve type %q", string(b[0:4]))
+}
+
+// invert numerically inverts a monotonic curve on 0–1.
+func invert(c Curve, y float64) float64 {
+	if g, ok := c.(GammaCurve); ok && g > 0 {
+		if y <= 0 {
+			return 0
+		}
+		return math.Pow(y, 1/float64(g))
+	}
+	if _, ok := c.(identityCurve); ok {
+		return y
+	}
+
+	lo, hi := 0.0, 1.0
+	increasing := c.Eval(1) >= c.Eval(0)
+	for i := 0; i < 50; i++ {
+		mid := (lo + hi) / 2
+		if (c.Eval(mid) < y) == increasing {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
+
+func clamp01(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
@@ -0,0 +1,375 @@
+package icc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// -------------------------------
+// LUT pipelines (A2B / B2A tags)
+// -------------------------------
+
+// pipeline evaluates a LUT-based tag on normalised (0–1) values. For
+// the PCS side, pcsLabScale converts the tag's Lab encoding to the v4
+// one (the v2 16-bit encoding puts L = 100 at 0xFF00).
+type pipeline struct {
+	inputs, outputs int
+	stages          []stage
+	pcsLabScale     float64
+}
+
+type stage func(in []float64) []float64
+
+func (p *pipeline) eval(in []float64) []float64 {
+	v := in
+	for _, s := range p.stages {
+		v = s(v)
+	}
+	return v
+}
+
+func curveStage(curves []Curve) stage {
+	return func(in []float64) []float64 {
+		out := make([]float64, len(in))
+		for i, v := range in {
+			out[i] = clamp01(curves[i].Eval(clamp01(v)))
+		}
+		return out
+	}
+}
+
+func matrixStage(m [3][3]float64, offset [3]float64) stage {
+	return func(in []float64) []float64 {
+		out := make([]float64, 3)
+		for i := 0; i < 3; i++ {
+			out[i] = m[i][0]*in[0] + m[i][1]*in[1] + m[i][2]*in[2] + offset[i]
+		}
+		return out
+	}
+}
+
+// clut is a multidimensional colour lookup table, interpolated
+// multilinearly. The first input channel varies slowest.
+type clut struct {
+	grid    []int
+	outputs int
+	data    []float64
+}
+
+func (c *clut) eval(in []float64) []float64 {
+	n := len(c.grid)
+	base := make([]int, n)
+	frac := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x := clamp01(in[i]) * float64(c.grid[i]-1)
+		b := int(x)
+		if b >= c.grid[i]-1 {
+			b = c.grid[i] - 2
+			if b < 0 {
+				b = 0
+			}
+		}
+		base[i] = b
+		frac[i] = x - float64(b)
+	}
+
+	out := make([]float64, c.outputs)
+	for corner := 0; corner < 1<<n; corner++ {
+		w := 1.0
+		idx := 0
+		for i := 0; i < n; i++ {
+			p := base[i]
+			if corner&(1<<(n-1-i)) != 0 {
+				if c.grid[i] > 1 {
+					p++
+				}
+				w *= frac[i]
+			} else {
+				w *= 1 - frac[i]
+			}
+			idx = idx*c.grid[i] + p
+		}
+		if w == 0 {
+			continue
+		}
+		for o := 0; o < c.outputs; o++ {
+			out[o] += w * c.data[idx*c.outputs+o]
+		}
+	}
+	return out
+}
+
+func clutStage(c *clut) stage {
+	return c.eval
+}
+
+// maxChannels is the most channels a LUT may have: ICC colour spaces
+// stop at 15 (FCLR), and lutAtoBType leaves room for 16 grid sizes.
+const maxChannels = 15
+
+// clutPoints returns the number of grid points of a CLUT after checking
+// that every dimension has at least 2 points and that the table, at
+// outputs × bytesPer bytes a point, fits in the avail bytes left.
+func clutPoints(grids []int, outputs, bytesPer, avail int) (int, error) {
+	points := 1
+	for _, g := range grids {
+		if g < 2 {
+			return 0, fmt.Errorf("invalid clut grid size %d", g)
+		}
+		// checked before multiplying so a large grid cannot overflow
+		if points > avail/(g*outputs*bytesPer) {
+			return 0, errors.New("clut truncated")
+		}
+		points *= g
+	}
+	if points*outputs*bytesPer > avail {
+		return 0, errors.New("clut truncated")
+	}
+	return points, nil
+}
+
+// parseLut decodes lut8Type, lut16Type, lutAtoBType and lutBtoAType.
+// toPCS is true for A2B tags.
+func parseLut(b []byte, toPCS bool) (pipeline, error) {
+	if len(b) < 12 {
+		return pipeline{}, errors.New("lut too short")
+	}
+	switch string(b[0:4]) {
+	case "mft1":
+		return parseLutLegacy(b, 1, toPCS)
+	case "mft2":
+		return parseLutLegacy(b, 2, toPCS)
+	case "mAB ":
+		return parseLutAB(b, true)
+	case "mBA ":
+		return parseLutAB(b, false)
+	}
+	return pipeline{}, fmt.Errorf("unsupported lut type %q", string(b[0:4]))
+}
+
+// lut8Type / lut16Type: matrix → input curves → CLUT → output curves.
+func parseLutLegacy(b []byte, bytesPer int, toPCS bool) (pipeline, error) {
+	if len(b) < 52 {
+		return pipeline{}, errors.New("lut truncated")
+	}
+	in, out, grid := int(b[8]), int(b[9]), int(b[10])
+	if in == 0 || out == 0 || in > maxChannels || out > maxChannels || grid < 2 {
+		return pipeline{}, errors.New("invalid lut dimensions")
+	}
+
+	var m [3][3]float64
+	for i := 0; i < 9; i++ {
+		m[i/3][i%3] = s15Fixed16(b[12+4*i:])
+	}
+
+	inEntries, outEntries, pos := 256, 256, 48
+	if bytesPer == 2 {
+		inEntries = int(binary.BigEndian.Uint16(b[48:50]))
+		outEntries = int(binary.BigEndian.Uint16(b[50:52]))
+		pos = 52
+	}
+
+	read := func(n int) ([]float64, error) {
+		if pos+n*bytesPer > len(b) {
+			return nil, errors.New("lut truncated")
+		}
+		v := make([]float64, n)
+		for i := range v {
+			if bytesPer == 1 {
+				v[i] = float64(b[pos+i]) / 255
+			} else {
+				v[i] = float64(binary.BigEndian.Uint16(b[pos+2*i:])) / 65535
+			}
+		}
+		pos += n * bytesPer
+		return v, nil
+	}
+
+	inCurves := make([]Curve, in)
+	for i := range inCurves {
+		t, err := read(inEntries)
+		if err != nil {
+			return pipeline{}, err
+		}
+		inCurves[i] = TableCurve(t)
+	}
+
+	grids := make([]int, in)
+	for i := range grids {
+		grids[i] = grid
+	}
+	points, err := clutPoints(grids, out, bytesPer, len(b)-pos)
+	if err != nil {
+		return pipeline{}, err
+	}
+	data, err := read(points * out)
+	if err != nil {
+		return pipeline{}, err
+	}
+
+	outCurves := make([]Curve, out)
+	for i := range outCurves {
+		t, err := read(outEntries)
+		if err != nil {
+			return pipeline{}, err
+		}
+		outCurves[i] = TableCurve(t)
+	}
+
+	p := pipeline{inputs: in, outputs: out, pcsLabScale: 1}
+	if bytesPer == 2 {
+		p.pcsLabScale = 65535.0 / 65280.0
+	}
+	// the matrix only applies when the input is PCS XYZ; it is the
+	// identity otherwise, so including it is harmless
+	if !toPCS && in == 3 && m != ([3][3]float64{}) {
+		p.stages = append(p.stages, matrixStage(m, [3]float64{}))
+	}
+	p.stages = append(p.stages,
+		curveStage(inCurves),
+		clutStage(&clut{grid: grids, outputs: out, data: data}),
+		curveStage(outCurves),
+	)
+	return p, nil
+}
+
+// lutAtoBType: A curves → CLUT → M curves → matrix → B curves.
+// lutBtoAType: B curves → matrix → M curves → CLUT → A curves.
+func parseLutAB(b []byte, aToB bool) (pipeline, error) {
+	if len(b) < 32 {
+		return pipeline{}, errors.New("lut truncated")
+	}
+	in, out := int(b[8]), int(b[9])
+	if in == 0 || out == 0 || in > maxChannels || out > maxChannels {
+		return pipeline{}, errors.New("invalid lut dimensions")
+	}
+	off := func(i int) int { return int(binary.BigEndian.Uint32(b[12+4*i:])) }
+	offB, offMatrix, offM, offCLUT, offA := off(0), off(1), off(2), off(3), off(4)
+
+	curves := func(offset, n int) ([]Curve, error) {
+		if offset == 0 {
+			return nil, nil
+		}
+		cs := make([]Curve, n)
+		pos := offset
+		for i := 0; i < n; i++ {
+			if pos >= len(b) {
+				return nil, errors.New("curve offset out of bounds")
+			}
+			c, size, err := parseCurve(b[pos:])
+			if err != nil {
+				return nil, err
+			}
+			cs[i] = c
+			pos += size
+		}
+		return cs, nil
+	}
+
+	// PCS side has 3 channels, device side in (A2B) or out (B2A)
+	pcsCh, devCh := out, in
+	if !aToB {
+		pcsCh, devCh = in, out
+	}
+
+	bCurves, err := curves(offB, pcsCh)
+	if err != nil {
+		return pipeline{}, fmt.Errorf("B curves: %w", err)
+	}
+	mCurves, err := curves(offM, pcsCh)
+	if err != nil {
+		return pipeline{}, fmt.Errorf("M curves: %w", err)
+	}
+	aCurves, err := curves(offA, devCh)
+	if err != nil {
+		return pipeline{}, fmt.Errorf("A curves: %w", err)
+	}
+
+	var matrix stage
+	if offMatrix != 0 {
+		if offMatrix+48 > len(b) {
+			return pipeline{}, errors.New("matrix out of bounds")
+		}
+		var m [3][3]float64
+		var o [3]float64
+		for i := 0; i < 9; i++ {
+			m[i/3][i%3] = s15Fixed16(b[offMatrix+4*i:])
+		}
+		for i := 0; i < 3; i++ {
+			o[i] = s15Fixed16(b[offMatrix+36+4*i:])
+		}
+		matrix = matrixStage(m, o)
+	}
+
+	var table stage
+	if offCLUT != 0 {
+		clutIn, clutOut := in, out
+		if offCLUT+20 > len(b) {
+			return pipeline{}, errors.New("clut out of bounds")
+		}
+		grids := make([]int, clutIn)
+		for i := range grids {
+			grids[i] = int(b[offCLUT+i])
+		}
+		precision := int(b[offCLUT+16])
+		if precision != 1 && precision != 2 {
+			return pipeline{}, fmt.Errorf("invalid clut precision %d", precision)
+		}
+		pos := offCLUT + 20
+		points, err := clutPoints(grids, clutOut, precision, len(b)-pos)
+		if err != nil {
+			return pipeline{}, err
+		}
+		data := make([]float64, points*clutOut)
+		for i := range data {
+			if precision == 1 {
+				data[i] = float64(b[pos+i]) / 255
+			} else {
+				data[i] = float64(binary.BigEndian.Uint16(b[pos+2*i:])) / 65535
+			}
+		}
+		table = clutStage(&clut{grid: grids, outputs: clutOut, data: data})
+	}
+
+	// each stage must take the channels the previous one produced
+	p := pipeline{inputs: in, outputs: out, pcsLabScale: 1}
+	ch := in
+	add := func(name string, s stage, stageIn, stageOut int) {
+		if s == nil || err != nil {
+			return
+		}
+		if stageIn != ch {
+			err = fmt.Errorf("%s: expects %d channels, previous stage gives %d", name, stageIn, ch)
+			return
+		}
+		p.stages = append(p.stages, s)
+		ch = stageOut
+	}
+	addCurves := func(name string, cs []Curve) {
+		if cs != nil {
+			add(name, curveStage(cs), len(cs), len(cs))
+		}
+	}
+
+	if aToB {
+		addCurves("A curves", aCurves)
+		add("clut", table, in, out)
+		addCurves("M curves", mCurves)
+		add("matrix", matrix, 3, 3)
+		addCurves("B curves", bCurves)
+	} else {
+		addCurves("B curves", bCurves)
+		add("matrix", matrix, 3, 3)
+		addCurves("M curves", mCurves)
+		add("clut", table, in, out)
+		addCurves("A curves", aCurves)
+	}
+	if err == nil && ch != out {
+		err = fmt.Errorf("pipeline gives %d channels, want %d", ch, out)
+	}
+	if err != nil {
+		return pipeline{}, err
+	}
+	return p, nil
+}
@@ -0,0 +1,145 @@
+package icc
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// lut16Tag builds an mft2 tag with identity curves and a grid-point CLUT.
+func lut16Tag(in, out, grid, entries int) []byte {
+	b := make([]byte, 52)
+	copy(b, "mft2")
+	b[8], b[9], b[10] = byte(in), byte(out), byte(grid)
+	binary.BigEndian.PutUint16(b[48:], uint16(entries))
+	binary.BigEndian.PutUint16(b[50:], uint16(entries))
+	table := func() {
+		for i := 0; i < entries; i++ {
+			b = binary.BigEndian.AppendUint16(b, uint16(i*65535/(entries-1)))
+		}
+	}
+	for i := 0; i < in; i++ {
+		table()
+	}
+	points := 1
+	for i := 0; i < in; i++ {
+		points *= grid
+	}
+	for i := 0; i < points*out; i++ {
+		b = binary.BigEndian.AppendUint16(b, uint16(i*257))
+	}
+	for i := 0; i < out; i++ {
+		table()
+	}
+	return b
+}
+
+// lutABTag builds an mAB tag: identity A and B curves around a 2-point
+// CLUT with 8-bit precision.
+func lutABTag(in, out int) []byte {
+	curves := func(n int) []byte {
+		var c []byte
+		for i := 0; i < n; i++ {
+			c = append(c, "curv\x00\x00\x00\x00\x00\x00\x00\x00"...)
+		}
+		return c
+	}
+	b := make([]byte, 32)
+	copy(b, "mAB ")
+	b[8], b[9] = byte(in), byte(out)
+
+	binary.BigEndian.PutUint32(b[12:], uint32(len(b)))
+	b = append(b, curves(out)...)
+
+	binary.BigEndian.PutUint32(b[24:], uint32(len(b)))
+	grid := make([]byte, 20)
+	points := 1
+	for i := 0; i < in; i++ {
+		grid[i] = 2
+		points *= 2
+	}
+	grid[16] = 1
+	b = append(b, grid...)
+	for i := 0; i < points*out; i++ {
+		b = append(b, byte(i))
+	}
+	b = append(b, make([]byte, pad4(len(b))-len(b))...)
+
+	binary.BigEndian.PutUint32(b[28:], uint32(len(b)))
+	return append(b, curves(in)...)
+}
+
+func TestParseLutValid(t *testing.T) {
+	for name, tag := range map[string][]byte{
+		"mft2": lut16Tag(3, 3, 9, 256),
+		"mAB":  lutABTag(4, 3),
+	} {
+		p, err := parseLut(tag, true)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := p.eval(make([]float64, p.inputs)); len(got) != p.outputs {
+			t.Errorf("%s: eval gave %d channels, want %d", name, len(got), p.outputs)
+		}
+	}
+}
+
+func TestParseLutMalformed(t *testing.T) {
+	oversized := lut16Tag(3, 3, 2, 2)
+	oversized[8] = 255
+	bigGrid := lut16Tag(3, 3, 2, 2)
+	bigGrid[8], bigGrid[10] = 15, 255 // 255^15 points overflows int
+	zeroGrid := lutABTag(3, 3)
+	zeroGrid[binary.BigEndian.Uint32(zeroGrid[24:])+1] = 0
+	mismatch := lutABTag(3, 3)
+	binary.BigEndian.PutUint32(mismatch[24:], 0) // no CLUT, so 2 A curves feed 3 B curves
+	mismatch[8] = 2
+
+	for _, tc := range []struct {
+		name string
+		tag  []byte
+		want string
+	}{
+		{"too many channels", oversized, "invalid lut dimensions"},
+		{"grid overflow", bigGrid, "clut truncated"},
+		{"grid of 0", zeroGrid, "invalid clut grid size 0"},
+		{"stage channel mismatch", mismatch, "channels"},
+		{"truncated mft2", lut16Tag(3, 3, 9, 256)[:1000], "truncated"},
+		{"truncated mAB", lutABTag(3, 3)[:60], "too short"},
+	} {
+		_, err := parseLut(tc.tag, true)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+// TestParseLutCorrupted overwrites and truncates valid tags byte by
+// byte: parsing may fail but must not panic, and whatever parses must
+// evaluate.
+func TestParseLutCorrupted(t *testing.T) {
+	for _, valid := range [][]byte{lut16Tag(3, 3, 3, 4), lutABTag(3, 3), lutABTag(4, 3)} {
+		try := func(tag []byte) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("panic on %x: %v", tag, r)
+				}
+			}()
+			for _, toPCS := range []bool{true, false} {
+				if p, err := parseLut(tag, toPCS); err == nil {
+					p.eval(make([]float64, p.inputs))
+				}
+			}
+		}
+		for n := range valid {
+			try(valid[:n])
+		}
+		for i := range valid {
+			for _, v := range []byte{0, 1, 2, 16, 0x7F, 0xFF} {
+				tag := append([]byte(nil), valid...)
+				tag[i] = v
+				try(tag)
+			}
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Package icc reads ICC v2/v4 colour profiles and converts colours
+// between them through the profile connection space (PCS).
+package icc
+
+import (
+	"colors-cli/utils/colors"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// -------------------------------
+// Rendering intents
+// -------------------------------
+type Intent int
+
+const (
+	Perceptual Intent = iota
+	RelativeColorimetric
+	Saturation
+	AbsoluteColorimetric
+)
+
+var intentNames = []string{"perceptual", "relative", "saturation", "absolute"}
+
+func (i Intent) String() string {
+	if i < Perceptual || i > AbsoluteColorimetric {
+		return "unknown"
+	}
+	return intentNames[i]
+}
+
+// ParseIntent accepts perceptual, relative, saturation and absolute
+// (with or without the "-colorimetric" suffix).
+func ParseIntent(s string) (Intent, error) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "-colorimetric")
+	for i, name := range intentNames {
+		if s == name {
+			return Intent(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rendering intent %q (perceptual, relative, saturation, absolute)", s)
+}
+
+// -------------------------------
+// Profile
+// -------------------------------
+type Profile struct {
+	Version     uint32 // major in the top byte, e.g. 0x04300000 for 4.3
+	Class       string // mntr, prtr, scnr, spac, abst, link, nmcl
+	ColorSpace  string // data colour space: RGB, CMYK, GRAY, Lab, …
+	PCS         string // XYZ or Lab
+	Intent      Intent // default rendering intent from the header
+	Description string
+	Copyright   string
+
+	MediaWhite colors.XYZ // wtpt, D50 when absent
+
+	tags map[string][]byte
+
+	// matrix/TRC model (RGB and gray display profiles)
+	matrix *[3][3]float64
+	trc    []Curve
+
+	// LUT-based transforms indexed by intent (0–2)
+	a2b [3]pipeline
+	b2a [3]pipeline
+}
+
+// D50 is the PCS illuminant as encoded in profile headers.
+var D50 = colors.XYZ{X: 0.9642, Y: 1, Z: 0.8249}
+
+// Channels returns the number of device channels of the profile's data
+// colour space.
+func (p *Profile) Channels() int {
+	return channelCount(p.ColorSpace)
+}
+
+func channelCount(space string) int {
+	switch space {
+	case "GRAY":
+		return 1
+	case "CMYK":
+		return 4
+	}
+	// 2CLR … FCLR: the count is the leading hex digit
+	if len(space) == 4 && strings.HasSuffix(space, "CLR") {
+		if n, err := strconv.ParseUint(space[:1], 16, 8); err == nil && n >= 2 {
+			return int(n)
+		}
+	}
+	return 3
+}
+
+// VersionString formats the header version, e.g. "4.3".
+func (p *Profile) VersionString() string {
+	return fmt.Sprintf("%d.%d", p.Version>>24, (p.Version>>20)&0xF)
+}
+
+// Tag returns the raw bytes of a tag.
+func (p *Profile) Tag(sig string) ([]byte, bool) {
+	b, ok := p.tags[sig]
+	return b, ok
+}
+
+// Tags lists the tag signatures present in the profile.
+func (p *Profile) Tags() []string {
+	out := make([]string, 0, len(p.tags))
+	for sig := range p.tags {
+		out = append(out, sig)
+	}
+	return out
+}
+
+// -------------------------------
+// Reading
+// -------------------------------
+
+// Open reads a profile from disk.
+func Open(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse decodes an ICC profile.
+func Parse(data []byte) (*Profile, error) {
+	if len(data) < 132 {
+		return nil, errors.New("profile too short")
+	}
+	if string(data[36:40]) != "acsp" {
+		return nil, errors.New("missing 'acsp' signature")
+	}
+	size := binary.BigEndian.Uint32(data[0:4])
+	if int(size) > len(data) {
+		return nil, fmt.Errorf("profile truncated: header says %d bytes, have %d", size, len(data))
+	}
+
+	p := &Profile{
+		Version:    binary.BigEndian.Uint32(data[8:12]),
+		Class:      strings.TrimSpace(string(data[12:16])),
+		ColorSpace: strings.TrimSpace(string(data[16:20])),
+		PCS:        strings.TrimSpace(string(data[20:24])),
+		Intent:     Intent(binary.BigEndian.Uint32(data[64:68]) & 3),
+		MediaWhite: D50,
+		tags:       map[string][]byte{},
+	}
+	if p.PCS != "XYZ" && p.PCS != "Lab" {
+		return nil, fmt.Errorf("unsupported PCS %q", p.PCS)
+	}
+
+	count := int(binary.BigEndian.Uint32(data[128:132]))
+	if 132+count*12 > len(data) {
+		return nil, errors.New("tag table truncated")
+	}
+	for i := 0; i < count; i++ {
+		entry := data[132+i*12:]
+		sig := string(entry[0:4])
+		off := int(binary.BigEndian.Uint32(entry[4:8]))
+		n := int(binary.BigEndian.Uint32(entry[8:12]))
+		if off < 0 || n < 0 || off+n > len(data) {
+			return nil, fmt.Errorf("tag %q out of bounds", sig)
+		}
+		p.tags[sig] = data[off : off+n]
+	}
+
+	if err := p.parseTags(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Profile) parseTags() error {
+	if b, ok := p.tags["desc"]; ok {
+		p.Description = parseText(b)
+	}
+	if b, ok := p.tags["cprt"]; ok {
+		p.Copyright = parseText(b)
+	}
+	if b, ok := p.tags["wtpt"]; ok {
+		if xyz, err := parseXYZ(b); err == nil {
+			p.MediaWhite = xyz
+		}
+	}
+
+	// matrix/TRC
+	rc, rOK := p.tags["rXYZ"]
+	gc, gOK := p.tags["gXYZ"]
+	bc, bOK := p.tags["bXYZ"]
+	if rOK && gOK && bOK && p.ColorSpace == "RGB" {
+		r, err1 := parseXYZ(rc)
+		g, err2 := parseXYZ(gc)
+		b, err3 := parseXYZ(bc)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return fmt.Errorf("colorant tags: %w", err)
+		}
+		p.matrix = &[3][3]float64{
+			{r.X, g.X, b.X},
+			{r.Y, g.Y, b.Y},
+			{r.Z, g.Z, b.Z},
+		}
+		for _, sig := range []string{"rTRC", "gTRC", "bTRC"} {
+			b, ok := p.tags[sig]
+			if !ok {
+				return fmt.Errorf("missing %s", sig)
+			}
+			c, _, err := parseCurve(b)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sig, err)
+			}
+			p.trc = append(p.trc, c)
+		}
+	}
+	if b, ok := p.tags["kTRC"]; ok && p.ColorSpace == "GRAY" {
+		c, _, err := parseCurve(b)
+		if err != nil {
+			return fmt.Errorf("kTRC: %w", err)
+		}
+		p.trc = []Curve{c}
+	}
+
+	// LUTs, which must fit the device and PCS channel counts
+	for i := 0; i < 3; i++ {
+		if b, ok := p.tags[fmt.Sprintf("A2B%d", i)]; ok {
+			pl, err := parseLut(b, true)
+			if err == nil && (pl.inputs != p.Channels() || pl.outputs != 3) {
+				err = fmt.Errorf("%d→%d channels, want %d→3", pl.inputs, pl.outputs, p.Channels())
+			}
+			if err != nil {
+				return fmt.Errorf("A2B%d: %w", i, err)
+			}
+			p.a2b[i] = pl
+		}
+		if b, ok := p.tags[fmt.Sprintf("B2A%d", i)]; ok {
+			pl, err := parseLut(b, false)
+			if err == nil && (pl.inputs != 3 || pl.outputs != p.Channels()) {
+				err = fmt.Errorf("%d→%d channels, want 3→%d", pl.inputs, pl.outputs, p.Channels())
+			}
+			if err != nil {
+				return fmt.Errorf("B2A%d: %w", i, err)
+			}
+			p.b2a[i] = pl
+		}
+	}
+	return nil
+}
+
+// -------------------------------
+// Basic tag types
+// -------------------------------
+
+func s15Fixed16(b []byte) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b))) / 65536
+}
+
+func parseXYZ(b []byte) (colors.XYZ, error) {
+	if len(b) < 20 || string(b[0:4]) != "XYZ " {
+		return colors.XYZ{}, errors.New("not an XYZType")
+	}
+	return colors.XYZ{X: s15Fixed16(b[8:]), Y: s15Fixed16(b[12:]), Z: s15Fixed16(b[16:])}, nil
+}
+
+// parseText handles textDescriptionType (v2), multiLocalizedUnicodeType
+// (v4) and textType, returning the first (English if present) string.
+func parseText(b []byte) string {
+	if len(b) < 12 {
+		return ""
+	}
+	switch string(b[0:4]) {
+	case "desc":
+		n := int(binary.BigEndian.Uint32(b[8:12]))
+		if 12+n > len(b) {
+			return ""
+		}
+		return strings.TrimRight(string(b[12:12+n]), "\x00")
+	case "text":
+		return strings.TrimRight(string(b[8:]), "\x00")
+	case "mluc":
+		if len(b) < 16 {
+			return ""
+		}
+		count := int(binary.BigEndian.Uint32(b[8:12]))
+		recSize := int(binary.BigEndian.Uint32(b[12:16]))
+		if recSize < 12 {
+			return ""
+		}
+		best := ""
+		for i := 0; i < count; i++ {
+			rec := 16 + i*recSize
+			if rec+12 > len(b) {
+				break
+			}
+			lang := string(b[rec : rec+2])
+			n := int(binary.BigEndian.Uint32(b[rec+4 : rec+8]))
+			off := int(binary.BigEndian.Uint32(b[rec+8 : rec+12]))
+			if off+n > len(b) {
+				continue
+			}
+			s := decodeUTF16(b[off : off+n])
+			if best == "" || lang == "en" {
+				best = s
+			}
+			if lang == "en" {
+				break
+			}
+		}
+		return best
+	}
+	return ""
+}
+
+func decodeUTF16(b []byte) string {
+	runes := make([]rune, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		u := rune(binary.BigEndian.Uint16(b[i:]))
+		if u >= 0xD800 && u < 0xDC00 && i+3 < len(b) {
+			lo := rune(binary.BigEndian.Uint16(b[i+2:]))
+			u = 0x10000 + (u-0xD800)<<10 + (lo - 0xDC00)
+			i += 2
+		}
+		runes = append(runes, u)
+	}
+	return strings.TrimRight(string(runes), "\x00")
+}
@@ -0,0 +1,40 @@
+package icc
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestParseTextMLUC(t *testing.T) {
+	mluc := func(count, recSize uint32) []byte {
+		b := make([]byte, 28, 32)
+		copy(b, "mluc")
+		binary.BigEndian.PutUint32(b[8:], count)
+		binary.BigEndian.PutUint32(b[12:], recSize)
+		copy(b[16:], "enUS")
+		binary.BigEndian.PutUint32(b[20:], 4)
+		binary.BigEndian.PutUint32(b[24:], 28)
+		return append(b, 0, 'h', 0, 'i')
+	}
+
+	if got := parseText(mluc(1, 12)); got != "hi" {
+		t.Errorf("parseText = %q, want %q", got, "hi")
+	}
+	// a record size of 0 would revisit the first record count times
+	for _, recSize := range []uint32{0, 4, 11} {
+		if got := parseText(mluc(0xFFFFFFFF, recSize)); got != "" {
+			t.Errorf("recSize %d: parseText = %q, want \"\"", recSize, got)
+		}
+	}
+	if got := parseText(mluc(0xFFFFFFFF, 12)); got != "hi" {
+		t.Errorf("huge count: parseText = %q, want %q", got, "hi")
+	}
+}
+
+func TestChannelCount(t *testing.T) {
+	for space, want := range map[string]int{"GRAY": 1, "RGB": 3, "CMYK": 4, "4CLR": 4, "9CLR": 9, "FCLR": 15, "Lab": 3} {
+		if got := channelCount(space); got != want {
+			t.Errorf("channelCount(%q) = %d, want %d", space, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package icc
+
+import (
+	"colors-cli/utils/colors"
+	"errors"
+	"fmt"
+)
+
+// -------------------------------
+// PCS encoding
+// -------------------------------
+
+// decodePCS turns normalised PCS values from a LUT into XYZ (D50).
+func (p *Profile) decodePCS(pl *pipeline, v []float64) colors.XYZ {
+	if p.PCS == "Lab" {
+		s := pl.pcsLabScale
+		lab := colors.Lab{L: v[0] * s * 100, A: v[1]*s*255 - 128, B: v[2]*s*255 - 128}
+		return lab.ToXYZ(D50)
+	}
+	k := 65535.0 / 32768.0
+	return colors.XYZ{X: v[0] * k, Y: v[1] * k, Z: v[2] * k}
+}
+
+// encodePCS turns XYZ (D50) into normalised PCS values for a LUT.
+func (p *Profile) encodePCS(pl *pipeline, xyz colors.XYZ) []float64 {
+	if p.PCS == "Lab" {
+		s := pl.pcsLabScale
+		lab := xyz.ToLab(D50)
+		return []float64{
+			clamp01(lab.L / 100 / s),
+			clamp01((lab.A + 128) / 255 / s),
+			clamp01((lab.B + 128) / 255 / s),
+		}
+	}
+	k := 32768.0 / 65535.0
+	return []float64{clamp01(xyz.X * k), clamp01(xyz.Y * k), clamp01(xyz.Z * k)}
+}
+
+// lutFor picks the LUT for an intent, falling back to the perceptual
+// table as the specification requires.
+func lutFor(tables [3]pipeline, intent Intent) *pipeline {
+	i := int(intent)
+	if intent == AbsoluteColorimetric {
+		i = int(RelativeColorimetric)
+	}
+	if tables[i].stages != nil {
+		return &tables[i]
+	}
+	if tables[0].stages != nil {
+		return &tables[0]
+	}
+	return nil
+}
+
+// -------------------------------
+// Device → PCS
+// -------------------------------
+
+// ToPCS converts device values (0–1 per channel) to PCS XYZ relative
+// to D50, or media-relative XYZ scaled to absolute for that intent.
+func (p *Profile) ToPCS(device []float64, intent Intent) (colors.XYZ, error) {
+	if len(device) != p.Channels() {
+		return colors.XYZ{}, fmt.Errorf("%s profile expects %d channels, got %d", p.ColorSpace, p.Channels(), len(device))
+	}
+
+	var xyz colors.XYZ
+	switch {
+	case lutFor(p.a2b, intent) != nil:
+		pl := lutFor(p.a2b, intent)
+		xyz = p.decodePCS(pl, pl.eval(device))
+	case p.matrix != nil:
+		m := p.matrix
+		lin := [3]float64{}
+		for i := 0; i < 3; i++ {
+			lin[i] = p.trc[i].Eval(clamp01(device[i]))
+		}
+		xyz = colors.XYZ{
+			X: m[0][0]*lin[0] + m[0][1]*lin[1] + m[0][2]*lin[2],
+			Y: m[1][0]*lin[0] + m[1][1]*lin[1] + m[1][2]*lin[2],
+			Z: m[2][0]*lin[0] + m[2][1]*lin[1] + m[2][2]*lin[2],
+		}
+	case len(p.trc) == 1:
+		y := p.trc[0].Eval(clamp01(device[0]))
+		xyz = colors.XYZ{X: D50.X * y, Y: y, Z: D50.Z * y}
+	default:
+		return colors.XYZ{}, errors.New("profile has no device-to-PCS transform")
+	}
+
+	if intent == AbsoluteColorimetric {
+		w := p.MediaWhite
+		xyz = colors.XYZ{X: xyz.X * w.X / D50.X, Y: xyz.Y * w.Y / D50.Y, Z: xyz.Z * w.Z / D50.Z}
+	}
+	return xyz, nil
+}
+
+// -------------------------------
+// PCS → Device
+// -------------------------------
+
+// FromPCS converts PCS XYZ (D50) to device values (0–1 per channel).
+func (p *Profile) FromPCS(xyz colors.XYZ, intent Intent) ([]float64, error) {
+	if intent == AbsoluteColorimetric {
+		w := p.MediaWhite
+		xyz = colors.XYZ{X: xyz.X * D50.X / w.X, Y: xyz.Y * D50.Y / w.Y, Z: xyz.Z * D50.Z / w.Z}
+	}
+
+	switch {
+	case lutFor(p.b2a, intent) != nil:
+		pl := lutFor(p.b2a, intent)
+		out := pl.eval(p.encodePCS(pl, xyz))
+		for i := range out {
+			out[i] = clamp01(out[i])
+		}
+		return out, nil
+	case p.matrix != nil:
+		m := *p.matrix
+		inv, ok := invert3(m)
+		if !ok {
+			return nil, errors.New("singular colorant matrix")
+		}
+		out := make([]float64, 3)
+		for i := 0; i < 3; i++ {
+			lin := inv[i][0]*xyz.X + inv[i][1]*xyz.Y + inv[i][2]*xyz.Z
+			out[i] = clamp01(invert(p.trc[i], clamp01(lin)))
+		}
+		return out, nil
+	case len(p.trc) == 1:
+		return []float64{clamp01(invert(p.trc[0], clamp01(xyz.Y)))}, nil
+	}
+	return nil, errors.New("profile has no PCS-to-device transform")
+}
+
+func invert3(m [3][3]float64) ([3][3]float64, bool) {
+	d := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	if d == 0 {
+		return [3][3]float64{}, false
+	}
+	var inv [3][3]float64
+	inv[0][0] = (m[1][1]*m[2][2] - m[1][2]*m[2][1]) / d
+	inv[0][1] = (m[0][2]*m[2][1] - m[0][1]*m[2][2]) / d
+	inv[0][2] = (m[0][1]*m[1][2] - m[0][2]*m[1][1]) / d
+	inv[1][0] = (m[1][2]*m[2][0] - m[1][0]*m[2][2]) / d
+	inv[1][1] = (m[0][0]*m[2][2] - m[0][2]*m[2][0]) / d
+	inv[1][2] = (m[0][2]*m[1][0] - m[0][0]*m[1][2]) / d
+	inv[2][0] = (m[1][0]*m[2][1] - m[1][1]*m[2][0]) / d
+	inv[2][1] = (m[0][1]*m[2][0] - m[0][0]*m[2][1]) / d
+	inv[2][2] = (m[0][0]*m[1][1] - m[0][1]*m[1][0]) / d
+	return inv, true
+}
+
+// -------------------------------
+// Profile → Profile
+// -------------------------------
+
+// Transform converts device values from one profile to another.
+type Transform struct {
+	Src, Dst *Profile
+	Intent   Intent
+}
+
+// NewTransform checks both profiles can take part in the conversion.
+func NewTransform(src, dst *Profile, intent Intent) (*Transform, error) {
+	if src == nil || dst == nil {
+		return nil, errors.New("source and destination profiles are required")
+	}
+	if lutFor(src.a2b, intent) == nil && src.matrix == nil && len(src.trc) != 1 {
+		return nil, fmt.Errorf("source profile %q cannot convert to PCS", src.Description)
+	}
+	if lutFor(dst.b2a, intent) == nil && dst.matrix == nil && len(dst.trc) != 1 {
+		return nil, fmt.Errorf("destination profile %q cannot convert from PCS", dst.Description)
+	}
+	return &Transform{Src: src, Dst: dst, Intent: intent}, nil
+}
+
+// Convert maps source device values (0–1) to destination device values.
+func (t *Transform) Convert(device []float64) ([]float64, error) {
+	xyz, err := t.Src.ToPCS(device, t.Intent)
+	if err != nil {
+		return nil, err
+	}
+	return t.Dst.FromPCS(xyz, t.Intent)
+}
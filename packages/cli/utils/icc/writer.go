@@ -0,0 +1,245 @@
+package icc
+
+import (
+	"colors-cli/utils/colors"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf16"
+)
+
+// -------------------------------
+// Writing (v4 matrix/TRC display profiles)
+// -------------------------------
+
+// MatrixProfile describes an RGB display profile built from primaries,
+// a white point and a transfer function.
+type MatrixProfile struct {
+	Description string
+	Copyright   string
+	Space       colors.RGBSpace
+	Created     time.Time // zero means now
+}
+
+type tagEntry struct {
+	sig  string
+	data []byte
+}
+
+// Encode writes the profile as ICC v4.3 bytes: desc, cprt, wtpt, chad,
+// the Bradford-adapted colorants and parametric TRCs, with the MD5
+// profile ID filled in.
+func (m MatrixProfile) Encode() ([]byte, error) {
+	M, err := m.Space.ToXYZMatrix()
+	if err != nil {
+		return nil, err
+	}
+	if m.Space.TRC.Gamma <= 0 || m.Space.TRC.A <= 0 {
+		return nil, errors.New("invalid transfer function")
+	}
+
+	chad := colors.AdaptationMatrix(m.Space.White, D50)
+	var adapted [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				adapted[i][j] += chad[i][k] * M[k][j]
+			}
+		}
+	}
+	column := func(j int) colors.XYZ {
+		return colors.XYZ{X: adapted[0][j], Y: adapted[1][j], Z: adapted[2][j]}
+	}
+
+	trc := encodePara(m.Space.TRC)
+	tags := []tagEntry{
+		{"desc", encodeMLUC(m.Description)},
+		{"cprt", encodeMLUC(m.Copyright)},
+		{"wtpt", encodeXYZ(D50)},
+		{"chad", encodeSF32(chad)},
+		{"rXYZ", encodeXYZ(column(0))},
+		{"gXYZ", encodeXYZ(column(1))},
+		{"bXYZ", encodeXYZ(column(2))},
+		{"rTRC", trc},
+		{"gTRC", trc},
+		{"bTRC", trc},
+	}
+
+	created := m.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+	return assemble(header{class: "mntr", space: "RGB ", pcs: "XYZ ", created: created.UTC()}, tags), nil
+}
+
+type header struct {
+	class, space, pcs string
+	created           time.Time
+}
+
+// assemble lays out the header, tag table and tag data. Tags with
+// identical data share a single element, as the spec allows.
+func assemble(h header, tags []tagEntry) []byte {
+	tableEnd := 128 + 4 + 12*len(tags)
+	body := []byte{}
+	offsets := make([]int, len(tags))
+	seen := map[string]int{}
+	for i, t := range tags {
+		if off, ok := seen[string(t.data)]; ok {
+			offsets[i] = off
+			continue
+		}
+		off := tableEnd + len(body)
+		offsets[i] = off
+		seen[string(t.data)] = off
+		body = append(body, t.data...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+
+	out := make([]byte, tableEnd, tableEnd+len(body))
+	be := binary.BigEndian
+	be.PutUint32(out[0:], uint32(tableEnd+len(body)))
+	be.PutUint32(out[8:], 0x04300000)
+	copy(out[12:16], h.class)
+	copy(out[16:20], h.space)
+	copy(out[20:24], h.pcs)
+	t := h.created
+	for i, v := range []int{t.Year(), int(t.Month()), t.Day(), t.Hour(), t.Minute(), t.Second()} {
+		be.PutUint16(out[24+2*i:], uint16(v))
+	}
+	copy(out[36:40], "acsp")
+	be.PutUint32(out[64:], uint32(Perceptual))
+	copy(out[68:80], encodeXYZ(D50)[8:20])
+
+	be.PutUint32(out[128:], uint32(len(tags)))
+	for i, tag := range tags {
+		e := out[132+12*i:]
+		copy(e[0:4], tag.sig)
+		be.PutUint32(e[4:], uint32(offsets[i]))
+		be.PutUint32(e[8:], uint32(len(tag.data)))
+	}
+	out = append(out, body...)
+
+	id := ProfileID(out)
+	copy(out[84:100], id[:])
+	return out
+}
+
+// ProfileID computes the MD5 profile ID: the digest of the whole profile
+// with the flags, rendering intent and ID fields zeroed.
+func ProfileID(data []byte) [16]byte {
+	tmp := append([]byte(nil), data...)
+	if len(tmp) >= 100 {
+		clear(tmp[44:48])
+		clear(tmp[64:68])
+		clear(tmp[84:100])
+	}
+	return md5.Sum(tmp)
+}
+
+// -------------------------------
+// Tag encoders
+// -------------------------------
+
+func putS15Fixed16(b []byte, v float64) {
+	binary.BigEndian.PutUint32(b, uint32(int32(math.Round(v*65536))))
+}
+
+func encodeXYZ(c colors.XYZ) []byte {
+	b := make([]byte, 20)
+	copy(b, "XYZ ")
+	putS15Fixed16(b[8:], c.X)
+	putS15Fixed16(b[12:], c.Y)
+	putS15Fixed16(b[16:], c.Z)
+	return b
+}
+
+func encodeSF32(m [3][3]float64) []byte {
+	b := make([]byte, 8+36)
+	copy(b, "sf32")
+	for i := 0; i < 9; i++ {
+		putS15Fixed16(b[8+4*i:], m[i/3][i%3])
+	}
+	return b
+}
+
+// encodePara writes a parametric curve of type 3, or type 0 for a pure
+// power law.
+func encodePara(t colors.TransferFunction) []byte {
+	params := []float64{t.Gamma}
+	typ := 0
+	if t.A != 1 || t.B != 0 || t.D != 0 {
+		typ = 3
+		params = append(params, t.A, t.B, t.C, t.D)
+	}
+	b := make([]byte, 12+4*len(params))
+	copy(b, "para")
+	binary.BigEndian.PutUint16(b[8:], uint16(typ))
+	for i, p := range params {
+		putS15Fixed16(b[12+4*i:], p)
+	}
+	return b
+}
+
+// encodeMLUC writes a single en-US record.
+func encodeMLUC(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	b := make([]byte, 28+2*len(units))
+	copy(b, "mluc")
+	binary.BigEndian.PutUint32(b[8:], 1)
+	binary.BigEndian.PutUint32(b[12:], 12)
+	copy(b[16:20], "enUS")
+	binary.BigEndian.PutUint32(b[20:], uint32(2*len(units)))
+	binary.BigEndian.PutUint32(b[24:], 28)
+	for i, u := range units {
+		binary.BigEndian.PutUint16(b[28+2*i:], u)
+	}
+	return b
+}
+
+// -------------------------------
+// Validation
+// -------------------------------
+
+// ValidateMatrixProfile parses an encoded profile back with the reader
+// and checks the profile ID, the white point and a ramp of primaries and
+// grays against the source space. It returns the largest ΔE00 found.
+func ValidateMatrixProfile(data []byte, space colors.RGBSpace) (float64, error) {
+	p, err := Parse(data)
+	if err != nil {
+		return 0, err
+	}
+	var stored [16]byte
+	copy(stored[:], data[84:100])
+	if stored != ProfileID(data) {
+		return 0, errors.New("profile ID does not match contents")
+	}
+	if p.Version>>24 != 4 || p.Class != "mntr" || p.ColorSpace != "RGB" || p.PCS != "XYZ" {
+		return 0, fmt.Errorf("unexpected header: v%s %s %s→%s", p.VersionString(), p.Class, p.ColorSpace, p.PCS)
+	}
+	if p.matrix == nil || len(p.trc) != 3 {
+		return 0, errors.New("missing matrix/TRC tags")
+	}
+
+	worst := 0.0
+	for _, v := range []float64{0, 0.05, 0.25, 0.5, 0.75, 1} {
+		for _, rgb := range [][3]float64{{v, 0, 0}, {0, v, 0}, {0, 0, v}, {v, v, v}} {
+			want, err := space.ToXYZ(rgb[0], rgb[1], rgb[2])
+			if err != nil {
+				return 0, err
+			}
+			got, err := p.ToPCS(rgb[:], RelativeColorimetric)
+			if err != nil {
+				return 0, err
+			}
+			d := colors.DeltaE2000(want.Adapt(space.White, D50).ToLab(D50), got.ToLab(D50))
+			worst = math.Max(worst, d)
+		}
+	}
+	return worst, nil
+}